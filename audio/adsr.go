@@ -0,0 +1,127 @@
+package audio
+
+// PS1 SPU volume envelope
+// The SPU updates the envelope at 44.1kHz, once for every output frame
+
+const (
+	ENVELOPE_MAX_LEVEL = 0x7FFF
+)
+
+type envelopePhase int
+
+const (
+	ENVELOPE_ATTACK envelopePhase = iota
+	ENVELOPE_DECAY
+	ENVELOPE_SUSTAIN
+	ENVELOPE_RELEASE
+	ENVELOPE_OFF
+)
+
+// Settings decoded from the ADSR registers of a VAB tone
+type ADSRSettings struct {
+	AttackExponential  bool
+	AttackShift        int
+	AttackStep         int
+	DecayShift         int
+	SustainLevel       int
+	SustainExponential bool
+	SustainDecrease    bool
+	SustainShift       int
+	SustainStep        int
+	ReleaseExponential bool
+	ReleaseShift       int
+}
+
+func NewADSRSettings(adsr1 uint16, adsr2 uint16) ADSRSettings {
+	settings := ADSRSettings{
+		AttackExponential:  adsr1&0x8000 != 0,
+		AttackShift:        int(adsr1>>10) & 0x1F,
+		AttackStep:         7 - int(adsr1>>8)&0x3,
+		DecayShift:         int(adsr1>>4) & 0xF,
+		SustainLevel:       min((int(adsr1&0xF)+1)*0x800, ENVELOPE_MAX_LEVEL),
+		SustainExponential: adsr2&0x8000 != 0,
+		SustainDecrease:    adsr2&0x4000 != 0,
+		SustainShift:       int(adsr2>>8) & 0x1F,
+		ReleaseExponential: adsr2&0x0020 != 0,
+		ReleaseShift:       int(adsr2) & 0x1F,
+	}
+	sustainStep := int(adsr2>>6) & 0x3
+	if settings.SustainDecrease {
+		settings.SustainStep = -8 + sustainStep
+	} else {
+		settings.SustainStep = 7 - sustainStep
+	}
+	return settings
+}
+
+type envelope struct {
+	settings ADSRSettings
+	phase    envelopePhase
+	level    int
+	counter  int
+}
+
+func newEnvelope(settings ADSRSettings) *envelope {
+	return &envelope{
+		settings: settings,
+		phase:    ENVELOPE_ATTACK,
+	}
+}
+
+func (env *envelope) gain() float32 {
+	return float32(env.level) / ENVELOPE_MAX_LEVEL
+}
+
+// Starts the release phase when the note is released
+func (env *envelope) keyOff() {
+	if env.phase != ENVELOPE_OFF {
+		env.phase = ENVELOPE_RELEASE
+		env.counter = 0
+	}
+}
+
+// Moves the envelope forward by one SPU tick
+func (env *envelope) update() {
+	switch env.phase {
+	case ENVELOPE_ATTACK:
+		env.step(env.settings.AttackShift, env.settings.AttackStep, env.settings.AttackExponential, false)
+		if env.level >= ENVELOPE_MAX_LEVEL {
+			env.phase = ENVELOPE_DECAY
+			env.counter = 0
+		}
+	case ENVELOPE_DECAY:
+		env.step(env.settings.DecayShift, -8, true, true)
+		if env.level <= env.settings.SustainLevel {
+			env.phase = ENVELOPE_SUSTAIN
+			env.counter = 0
+		}
+	case ENVELOPE_SUSTAIN:
+		env.step(env.settings.SustainShift, env.settings.SustainStep, env.settings.SustainExponential, env.settings.SustainDecrease)
+	case ENVELOPE_RELEASE:
+		env.step(env.settings.ReleaseShift, -8, env.settings.ReleaseExponential, true)
+		if env.level <= 0 {
+			env.phase = ENVELOPE_OFF
+		}
+	}
+}
+
+// Large shifts wait more ticks between steps, small shifts use larger steps
+func (env *envelope) step(shift int, step int, exponential bool, decrease bool) {
+	cycles := 1 << max(shift-11, 0)
+	adjustedStep := step << max(11-shift, 0)
+	if exponential {
+		if decrease {
+			// Keep decreasing so the release always reaches silence
+			adjustedStep = min(adjustedStep*env.level/0x8000, -1)
+		} else if env.level > 0x6000 {
+			cycles *= 4
+		}
+	}
+
+	env.counter++
+	if env.counter < cycles {
+		return
+	}
+	env.counter = 0
+	env.level = min(max(env.level+adjustedStep, 0), ENVELOPE_MAX_LEVEL)
+}
@@ -0,0 +1,36 @@
+package audio
+
+// Output for the mixed samples
+type Backend interface {
+	Open(sampleRate int, channels int) error
+	Write(samples []int16) error
+	Close() error
+	// Realtime backends block in Write until the device needs more samples
+	IsRealtime() bool
+}
+
+// Discards all samples, used when there is no audio device
+type NullBackend struct {
+	SamplesWritten int
+}
+
+func NewNullBackend() *NullBackend {
+	return &NullBackend{}
+}
+
+func (backend *NullBackend) Open(sampleRate int, channels int) error {
+	return nil
+}
+
+func (backend *NullBackend) Write(samples []int16) error {
+	backend.SamplesWritten += len(samples)
+	return nil
+}
+
+func (backend *NullBackend) Close() error {
+	return nil
+}
+
+func (backend *NullBackend) IsRealtime() bool {
+	return false
+}
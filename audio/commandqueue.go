@@ -0,0 +1,109 @@
+package audio
+
+import (
+	"sync/atomic"
+)
+
+// Must be a power of two so the indices wrap around
+const COMMAND_QUEUE_SIZE = 256
+
+type commandType int
+
+const (
+	COMMAND_PLAY commandType = iota
+	COMMAND_STOP
+	COMMAND_STOP_ALL
+	COMMAND_RELEASE
+	COMMAND_PAUSE
+	COMMAND_RESUME
+	COMMAND_SET_VOLUME
+	COMMAND_SET_PAN
+	COMMAND_SET_PITCH
+	COMMAND_FADE
+	COMMAND_SET_MASTER_VOLUME
+	COMMAND_SET_REVERB
+)
+
+type command struct {
+	Type          commandType
+	VoiceId       VoiceId
+	Sound         *Sound
+	Params        VoiceParams
+	Status        *VoiceStatus
+	Reverb        *Reverb
+	Value         float32
+	Seconds       float64
+	StopAfterFade bool
+}
+
+// Lock free ring buffer with one producer (game loop) and one consumer (mixer)
+type commandQueue struct {
+	commands [COMMAND_QUEUE_SIZE]command
+	head     atomic.Uint64 // Next command to read, only written by the consumer
+	tail     atomic.Uint64 // Next free slot, only written by the producer
+}
+
+// Returns false if the queue is full and the command was dropped
+func (queue *commandQueue) push(cmd command) bool {
+	tail := queue.tail.Load()
+	if tail-queue.head.Load() >= COMMAND_QUEUE_SIZE {
+		return false
+	}
+	queue.commands[tail%COMMAND_QUEUE_SIZE] = cmd
+	queue.tail.Store(tail + 1)
+	return true
+}
+
+func (queue *commandQueue) pop() (command, bool) {
+	head := queue.head.Load()
+	if head == queue.tail.Load() {
+		return command{}, false
+	}
+	index := head % COMMAND_QUEUE_SIZE
+	cmd := queue.commands[index]
+	// Release the sound so it can be garbage collected
+	queue.commands[index] = command{}
+	queue.head.Store(head + 1)
+	return cmd, true
+}
+
+func (mixer *Mixer) applyCommand(cmd command) {
+	switch cmd.Type {
+	case COMMAND_PLAY:
+		mixer.play(cmd.VoiceId, cmd.Sound, cmd.Params, cmd.Status)
+	case COMMAND_STOP:
+		mixer.stop(cmd.VoiceId)
+	case COMMAND_STOP_ALL:
+		mixer.stopAll()
+	case COMMAND_RELEASE:
+		if v := mixer.findVoice(cmd.VoiceId); v != nil {
+			v.release()
+		}
+		mixer.removeStoppedVoices()
+	case COMMAND_PAUSE, COMMAND_RESUME:
+		if v := mixer.findVoice(cmd.VoiceId); v != nil {
+			v.paused = cmd.Type == COMMAND_PAUSE
+		}
+	case COMMAND_SET_VOLUME:
+		if v := mixer.findVoice(cmd.VoiceId); v != nil {
+			v.params.Volume = cmd.Value
+		}
+	case COMMAND_SET_PAN:
+		if v := mixer.findVoice(cmd.VoiceId); v != nil {
+			v.params.Pan = cmd.Value
+		}
+	case COMMAND_SET_PITCH:
+		if v := mixer.findVoice(cmd.VoiceId); v != nil {
+			v.params.Pitch = cmd.Value
+		}
+	case COMMAND_FADE:
+		if v := mixer.findVoice(cmd.VoiceId); v != nil {
+			v.fade(cmd.Value, cmd.Seconds, cmd.StopAfterFade, mixer.SampleRate)
+		}
+		mixer.removeStoppedVoices()
+	case COMMAND_SET_MASTER_VOLUME:
+		mixer.MasterVolume = cmd.Value
+	case COMMAND_SET_REVERB:
+		mixer.reverb = cmd.Reverb
+	}
+}
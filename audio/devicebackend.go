@@ -0,0 +1,143 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ebitengine/oto/v3"
+)
+
+// Mixed blocks waiting for the sound card
+// Write blocks once they are all full, which keeps the mixer in time with the device
+const DEVICE_QUEUED_BLOCKS = 2
+
+// Only one device context can be made for the whole program
+var (
+	deviceContext     *oto.Context
+	deviceContextErr  error
+	deviceContextOnce sync.Once
+)
+
+// Plays the samples on the sound card
+// The device pulls the mixed blocks on its own goroutine and plays silence if the mixer falls behind
+type DeviceBackend struct {
+	player *oto.Player
+
+	blocks     chan []byte // Blocks ready to play
+	free       chan []byte // Blocks that were played and can be reused
+	current    []byte      // Block being read by the device
+	readOffset int
+	closed     chan struct{}
+}
+
+func NewDeviceBackend() *DeviceBackend {
+	return &DeviceBackend{}
+}
+
+func getDeviceContext(sampleRate int, channels int) (*oto.Context, error) {
+	deviceContextOnce.Do(func() {
+		blockDuration := time.Duration(MIX_BLOCK_FRAMES) * time.Second / time.Duration(sampleRate)
+		context, ready, err := oto.NewContext(&oto.NewContextOptions{
+			SampleRate:   sampleRate,
+			ChannelCount: channels,
+			Format:       oto.FormatSignedInt16LE,
+			BufferSize:   blockDuration * DEVICE_QUEUED_BLOCKS,
+		})
+		if err != nil {
+			deviceContextErr = err
+			return
+		}
+		<-ready
+		deviceContext = context
+	})
+	return deviceContext, deviceContextErr
+}
+
+func (backend *DeviceBackend) Open(sampleRate int, channels int) error {
+	context, err := getDeviceContext(sampleRate, channels)
+	if err != nil {
+		return fmt.Errorf("failed to open audio device: %w", err)
+	}
+
+	backend.makeBlocks(channels)
+	backend.player = context.NewPlayer(backend)
+	backend.player.SetBufferSize(MIX_BLOCK_FRAMES * channels * 2)
+	backend.player.Play()
+	return nil
+}
+
+// One more block than the queue holds, so the mixer can fill one while the device plays the others
+func (backend *DeviceBackend) makeBlocks(channels int) {
+	blockSize := MIX_BLOCK_FRAMES * channels * 2
+	backend.blocks = make(chan []byte, DEVICE_QUEUED_BLOCKS)
+	backend.free = make(chan []byte, DEVICE_QUEUED_BLOCKS+1)
+	for i := 0; i < DEVICE_QUEUED_BLOCKS+1; i++ {
+		backend.free <- make([]byte, 0, blockSize)
+	}
+	backend.closed = make(chan struct{})
+}
+
+func (backend *DeviceBackend) Write(samples []int16) error {
+	var block []byte
+	select {
+	case block = <-backend.free:
+	case <-backend.closed:
+		return fmt.Errorf("audio device is closed")
+	}
+	block = block[:0]
+	for _, sample := range samples {
+		block = binary.LittleEndian.AppendUint16(block, uint16(sample))
+	}
+
+	select {
+	case backend.blocks <- block:
+	case <-backend.closed:
+		return fmt.Errorf("audio device is closed")
+	}
+	if deviceContext == nil {
+		return nil
+	}
+	if err := deviceContext.Err(); err != nil {
+		return fmt.Errorf("failed to write to audio device: %w", err)
+	}
+	return nil
+}
+
+// Called by the device when it needs more samples
+func (backend *DeviceBackend) Read(buffer []byte) (int, error) {
+	if backend.current == nil {
+		select {
+		case block := <-backend.blocks:
+			backend.current = block
+			backend.readOffset = 0
+		default:
+			// The mixer fell behind, so the gap is silent
+			clear(buffer)
+			return len(buffer), nil
+		}
+	}
+
+	n := copy(buffer, backend.current[backend.readOffset:])
+	backend.readOffset += n
+	if backend.readOffset == len(backend.current) {
+		backend.free <- backend.current
+		backend.current = nil
+	}
+	return n, nil
+}
+
+func (backend *DeviceBackend) Close() error {
+	if backend.player == nil {
+		return nil
+	}
+	close(backend.closed)
+	err := backend.player.Close()
+	backend.player = nil
+	return err
+}
+
+func (backend *DeviceBackend) IsRealtime() bool {
+	return true
+}
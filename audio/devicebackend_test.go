@@ -0,0 +1,57 @@
+package audio
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDeviceBackendReadsWrittenBlocks(t *testing.T) {
+	backend := NewDeviceBackend()
+	backend.makeBlocks(CHANNELS)
+
+	if err := backend.Write([]int16{1, -2}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The device can read a block in several parts
+	buffer := make([]byte, 2)
+	backend.Read(buffer)
+	if !bytes.Equal(buffer, []byte{0x01, 0x00}) {
+		t.Errorf("Expected first sample, got %v", buffer)
+	}
+	backend.Read(buffer)
+	if !bytes.Equal(buffer, []byte{0xFE, 0xFF}) {
+		t.Errorf("Expected second sample, got %v", buffer)
+	}
+
+	// Nothing is queued, so the device gets silence
+	buffer = []byte{1, 2, 3, 4}
+	if n, _ := backend.Read(buffer); n != len(buffer) || !bytes.Equal(buffer, []byte{0, 0, 0, 0}) {
+		t.Errorf("Expected silence, got %v", buffer[:n])
+	}
+}
+
+func TestDeviceBackendWriteBlocksWhenFull(t *testing.T) {
+	backend := NewDeviceBackend()
+	backend.makeBlocks(CHANNELS)
+	for i := 0; i < DEVICE_QUEUED_BLOCKS; i++ {
+		if err := backend.Write(make([]int16, 4)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	written := make(chan error)
+	go func() {
+		written <- backend.Write(make([]int16, 4))
+	}()
+	select {
+	case <-written:
+		t.Fatal("Expected write to wait for the device")
+	default:
+	}
+
+	backend.Read(make([]byte, 16))
+	if err := <-written; err != nil {
+		t.Error(err)
+	}
+}
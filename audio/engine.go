@@ -0,0 +1,173 @@
+package audio
+
+import (
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// Frames mixed at a time, about 23ms at 44.1kHz
+const MIX_BLOCK_FRAMES = 1024
+
+// Runs the mixer on its own goroutine
+// The game loop sends commands through a lock free queue so it never waits on audio
+type Engine struct {
+	mixer       *Mixer
+	backend     Backend
+	commands    *commandQueue
+	nextVoiceId atomic.Uint32
+	blockBuffer []int16
+
+	running atomic.Bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func NewEngine(backend Backend) *Engine {
+	return &Engine{
+		mixer:       NewMixer(SAMPLE_RATE),
+		backend:     backend,
+		commands:    &commandQueue{},
+		blockBuffer: make([]int16, MIX_BLOCK_FRAMES*CHANNELS),
+	}
+}
+
+func (engine *Engine) Start() error {
+	if err := engine.backend.Open(SAMPLE_RATE, CHANNELS); err != nil {
+		return err
+	}
+	engine.stop = make(chan struct{})
+	engine.done = make(chan struct{})
+	engine.running.Store(true)
+	go engine.run()
+	return nil
+}
+
+func (engine *Engine) IsRunning() bool {
+	return engine.running.Load()
+}
+
+// Stops the audio goroutine and closes the backend
+func (engine *Engine) Close() error {
+	if !engine.running.Load() {
+		return nil
+	}
+	close(engine.stop)
+	<-engine.done
+	engine.running.Store(false)
+	return engine.backend.Close()
+}
+
+func (engine *Engine) run() {
+	defer close(engine.done)
+
+	// Backends that don't block are paced with the clock
+	blockDuration := time.Duration(MIX_BLOCK_FRAMES) * time.Second / SAMPLE_RATE
+	nextBlockTime := time.Now()
+	for {
+		select {
+		case <-engine.stop:
+			return
+		default:
+		}
+
+		// Keep mixing without output so the command queue is still drained
+		if err := engine.MixBlock(); err != nil {
+			log.Print("AUDIO: Sound is disabled: ", err)
+			engine.backend.Close()
+			engine.backend = NewNullBackend()
+		}
+
+		if !engine.backend.IsRealtime() {
+			nextBlockTime = nextBlockTime.Add(blockDuration)
+			time.Sleep(time.Until(nextBlockTime))
+		}
+	}
+}
+
+// Applies the pending commands, mixes one block and sends it to the backend
+// Called by the audio goroutine, or directly by tests when the engine is not started
+func (engine *Engine) MixBlock() error {
+	for {
+		cmd, ok := engine.commands.pop()
+		if !ok {
+			break
+		}
+		engine.mixer.applyCommand(cmd)
+	}
+	engine.mixer.Mix(engine.blockBuffer)
+	return engine.backend.Write(engine.blockBuffer)
+}
+
+func (engine *Engine) send(cmd command) {
+	if !engine.commands.push(cmd) && engine.running.Load() {
+		log.Print("AUDIO: Command queue is full, dropping command")
+	}
+}
+
+// Returns the id used to control the voice while it plays
+func (engine *Engine) Play(sound *Sound, params VoiceParams) VoiceId {
+	id := VoiceId(engine.nextVoiceId.Add(1))
+	engine.send(command{Type: COMMAND_PLAY, VoiceId: id, Sound: sound, Params: params})
+	return id
+}
+
+// The status is marked finished when the voice ends or is stopped
+func (engine *Engine) PlayWithStatus(sound *Sound, params VoiceParams) (VoiceId, *VoiceStatus) {
+	id := VoiceId(engine.nextVoiceId.Add(1))
+	status := &VoiceStatus{}
+	engine.send(command{Type: COMMAND_PLAY, VoiceId: id, Sound: sound, Params: params, Status: status})
+	return id, status
+}
+
+func (engine *Engine) Stop(id VoiceId) {
+	engine.send(command{Type: COMMAND_STOP, VoiceId: id})
+}
+
+// Starts the release phase of the voice envelope
+func (engine *Engine) Release(id VoiceId) {
+	engine.send(command{Type: COMMAND_RELEASE, VoiceId: id})
+}
+
+func (engine *Engine) Pause(id VoiceId) {
+	engine.send(command{Type: COMMAND_PAUSE, VoiceId: id})
+}
+
+func (engine *Engine) Resume(id VoiceId) {
+	engine.send(command{Type: COMMAND_RESUME, VoiceId: id})
+}
+
+func (engine *Engine) StopAll() {
+	engine.send(command{Type: COMMAND_STOP_ALL})
+}
+
+func (engine *Engine) SetVolume(id VoiceId, volume float32) {
+	engine.send(command{Type: COMMAND_SET_VOLUME, VoiceId: id, Value: volume})
+}
+
+func (engine *Engine) SetPan(id VoiceId, pan float32) {
+	engine.send(command{Type: COMMAND_SET_PAN, VoiceId: id, Value: pan})
+}
+
+func (engine *Engine) SetPitch(id VoiceId, pitch float32) {
+	engine.send(command{Type: COMMAND_SET_PITCH, VoiceId: id, Value: pitch})
+}
+
+// Changes the voice gain over time, the voice can stop once the fade ends
+func (engine *Engine) Fade(id VoiceId, targetGain float32, seconds float64, stopAfterFade bool) {
+	engine.send(command{Type: COMMAND_FADE, VoiceId: id, Value: targetGain, Seconds: seconds, StopAfterFade: stopAfterFade})
+}
+
+func (engine *Engine) SetMasterVolume(volume float32) {
+	engine.send(command{Type: COMMAND_SET_MASTER_VOLUME, Value: volume})
+}
+
+// Switches the reverb bus to a preset, REVERB_TYPE_OFF removes it
+// The tail of the old preset is cut, so this is meant for room changes
+func (engine *Engine) SetReverb(reverbType int, depth float32) {
+	var reverb *Reverb
+	if preset := GetReverbPreset(reverbType); preset != nil {
+		reverb = NewReverb(preset, depth)
+	}
+	engine.send(command{Type: COMMAND_SET_REVERB, Reverb: reverb})
+}
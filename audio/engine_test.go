@@ -0,0 +1,107 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCommandQueueFull(t *testing.T) {
+	queue := &commandQueue{}
+	for i := 0; i < COMMAND_QUEUE_SIZE; i++ {
+		if !queue.push(command{VoiceId: VoiceId(i)}) {
+			t.Fatalf("Expected push %d to succeed", i)
+		}
+	}
+	if queue.push(command{}) {
+		t.Error("Expected push to fail when the queue is full")
+	}
+
+	cmd, ok := queue.pop()
+	if !ok || cmd.VoiceId != 0 {
+		t.Errorf("Expected first command, got %v %v", cmd, ok)
+	}
+	if !queue.push(command{}) {
+		t.Error("Expected push to succeed after pop")
+	}
+}
+
+func TestEngineMixBlockAppliesCommands(t *testing.T) {
+	backend := NewNullBackend()
+	engine := NewEngine(backend)
+	id := engine.Play(newConstantSound(1000, SAMPLE_RATE, SAMPLE_RATE), DefaultVoiceParams())
+
+	if err := engine.MixBlock(); err != nil {
+		t.Fatal(err)
+	}
+	if engine.mixer.findVoice(id) == nil {
+		t.Error("Expected voice to be playing")
+	}
+	if backend.SamplesWritten != MIX_BLOCK_FRAMES*CHANNELS {
+		t.Errorf("Expected %d samples, got %d", MIX_BLOCK_FRAMES*CHANNELS, backend.SamplesWritten)
+	}
+
+	engine.Stop(id)
+	engine.MixBlock()
+	if engine.mixer.ActiveVoiceCount() != 0 {
+		t.Error("Expected voice to stop")
+	}
+}
+
+func TestEngineWritesWAV(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "mix.wav")
+	engine := NewEngine(NewWAVBackend(filename))
+	if err := engine.backend.Open(SAMPLE_RATE, CHANNELS); err != nil {
+		t.Fatal(err)
+	}
+	engine.Play(newConstantSound(1000, SAMPLE_RATE, SAMPLE_RATE), DefaultVoiceParams())
+	engine.MixBlock()
+	engine.MixBlock()
+	if err := engine.backend.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := wavHeader{}
+	binary.Read(bytes.NewReader(data), binary.LittleEndian, &header)
+	expectedSize := 2 * MIX_BLOCK_FRAMES * CHANNELS * 2
+	if string(header.RiffId[:]) != "RIFF" || int(header.DataSize) != expectedSize {
+		t.Errorf("Unexpected header %+v", header)
+	}
+	if len(data) != WAV_HEADER_SIZE+expectedSize {
+		t.Errorf("Expected file size %d, got %d", WAV_HEADER_SIZE+expectedSize, len(data))
+	}
+}
+
+func TestEngineStartAndClose(t *testing.T) {
+	engine := NewEngine(NewNullBackend())
+	if err := engine.Start(); err != nil {
+		t.Fatal(err)
+	}
+	engine.Play(newConstantSound(1000, 100, SAMPLE_RATE), DefaultVoiceParams())
+	if err := engine.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if engine.IsRunning() {
+		t.Error("Expected engine to stop")
+	}
+}
+
+func TestEnginePlayWithStatus(t *testing.T) {
+	engine := NewEngine(NewNullBackend())
+	_, status := engine.PlayWithStatus(newConstantSound(1000, MIX_BLOCK_FRAMES+10, SAMPLE_RATE), DefaultVoiceParams())
+
+	engine.MixBlock()
+	if status.IsFinished() {
+		t.Error("Expected voice to still be playing")
+	}
+	engine.MixBlock()
+	if !status.IsFinished() {
+		t.Error("Expected voice to finish at the end of the sound")
+	}
+}
@@ -0,0 +1,121 @@
+package audio
+
+const (
+	SAMPLE_RATE = 44100
+	CHANNELS    = 2
+	MAX_VOICES  = 32
+)
+
+// Software mixer that adds all playing voices into one stereo stream
+// Only the audio goroutine should call the mixer once the engine is started
+type Mixer struct {
+	SampleRate   int
+	MasterVolume float32
+	voices       []*voice // Oldest voice first
+	mixBuffer    []float32
+	reverb       *Reverb // nil if reverb is off
+	reverbBuffer []float32
+}
+
+func NewMixer(sampleRate int) *Mixer {
+	return &Mixer{
+		SampleRate:   sampleRate,
+		MasterVolume: 1.0,
+		voices:       make([]*voice, 0, MAX_VOICES),
+	}
+}
+
+func (mixer *Mixer) ActiveVoiceCount() int {
+	return len(mixer.voices)
+}
+
+// The oldest voice is stopped when all voices are in use
+func (mixer *Mixer) play(id VoiceId, sound *Sound, params VoiceParams, status *VoiceStatus) {
+	newVoice := newVoice(id, sound, params, status, mixer.SampleRate)
+	if !newVoice.active {
+		newVoice.finish()
+		return
+	}
+	if len(mixer.voices) >= MAX_VOICES {
+		mixer.voices[0].finish()
+		mixer.voices = mixer.voices[1:]
+	}
+	mixer.voices = append(mixer.voices, newVoice)
+}
+
+// Returns nil if the voice has stopped
+func (mixer *Mixer) findVoice(id VoiceId) *voice {
+	for _, v := range mixer.voices {
+		if v.id == id {
+			return v
+		}
+	}
+	return nil
+}
+
+func (mixer *Mixer) stop(id VoiceId) {
+	if v := mixer.findVoice(id); v != nil {
+		v.active = false
+	}
+	mixer.removeStoppedVoices()
+}
+
+func (mixer *Mixer) stopAll() {
+	for _, v := range mixer.voices {
+		v.finish()
+	}
+	mixer.voices = mixer.voices[:0]
+}
+
+func (mixer *Mixer) removeStoppedVoices() {
+	activeVoices := mixer.voices[:0]
+	for _, v := range mixer.voices {
+		if v.active {
+			activeVoices = append(activeVoices, v)
+		} else {
+			v.finish()
+		}
+	}
+	for i := len(activeVoices); i < len(mixer.voices); i++ {
+		mixer.voices[i] = nil
+	}
+	mixer.voices = activeVoices
+}
+
+// Fills the interleaved stereo buffer with the next samples
+func (mixer *Mixer) Mix(out []int16) {
+	if cap(mixer.mixBuffer) < len(out) {
+		mixer.mixBuffer = make([]float32, len(out))
+	}
+	if cap(mixer.reverbBuffer) < len(out) {
+		mixer.reverbBuffer = make([]float32, len(out))
+	}
+	mixBuffer := mixer.mixBuffer[:len(out)]
+	reverbBuffer := mixer.reverbBuffer[:len(out)]
+	for i := range mixBuffer {
+		mixBuffer[i] = 0
+		reverbBuffer[i] = 0
+	}
+
+	for _, v := range mixer.voices {
+		if v.params.Reverb && mixer.reverb != nil {
+			v.mix(reverbBuffer, mixer.SampleRate)
+		} else {
+			v.mix(mixBuffer, mixer.SampleRate)
+		}
+	}
+	mixer.removeStoppedVoices()
+
+	// Reverb voices are heard dry as well
+	if mixer.reverb != nil {
+		for i, sample := range reverbBuffer {
+			mixBuffer[i] += sample
+		}
+		mixer.reverb.process(reverbBuffer, mixBuffer, mixer.SampleRate)
+	}
+
+	for i, sample := range mixBuffer {
+		value := sample * mixer.MasterVolume * 32768.0
+		out[i] = int16(min(max(value, -32768.0), 32767.0))
+	}
+}
@@ -0,0 +1,121 @@
+package audio
+
+import (
+	"testing"
+)
+
+func newConstantSound(value int16, frames int, sampleRate int) *Sound {
+	samples := make([]int16, frames)
+	for i := range samples {
+		samples[i] = value
+	}
+	return NewSound(samples, 1, sampleRate)
+}
+
+func TestMixerPan(t *testing.T) {
+	mixer := NewMixer(100)
+	params := DefaultVoiceParams()
+	params.Pan = -1.0
+	mixer.play(1, newConstantSound(16384, 10, 100), params, nil)
+
+	out := make([]int16, 4)
+	mixer.Mix(out)
+	if out[0] < 16000 || out[1] != 0 {
+		t.Errorf("Expected sound on the left only, got %v", out)
+	}
+}
+
+func TestMixerStopsAtEndOfSound(t *testing.T) {
+	mixer := NewMixer(100)
+	mixer.play(1, newConstantSound(1000, 3, 100), DefaultVoiceParams(), nil)
+
+	out := make([]int16, 10)
+	mixer.Mix(out)
+	if out[4] != 1000 || out[6] != 0 {
+		t.Errorf("Expected 3 frames of sound, got %v", out)
+	}
+	if mixer.ActiveVoiceCount() != 0 {
+		t.Errorf("Expected voice to stop, got %d voices", mixer.ActiveVoiceCount())
+	}
+}
+
+func TestMixerLoopAndPitch(t *testing.T) {
+	mixer := NewMixer(100)
+	sound := NewSound([]int16{0, 1000, 2000, 3000}, 1, 100)
+	sound.LoopStart = 2
+	params := DefaultVoiceParams()
+	params.Loop = true
+	params.Pitch = 2.0
+	mixer.play(1, sound, params, nil)
+
+	out := make([]int16, 8)
+	mixer.Mix(out)
+	expected := []int16{0, 2000, 2000, 2000}
+	for i, value := range expected {
+		if out[i*2] != value {
+			t.Errorf("Frame %d: expected %d, got %d", i, value, out[i*2])
+		}
+	}
+	if mixer.ActiveVoiceCount() != 1 {
+		t.Error("Expected looping voice to keep playing")
+	}
+}
+
+func TestMixerFadeOut(t *testing.T) {
+	mixer := NewMixer(100)
+	mixer.play(1, newConstantSound(10000, 100, 100), DefaultVoiceParams(), nil)
+	mixer.applyCommand(command{Type: COMMAND_FADE, VoiceId: 1, Value: 0.0, Seconds: 0.04, StopAfterFade: true})
+
+	out := make([]int16, 12)
+	mixer.Mix(out)
+	if out[0] != 10000 || out[2] != 7500 || out[6] != 2500 || out[8] != 0 {
+		t.Errorf("Unexpected fade, got %v", out)
+	}
+	if mixer.ActiveVoiceCount() != 0 {
+		t.Error("Expected voice to stop after the fade")
+	}
+}
+
+func TestMixerStealsOldestVoice(t *testing.T) {
+	mixer := NewMixer(100)
+	for i := 0; i <= MAX_VOICES; i++ {
+		mixer.play(VoiceId(i), newConstantSound(1, 10, 100), DefaultVoiceParams(), nil)
+	}
+	if mixer.ActiveVoiceCount() != MAX_VOICES {
+		t.Errorf("Expected %d voices, got %d", MAX_VOICES, mixer.ActiveVoiceCount())
+	}
+	if mixer.findVoice(0) != nil {
+		t.Error("Expected oldest voice to be stopped")
+	}
+}
+
+func TestMixerClampsOutput(t *testing.T) {
+	mixer := NewMixer(100)
+	mixer.play(1, newConstantSound(30000, 10, 100), DefaultVoiceParams(), nil)
+	mixer.play(2, newConstantSound(30000, 10, 100), DefaultVoiceParams(), nil)
+
+	out := make([]int16, 2)
+	mixer.Mix(out)
+	if out[0] != 32767 {
+		t.Errorf("Expected clamped sample, got %d", out[0])
+	}
+}
+
+func TestMixerPauseAndResume(t *testing.T) {
+	mixer := NewMixer(100)
+	mixer.play(1, NewSound([]int16{1000, 2000, 3000}, 1, 100), DefaultVoiceParams(), nil)
+
+	out := make([]int16, 2)
+	mixer.Mix(out)
+	mixer.applyCommand(command{Type: COMMAND_PAUSE, VoiceId: 1})
+	mixer.Mix(out)
+	if out[0] != 0 {
+		t.Errorf("Expected silence while paused, got %d", out[0])
+	}
+
+	mixer.applyCommand(command{Type: COMMAND_RESUME, VoiceId: 1})
+	mixer.Mix(out)
+	if out[0] != 2000 {
+		t.Errorf("Expected playback to continue from the second frame, got %d", out[0])
+	}
+}
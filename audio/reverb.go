@@ -0,0 +1,280 @@
+package audio
+
+// Reverb bus that works like the PS1 SPU reverb unit
+// The presets are the register values used by the libsnd reverb types
+
+const (
+	REVERB_SAMPLE_RATE   = 22050 // The SPU runs the reverb at half the output rate
+	REVERB_DEFAULT_DEPTH = 0.5
+
+	// Reverb types in the same order as libsnd
+	REVERB_TYPE_OFF           = 0
+	REVERB_TYPE_ROOM          = 1
+	REVERB_TYPE_STUDIO_SMALL  = 2
+	REVERB_TYPE_STUDIO_MEDIUM = 3
+	REVERB_TYPE_STUDIO_LARGE  = 4
+	REVERB_TYPE_HALL          = 5
+	REVERB_TYPE_SPACE_ECHO    = 6
+	REVERB_TYPE_ECHO          = 7
+	REVERB_TYPE_DELAY         = 8
+	REVERB_TYPE_PIPE          = 9
+	REVERB_TYPE_COUNT         = 10
+)
+
+// Index of each value in ReverbPreset.Registers
+const (
+	reverbAPFOffset1 = iota
+	reverbAPFOffset2
+	reverbReflectionVolume1
+	reverbCombVolume1
+	reverbCombVolume2
+	reverbCombVolume3
+	reverbCombVolume4
+	reverbReflectionVolume2
+	reverbAPFVolume1
+	reverbAPFVolume2
+	reverbSameSideLeft
+	reverbSameSideRight
+	reverbCombLeft1
+	reverbCombRight1
+	reverbCombLeft2
+	reverbCombRight2
+	reverbSameSideReflectLeft
+	reverbSameSideReflectRight
+	reverbDiffSideLeft
+	reverbDiffSideRight
+	reverbCombLeft3
+	reverbCombRight3
+	reverbCombLeft4
+	reverbCombRight4
+	reverbDiffSideReflectLeft
+	reverbDiffSideReflectRight
+	reverbAPFLeft1
+	reverbAPFRight1
+	reverbAPFLeft2
+	reverbAPFRight2
+	reverbInputVolumeLeft
+	reverbInputVolumeRight
+	reverbRegisterCount
+)
+
+type ReverbPreset struct {
+	Name         string
+	WorkAreaSize int                         // Bytes of sound RAM used by the reverb buffer
+	Registers    [reverbRegisterCount]uint16 // Values of the SPU reverb registers
+}
+
+// Addresses are in units of 8 bytes and volumes are signed 1.15 fixed point
+var reverbPresets = [REVERB_TYPE_COUNT]*ReverbPreset{
+	REVERB_TYPE_OFF: nil,
+	REVERB_TYPE_ROOM: {
+		Name:         "Room",
+		WorkAreaSize: 0x26C0,
+		Registers: [reverbRegisterCount]uint16{
+			0x007D, 0x005B, 0x6D80, 0x54B8, 0xBED0, 0x0000, 0x0000, 0xBA80,
+			0x5800, 0x5300, 0x04D6, 0x0333, 0x03F0, 0x0227, 0x0374, 0x01EF,
+			0x0334, 0x01B5, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000,
+			0x0000, 0x0000, 0x01B4, 0x0136, 0x00B8, 0x005C, 0x8000, 0x8000,
+		},
+	},
+	REVERB_TYPE_STUDIO_SMALL: {
+		Name:         "Studio Small",
+		WorkAreaSize: 0x1F40,
+		Registers: [reverbRegisterCount]uint16{
+			0x0033, 0x0025, 0x70F0, 0x4FA8, 0xBCE0, 0x4410, 0xC0F0, 0x9C00,
+			0x5280, 0x4EC0, 0x03E4, 0x031B, 0x03A4, 0x02AF, 0x0372, 0x0266,
+			0x031C, 0x025D, 0x025C, 0x018E, 0x022F, 0x0135, 0x01D2, 0x00B7,
+			0x018F, 0x00B5, 0x00B4, 0x0080, 0x004C, 0x0026, 0x8000, 0x8000,
+		},
+	},
+	REVERB_TYPE_STUDIO_MEDIUM: {
+		Name:         "Studio Medium",
+		WorkAreaSize: 0x4840,
+		Registers: [reverbRegisterCount]uint16{
+			0x00B1, 0x007F, 0x70F0, 0x4FA8, 0xBCE0, 0x4510, 0xBEF0, 0xB4C0,
+			0x5280, 0x4EC0, 0x0904, 0x076B, 0x0824, 0x065F, 0x07A2, 0x0616,
+			0x076C, 0x05ED, 0x05EC, 0x042E, 0x050F, 0x0305, 0x0462, 0x02B7,
+			0x042F, 0x0265, 0x0264, 0x01B2, 0x0100, 0x0080, 0x8000, 0x8000,
+		},
+	},
+	REVERB_TYPE_STUDIO_LARGE: {
+		Name:         "Studio Large",
+		WorkAreaSize: 0x6FE0,
+		Registers: [reverbRegisterCount]uint16{
+			0x00E3, 0x00A9, 0x6F60, 0x4FA8, 0xBCE0, 0x4510, 0xBEF0, 0xA680,
+			0x5680, 0x52C0, 0x0DFB, 0x0B58, 0x0D09, 0x0A3C, 0x0BD9, 0x0973,
+			0x0B59, 0x08DA, 0x08D9, 0x05E9, 0x07EC, 0x04B0, 0x06EF, 0x03D2,
+			0x05EA, 0x031D, 0x031C, 0x0238, 0x0154, 0x00AA, 0x8000, 0x8000,
+		},
+	},
+	REVERB_TYPE_HALL: {
+		Name:         "Hall",
+		WorkAreaSize: 0xADE0,
+		Registers: [reverbRegisterCount]uint16{
+			0x01A5, 0x0139, 0x6000, 0x5000, 0x4C00, 0xB800, 0xBC00, 0xC000,
+			0x6000, 0x5C00, 0x15BA, 0x11BB, 0x14C2, 0x10BD, 0x11BC, 0x0DC1,
+			0x11C0, 0x0DC3, 0x0DC0, 0x09C1, 0x0BC4, 0x07C1, 0x0A00, 0x06CD,
+			0x09C2, 0x05C1, 0x05C0, 0x041A, 0x0274, 0x013A, 0x8000, 0x8000,
+		},
+	},
+	REVERB_TYPE_SPACE_ECHO: {
+		Name:         "Space Echo",
+		WorkAreaSize: 0xF6C0,
+		Registers: [reverbRegisterCount]uint16{
+			0x033D, 0x0231, 0x7E00, 0x5000, 0xB400, 0xB000, 0x4C00, 0xB000,
+			0x6000, 0x5400, 0x1ED6, 0x1A31, 0x1D14, 0x183B, 0x1BC3, 0x16B2,
+			0x1A32, 0x15EF, 0x15EE, 0x1055, 0x1334, 0x0F2D, 0x11F6, 0x0C5D,
+			0x1056, 0x0AE1, 0x0AE0, 0x07A2, 0x0464, 0x0232, 0x8000, 0x8000,
+		},
+	},
+	REVERB_TYPE_ECHO: {
+		Name:         "Echo",
+		WorkAreaSize: 0x18040,
+		Registers: [reverbRegisterCount]uint16{
+			0x0001, 0x0001, 0x7FFF, 0x7FFF, 0x0000, 0x0000, 0x0000, 0x8100,
+			0x0000, 0x0000, 0x1FFF, 0x0FFF, 0x1005, 0x0005, 0x0000, 0x0000,
+			0x1005, 0x0005, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000,
+			0x0000, 0x0000, 0x1004, 0x1002, 0x0004, 0x0002, 0x8000, 0x8000,
+		},
+	},
+	REVERB_TYPE_DELAY: {
+		Name:         "Delay",
+		WorkAreaSize: 0x18040,
+		Registers: [reverbRegisterCount]uint16{
+			0x0001, 0x0001, 0x7FFF, 0x7FFF, 0x0000, 0x0000, 0x0000, 0x0000,
+			0x0000, 0x0000, 0x1FFF, 0x0FFF, 0x1005, 0x0005, 0x0000, 0x0000,
+			0x1005, 0x0005, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000, 0x0000,
+			0x0000, 0x0000, 0x1004, 0x1002, 0x0004, 0x0002, 0x8000, 0x8000,
+		},
+	},
+	// Listed as "Half Echo" in some SPU documents
+	REVERB_TYPE_PIPE: {
+		Name:         "Pipe",
+		WorkAreaSize: 0x3C00,
+		Registers: [reverbRegisterCount]uint16{
+			0x0017, 0x0013, 0x70F0, 0x4FA8, 0xBCE0, 0x4510, 0xBEF0, 0x8500,
+			0x5F80, 0x54C0, 0x0371, 0x02AF, 0x02E5, 0x01DF, 0x02B0, 0x01D7,
+			0x0358, 0x026A, 0x01D6, 0x011E, 0x012D, 0x00B1, 0x011F, 0x0059,
+			0x01A0, 0x00E3, 0x0058, 0x0040, 0x0028, 0x0014, 0x8000, 0x8000,
+		},
+	},
+}
+
+// Returns nil for REVERB_TYPE_OFF and unknown types
+func GetReverbPreset(reverbType int) *ReverbPreset {
+	if reverbType < 0 || reverbType >= REVERB_TYPE_COUNT {
+		return nil
+	}
+	return reverbPresets[reverbType]
+}
+
+// Reverb state owned by the mixer
+type Reverb struct {
+	Preset *ReverbPreset
+	Depth  float32 // Output volume of the reverb bus
+
+	buffer   []float32 // One value for each 16 bit sample of the work area
+	position int
+	phase    float64
+
+	// Input is averaged and output is held between reverb ticks
+	inputLeft   float32
+	inputRight  float32
+	inputFrames int
+	outputLeft  float32
+	outputRight float32
+}
+
+func NewReverb(preset *ReverbPreset, depth float32) *Reverb {
+	return &Reverb{
+		Preset: preset,
+		Depth:  depth,
+		buffer: make([]float32, preset.WorkAreaSize/2),
+	}
+}
+
+func (reverb *Reverb) volume(register int) float32 {
+	return float32(int16(reverb.Preset.Registers[register])) / 32768.0
+}
+
+// Addresses are stored in units of 8 bytes, which is 4 samples
+func (reverb *Reverb) address(register int) int {
+	return int(reverb.Preset.Registers[register]) * 4
+}
+
+func (reverb *Reverb) read(offset int) float32 {
+	length := len(reverb.buffer)
+	return reverb.buffer[((reverb.position+offset)%length+length)%length]
+}
+
+func (reverb *Reverb) write(offset int, value float32) {
+	length := len(reverb.buffer)
+	reverb.buffer[((reverb.position+offset)%length+length)%length] = min(max(value, -1.0), 1.0)
+}
+
+// Reflection from one side of the room, filtered by the previous sample
+func (reverb *Reverb) reflect(input float32, destRegister int, sourceRegister int) {
+	dest := reverb.address(destRegister)
+	previous := reverb.read(dest - 1)
+	value := (input+reverb.read(reverb.address(sourceRegister))*reverb.volume(reverbReflectionVolume2)-previous)*
+		reverb.volume(reverbReflectionVolume1) + previous
+	reverb.write(dest, value)
+}
+
+func (reverb *Reverb) allPass(input float32, addressRegister int, offsetRegister int, volumeRegister int) float32 {
+	address := reverb.address(addressRegister)
+	delayed := reverb.read(address - reverb.address(offsetRegister))
+	volume := reverb.volume(volumeRegister)
+	output := input - volume*delayed
+	reverb.write(address, output)
+	return output*volume + delayed
+}
+
+// Runs the reverb unit for one sample at REVERB_SAMPLE_RATE
+func (reverb *Reverb) tick(left float32, right float32) (float32, float32) {
+	leftIn := left * reverb.volume(reverbInputVolumeLeft)
+	rightIn := right * reverb.volume(reverbInputVolumeRight)
+
+	reverb.reflect(leftIn, reverbSameSideLeft, reverbSameSideReflectLeft)
+	reverb.reflect(rightIn, reverbSameSideRight, reverbSameSideReflectRight)
+	reverb.reflect(leftIn, reverbDiffSideLeft, reverbDiffSideReflectRight)
+	reverb.reflect(rightIn, reverbDiffSideRight, reverbDiffSideReflectLeft)
+
+	leftOut := reverb.volume(reverbCombVolume1)*reverb.read(reverb.address(reverbCombLeft1)) +
+		reverb.volume(reverbCombVolume2)*reverb.read(reverb.address(reverbCombLeft2)) +
+		reverb.volume(reverbCombVolume3)*reverb.read(reverb.address(reverbCombLeft3)) +
+		reverb.volume(reverbCombVolume4)*reverb.read(reverb.address(reverbCombLeft4))
+	rightOut := reverb.volume(reverbCombVolume1)*reverb.read(reverb.address(reverbCombRight1)) +
+		reverb.volume(reverbCombVolume2)*reverb.read(reverb.address(reverbCombRight2)) +
+		reverb.volume(reverbCombVolume3)*reverb.read(reverb.address(reverbCombRight3)) +
+		reverb.volume(reverbCombVolume4)*reverb.read(reverb.address(reverbCombRight4))
+
+	leftOut = reverb.allPass(leftOut, reverbAPFLeft1, reverbAPFOffset1, reverbAPFVolume1)
+	rightOut = reverb.allPass(rightOut, reverbAPFRight1, reverbAPFOffset1, reverbAPFVolume1)
+	leftOut = reverb.allPass(leftOut, reverbAPFLeft2, reverbAPFOffset2, reverbAPFVolume2)
+	rightOut = reverb.allPass(rightOut, reverbAPFRight2, reverbAPFOffset2, reverbAPFVolume2)
+
+	reverb.position = (reverb.position + 1) % len(reverb.buffer)
+	return leftOut, rightOut
+}
+
+// Adds the reverb of the interleaved stereo input to the output
+func (reverb *Reverb) process(input []float32, out []float32, outputRate int) {
+	step := float64(REVERB_SAMPLE_RATE) / float64(outputRate)
+	for i := 0; i+1 < len(input); i += 2 {
+		reverb.inputLeft += input[i]
+		reverb.inputRight += input[i+1]
+		reverb.inputFrames++
+
+		reverb.phase += step
+		if reverb.phase >= 1.0 {
+			reverb.phase -= 1.0
+			frames := float32(reverb.inputFrames)
+			reverb.outputLeft, reverb.outputRight = reverb.tick(reverb.inputLeft/frames, reverb.inputRight/frames)
+			reverb.inputLeft, reverb.inputRight, reverb.inputFrames = 0, 0, 0
+		}
+
+		out[i] += reverb.outputLeft * reverb.Depth
+		out[i+1] += reverb.outputRight * reverb.Depth
+	}
+}
@@ -0,0 +1,63 @@
+package audio
+
+import (
+	"testing"
+)
+
+func TestReverbPresetsFitWorkArea(t *testing.T) {
+	for reverbType := 0; reverbType < REVERB_TYPE_COUNT; reverbType++ {
+		preset := GetReverbPreset(reverbType)
+		if preset == nil {
+			continue
+		}
+		workAreaUnits := preset.WorkAreaSize / 8
+		for i := reverbSameSideLeft; i <= reverbAPFRight2; i++ {
+			if int(preset.Registers[i]) >= workAreaUnits {
+				t.Errorf("%s: register %d address %#x is outside the work area", preset.Name, i, preset.Registers[i])
+			}
+		}
+	}
+	if GetReverbPreset(REVERB_TYPE_OFF) != nil || GetReverbPreset(REVERB_TYPE_COUNT) != nil {
+		t.Error("Expected no preset when reverb is off or the type is unknown")
+	}
+}
+
+func TestMixerReverbOnlyForReverbVoices(t *testing.T) {
+	mixTail := func(useReverb bool) int16 {
+		mixer := NewMixer(SAMPLE_RATE)
+		mixer.reverb = NewReverb(GetReverbPreset(REVERB_TYPE_HALL), 1.0)
+		params := DefaultVoiceParams()
+		params.Reverb = useReverb
+		mixer.play(1, newConstantSound(16384, 100, SAMPLE_RATE), params, nil)
+
+		out := make([]int16, SAMPLE_RATE*CHANNELS)
+		mixer.Mix(out)
+		peak := int16(0)
+		for _, sample := range out[200*CHANNELS:] {
+			peak = max(peak, sample, -sample)
+		}
+		return peak
+	}
+
+	if peak := mixTail(false); peak != 0 {
+		t.Errorf("Expected a dry voice to stay silent after it ends, got peak %d", peak)
+	}
+	if peak := mixTail(true); peak == 0 {
+		t.Error("Expected a reverb tail after the voice ends")
+	}
+}
+
+func TestEngineSetReverb(t *testing.T) {
+	engine := NewEngine(NewNullBackend())
+	engine.SetReverb(REVERB_TYPE_ROOM, REVERB_DEFAULT_DEPTH)
+	engine.MixBlock()
+	if engine.mixer.reverb == nil || engine.mixer.reverb.Preset.Name != "Room" {
+		t.Fatalf("Expected room reverb, got %+v", engine.mixer.reverb)
+	}
+
+	engine.SetReverb(REVERB_TYPE_OFF, REVERB_DEFAULT_DEPTH)
+	engine.MixBlock()
+	if engine.mixer.reverb != nil {
+		t.Error("Expected reverb to be off")
+	}
+}
@@ -0,0 +1,134 @@
+package audio
+
+import (
+	"math"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+)
+
+const (
+	SPU_SAMPLE_RATE = 44100 // Rate of a waveform played at the tone's center note
+	VAB_MAX_VOLUME  = 127
+	VAB_CENTER_PAN  = 64
+	VAB_MAX_NOTE    = 127
+)
+
+// Plays the instruments of a VAB sound bank the way the PS1 SPU does
+type Sampler struct {
+	Header    *fileio.VABHeaderOutput
+	Waveforms []*Sound // Indexed by VABTone.Vag, nil if there is no waveform
+}
+
+func NewSampler(vabHeaderOutput *fileio.VABHeaderOutput, vabDataOutput *fileio.VABDataOutput) *Sampler {
+	waveforms := make([]*Sound, len(vabDataOutput.VagData))
+	for i, adpcmData := range vabDataOutput.VagData {
+		if adpcmData == nil {
+			continue
+		}
+		vagOutput := fileio.DecodeVAG(adpcmData)
+		sound := NewSound(vagOutput.Samples, 1, SPU_SAMPLE_RATE)
+		sound.HasLoop = vagOutput.HasLoop
+		sound.LoopStart = vagOutput.LoopStart
+		waveforms[i] = sound
+	}
+	return &Sampler{
+		Header:    vabHeaderOutput,
+		Waveforms: waveforms,
+	}
+}
+
+// Returns nil if none of the program's tones covers the note
+func (sampler *Sampler) FindTone(program int, note int) *fileio.VABTone {
+	tones := sampler.Header.GetProgramTones(program)
+	for i := range tones {
+		if note >= int(tones[i].NoteMin) && note <= int(tones[i].NoteMax) {
+			return &tones[i]
+		}
+	}
+	return nil
+}
+
+// Returns nil if the program doesn't have the tone
+func (sampler *Sampler) GetTone(program int, toneIndex int) *fileio.VABTone {
+	tones := sampler.Header.GetProgramTones(program)
+	if toneIndex < 0 || toneIndex >= len(tones) {
+		return nil
+	}
+	return &tones[toneIndex]
+}
+
+// Shift is a fine tune in 1/128 of a semitone
+func TonePitch(tone *fileio.VABTone, note int) float32 {
+	semitones := float64(note-int(tone.Center)) + float64(tone.Shift)/128.0
+	return float32(math.Pow(2.0, semitones/12.0))
+}
+
+// Tone and program pan are offsets from the center
+func TonePan(program *fileio.VABProgram, tone *fileio.VABTone) float32 {
+	offset := (int(tone.Pan) - VAB_CENTER_PAN) + (int(program.Pan) - VAB_CENTER_PAN)
+	return min(max(float32(offset)/(VAB_CENTER_PAN-1), -1.0), 1.0)
+}
+
+func ToneVolume(header fileio.VABHeader, program *fileio.VABProgram, tone *fileio.VABTone, velocity int) float32 {
+	volume := float32(tone.Volume) / VAB_MAX_VOLUME
+	volume *= float32(program.Volume) / VAB_MAX_VOLUME
+	volume *= float32(header.MasterVolume) / VAB_MAX_VOLUME
+	volume *= float32(velocity) / VAB_MAX_VOLUME
+	return volume
+}
+
+// Returns the waveform and voice settings to play the tone at a note
+func (sampler *Sampler) GetToneVoice(tone *fileio.VABTone, note int, velocity int) (*Sound, VoiceParams, bool) {
+	vag := int(tone.Vag)
+	program := int(tone.Program)
+	if vag < 0 || vag >= len(sampler.Waveforms) || sampler.Waveforms[vag] == nil {
+		return nil, VoiceParams{}, false
+	}
+	if program < 0 || program >= len(sampler.Header.Programs) {
+		return nil, VoiceParams{}, false
+	}
+
+	sound := sampler.Waveforms[vag]
+	envelope := NewADSRSettings(tone.Adsr1, tone.Adsr2)
+	params := VoiceParams{
+		Volume:   ToneVolume(sampler.Header.VABHeader, &sampler.Header.Programs[program], tone, velocity),
+		Pan:      TonePan(&sampler.Header.Programs[program], tone),
+		Pitch:    TonePitch(tone, note),
+		Loop:     sound.HasLoop,
+		Envelope: &envelope,
+		Reverb:   tone.Mode == fileio.VAB_TONE_MODE_REVERB,
+	}
+	return sound, params, true
+}
+
+// Plays the tone that covers the note in the program
+// Returns false if there is no tone for the note
+func (sampler *Sampler) NoteOn(engine *Engine, program int, note int, velocity int) (VoiceId, bool) {
+	tone := sampler.FindTone(program, note)
+	if tone == nil {
+		return 0, false
+	}
+	sound, params, ok := sampler.GetToneVoice(tone, note, velocity)
+	if !ok {
+		return 0, false
+	}
+	return engine.Play(sound, params), true
+}
+
+// Plays a tone of the program at its center note
+func (sampler *Sampler) ToneOn(engine *Engine, program int, toneIndex int) (VoiceId, bool) {
+	tone := sampler.GetTone(program, toneIndex)
+	if tone == nil {
+		return 0, false
+	}
+	sound, params, ok := sampler.GetToneVoice(tone, int(tone.Center), VAB_MAX_VOLUME)
+	if !ok {
+		return 0, false
+	}
+	return engine.Play(sound, params), true
+}
+
+// Releases the note so the envelope fades it out
+func (sampler *Sampler) NoteOff(engine *Engine, id VoiceId) {
+	engine.Release(id)
+}
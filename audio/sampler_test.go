@@ -0,0 +1,130 @@
+package audio
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+)
+
+func newTestSampler() *Sampler {
+	tones := make([]fileio.VABTone, 16)
+	tones[0] = fileio.VABTone{Volume: 127, Pan: 64, Center: 60, NoteMin: 0, NoteMax: 59, Vag: 1}
+	tones[1] = fileio.VABTone{Volume: 127, Pan: 127, Center: 72, NoteMin: 60, NoteMax: 127, Vag: 1}
+	header := &fileio.VABHeaderOutput{
+		VABHeader: fileio.VABHeader{MasterVolume: 127},
+		Programs:  []fileio.VABProgram{{Tones: 2, Volume: 127, Pan: 64}},
+		Tones:     [][]fileio.VABTone{tones},
+	}
+	return &Sampler{
+		Header:    header,
+		Waveforms: []*Sound{nil, newConstantSound(1000, 100, SPU_SAMPLE_RATE)},
+	}
+}
+
+func TestSamplerFindTone(t *testing.T) {
+	sampler := newTestSampler()
+	if tone := sampler.FindTone(0, 40); tone == nil || tone.Center != 60 {
+		t.Errorf("Expected first tone for note 40, got %v", tone)
+	}
+	if tone := sampler.FindTone(0, 80); tone == nil || tone.Center != 72 {
+		t.Errorf("Expected second tone for note 80, got %v", tone)
+	}
+	if tone := sampler.FindTone(1, 80); tone != nil {
+		t.Errorf("Expected no tone for missing program, got %v", tone)
+	}
+}
+
+func TestSamplerToneVoice(t *testing.T) {
+	sampler := newTestSampler()
+	sound, params, ok := sampler.GetToneVoice(sampler.FindTone(0, 84), 84, VAB_MAX_VOLUME)
+	if !ok || sound != sampler.Waveforms[1] {
+		t.Fatal("Expected waveform for the tone")
+	}
+	if params.Pitch != 2.0 {
+		t.Errorf("Expected one octave up, got pitch %f", params.Pitch)
+	}
+	if params.Pan != 1.0 {
+		t.Errorf("Expected right pan, got %f", params.Pan)
+	}
+	if params.Volume != 1.0 || params.Envelope == nil {
+		t.Errorf("Unexpected params %+v", params)
+	}
+	if params.Reverb {
+		t.Error("Expected a normal tone to skip the reverb")
+	}
+
+	reverbTone := *sampler.FindTone(0, 84)
+	reverbTone.Mode = fileio.VAB_TONE_MODE_REVERB
+	if _, params, _ := sampler.GetToneVoice(&reverbTone, 84, VAB_MAX_VOLUME); !params.Reverb {
+		t.Error("Expected a reverb tone to use the reverb bus")
+	}
+}
+
+func TestTonePitchShift(t *testing.T) {
+	tone := &fileio.VABTone{Center: 60, Shift: 64}
+	pitch := TonePitch(tone, 59)
+	// Half a semitone down
+	if pitch < 0.970 || pitch > 0.972 {
+		t.Errorf("Expected pitch 0.971, got %f", pitch)
+	}
+}
+
+func TestADSRSettings(t *testing.T) {
+	settings := NewADSRSettings(0x83FF, 0x5FC5)
+	if !settings.AttackExponential || settings.AttackShift != 0 || settings.AttackStep != 4 {
+		t.Errorf("Unexpected attack %+v", settings)
+	}
+	if settings.DecayShift != 15 || settings.SustainLevel != ENVELOPE_MAX_LEVEL {
+		t.Errorf("Unexpected decay %+v", settings)
+	}
+	if !settings.SustainDecrease || settings.SustainShift != 31 || settings.SustainStep != -5 {
+		t.Errorf("Unexpected sustain %+v", settings)
+	}
+	if settings.ReleaseExponential || settings.ReleaseShift != 5 {
+		t.Errorf("Unexpected release %+v", settings)
+	}
+}
+
+func TestEnvelopePhases(t *testing.T) {
+	env := newEnvelope(ADSRSettings{AttackShift: 0, AttackStep: 7, DecayShift: 0, SustainLevel: 0x4000, SustainShift: 31, ReleaseShift: 0})
+	for i := 0; i < 10 && env.phase == ENVELOPE_ATTACK; i++ {
+		env.update()
+	}
+	if env.phase != ENVELOPE_DECAY || env.level != ENVELOPE_MAX_LEVEL {
+		t.Fatalf("Expected decay after attack, got phase %d level %d", env.phase, env.level)
+	}
+	for i := 0; i < 100 && env.phase == ENVELOPE_DECAY; i++ {
+		env.update()
+	}
+	if env.phase != ENVELOPE_SUSTAIN || env.level > 0x4000 {
+		t.Fatalf("Expected sustain after decay, got phase %d level %d", env.phase, env.level)
+	}
+
+	env.keyOff()
+	for i := 0; i < 1000 && env.phase == ENVELOPE_RELEASE; i++ {
+		env.update()
+	}
+	if env.phase != ENVELOPE_OFF || env.level != 0 {
+		t.Errorf("Expected envelope off after release, got phase %d level %d", env.phase, env.level)
+	}
+}
+
+func TestSamplerNoteOffStopsVoice(t *testing.T) {
+	sampler := newTestSampler()
+	sampler.Waveforms[1].HasLoop = true
+	engine := NewEngine(NewNullBackend())
+	id, ok := sampler.NoteOn(engine, 0, 60, VAB_MAX_VOLUME)
+	if !ok {
+		t.Fatal("Expected note to play")
+	}
+	engine.MixBlock()
+	if engine.mixer.ActiveVoiceCount() != 1 {
+		t.Fatal("Expected looping note to keep playing")
+	}
+
+	sampler.NoteOff(engine, id)
+	engine.MixBlock()
+	if engine.mixer.ActiveVoiceCount() != 0 {
+		t.Error("Expected released note to stop")
+	}
+}
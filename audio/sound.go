@@ -0,0 +1,48 @@
+package audio
+
+// PCM16 sound data that can be played by a voice
+type Sound struct {
+	Samples    []int16 // Interleaved if there is more than one channel
+	Channels   int
+	SampleRate int
+
+	// Frames played in a loop when the voice loops
+	// LoopEnd of 0 loops the whole sound
+	HasLoop   bool // The sound data has loop points
+	LoopStart int
+	LoopEnd   int
+}
+
+func NewSound(samples []int16, channels int, sampleRate int) *Sound {
+	return &Sound{
+		Samples:    samples,
+		Channels:   max(channels, 1),
+		SampleRate: sampleRate,
+	}
+}
+
+func (sound *Sound) FrameCount() int {
+	return len(sound.Samples) / sound.Channels
+}
+
+func (sound *Sound) getLoopEnd() int {
+	if sound.LoopEnd <= 0 || sound.LoopEnd > sound.FrameCount() {
+		return sound.FrameCount()
+	}
+	return sound.LoopEnd
+}
+
+func (sound *Sound) getLoopStart() int {
+	return min(max(sound.LoopStart, 0), sound.getLoopEnd()-1)
+}
+
+// Returns the left and right sample in the range -1 to 1
+// Mono sounds have the same value on both sides
+func (sound *Sound) frameAt(frame int) (float32, float32) {
+	offset := frame * sound.Channels
+	left := float32(sound.Samples[offset]) / 32768.0
+	if sound.Channels == 1 {
+		return left, left
+	}
+	return left, float32(sound.Samples[offset+1]) / 32768.0
+}
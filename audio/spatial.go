@@ -0,0 +1,30 @@
+package audio
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	SOUND_FULL_VOLUME_DISTANCE = 4000  // Sounds closer than this are not attenuated
+	SOUND_MAX_DISTANCE         = 40000 // Sounds farther than this are silent
+)
+
+// Volume and pan of a sound at a position heard from the camera
+// Volume falls off linearly with distance and pan follows the camera's right side
+func SpatialParams(cameraFrom mgl32.Vec3, cameraTo mgl32.Vec3, cameraUp mgl32.Vec3, position mgl32.Vec3) (float32, float32) {
+	toSound := position.Sub(cameraFrom)
+	distance := toSound.Len()
+
+	volume := float32(1.0)
+	if distance > SOUND_FULL_VOLUME_DISTANCE {
+		volume = 1.0 - (distance-SOUND_FULL_VOLUME_DISTANCE)/(SOUND_MAX_DISTANCE-SOUND_FULL_VOLUME_DISTANCE)
+		volume = max(volume, 0.0)
+	}
+
+	right := cameraTo.Sub(cameraFrom).Cross(cameraUp)
+	if right.Len() == 0 || distance == 0 {
+		return volume, 0.0
+	}
+	pan := right.Normalize().Dot(toSound.Normalize())
+	return volume, min(max(pan, -1.0), 1.0)
+}
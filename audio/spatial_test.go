@@ -0,0 +1,29 @@
+package audio
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestSpatialParams(t *testing.T) {
+	cameraFrom := mgl32.Vec3{0, 0, 0}
+	cameraTo := mgl32.Vec3{0, 0, 1000}
+	cameraUp := mgl32.Vec3{0, -1, 0}
+
+	volume, pan := SpatialParams(cameraFrom, cameraTo, cameraUp, mgl32.Vec3{0, 0, 2000})
+	if volume != 1.0 || pan != 0.0 {
+		t.Errorf("Expected full volume in the center, got volume %f pan %f", volume, pan)
+	}
+
+	// With y pointing down, +x is on the camera's right
+	_, pan = SpatialParams(cameraFrom, cameraTo, cameraUp, mgl32.Vec3{2000, 0, 0})
+	if pan != 1.0 {
+		t.Errorf("Expected sound on the right, got pan %f", pan)
+	}
+
+	volume, _ = SpatialParams(cameraFrom, cameraTo, cameraUp, mgl32.Vec3{0, 0, SOUND_MAX_DISTANCE + 1000})
+	if volume != 0.0 {
+		t.Errorf("Expected silent sound far away, got volume %f", volume)
+	}
+}
@@ -0,0 +1,183 @@
+package audio
+
+import (
+	"sync/atomic"
+)
+
+type VoiceId uint32
+
+// Lets the game loop see when a voice has finished without waiting on the mixer
+type VoiceStatus struct {
+	finished atomic.Bool
+}
+
+func (status *VoiceStatus) IsFinished() bool {
+	return status.finished.Load()
+}
+
+// Settings used when a voice starts playing
+type VoiceParams struct {
+	Volume        float32 // 0 is silent, 1 is the original volume
+	Pan           float32 // -1 is left, 0 is center, 1 is right
+	Pitch         float32 // Playback speed, 1 is the original pitch
+	Loop          bool
+	FadeInSeconds float64
+	Envelope      *ADSRSettings // Optional, the voice stops when the envelope is released
+	Reverb        bool          // Also sent to the reverb bus
+}
+
+func DefaultVoiceParams() VoiceParams {
+	return VoiceParams{
+		Volume: 1.0,
+		Pan:    0.0,
+		Pitch:  1.0,
+	}
+}
+
+type voice struct {
+	id       VoiceId
+	status   *VoiceStatus // Optional
+	sound    *Sound
+	params   VoiceParams
+	position float64 // Frame position in the sound
+	active   bool
+	paused   bool // Paused voices keep their position and are not mixed
+	envelope *envelope
+
+	// Fades change the gain over a number of output frames
+	gain           float32
+	fadeStartGain  float32
+	fadeTargetGain float32
+	fadeFrames     int
+	fadeElapsed    int
+	stopAfterFade  bool
+}
+
+func newVoice(id VoiceId, sound *Sound, params VoiceParams, status *VoiceStatus, outputRate int) *voice {
+	v := &voice{
+		id:     id,
+		status: status,
+		sound:  sound,
+		params: params,
+		active: sound != nil && sound.FrameCount() > 0 && sound.SampleRate > 0,
+		gain:   1.0,
+	}
+	if params.Envelope != nil {
+		v.envelope = newEnvelope(*params.Envelope)
+	}
+	if params.FadeInSeconds > 0 {
+		v.gain = 0.0
+		v.fade(1.0, params.FadeInSeconds, false, outputRate)
+	}
+	return v
+}
+
+func (v *voice) fade(targetGain float32, seconds float64, stopAfterFade bool, outputRate int) {
+	v.fadeStartGain = v.gain
+	v.fadeTargetGain = targetGain
+	v.fadeFrames = int(seconds * float64(outputRate))
+	v.fadeElapsed = 0
+	v.stopAfterFade = stopAfterFade
+	if v.fadeFrames <= 0 {
+		v.gain = targetGain
+		if stopAfterFade {
+			v.active = false
+		}
+	}
+}
+
+func (v *voice) finish() {
+	v.active = false
+	if v.status != nil {
+		v.status.finished.Store(true)
+	}
+}
+
+// Voices without an envelope stop right away
+func (v *voice) release() {
+	if v.envelope == nil {
+		v.active = false
+		return
+	}
+	v.envelope.keyOff()
+}
+
+func (v *voice) updateFade() {
+	if v.fadeElapsed >= v.fadeFrames {
+		return
+	}
+	v.fadeElapsed++
+	t := float32(v.fadeElapsed) / float32(v.fadeFrames)
+	v.gain = v.fadeStartGain + (v.fadeTargetGain-v.fadeStartGain)*t
+	if v.fadeElapsed == v.fadeFrames && v.stopAfterFade {
+		v.active = false
+	}
+}
+
+// Balance pan keeps the near side at full volume and lowers the far side
+func panGains(pan float32) (float32, float32) {
+	pan = min(max(pan, -1.0), 1.0)
+	return min(1.0-pan, 1.0), min(1.0+pan, 1.0)
+}
+
+// Adds the voice to the interleaved stereo buffer
+// The sound is resampled to the output rate with linear interpolation
+func (v *voice) mix(out []float32, outputRate int) {
+	if !v.active || v.paused {
+		return
+	}
+
+	step := float64(v.params.Pitch) * float64(v.sound.SampleRate) / float64(outputRate)
+	if step <= 0 {
+		return
+	}
+	leftPan, rightPan := panGains(v.params.Pan)
+	loopStart := v.sound.getLoopStart()
+	loopEnd := v.sound.getLoopEnd()
+	frameCount := v.sound.FrameCount()
+
+	for i := 0; i+1 < len(out); i += 2 {
+		frame := int(v.position)
+		nextFrame := frame + 1
+		if nextFrame >= loopEnd {
+			if v.params.Loop {
+				nextFrame = loopStart
+			} else {
+				nextFrame = min(nextFrame, frameCount-1)
+			}
+		}
+		t := float32(v.position - float64(frame))
+		left0, right0 := v.sound.frameAt(frame)
+		left1, right1 := v.sound.frameAt(nextFrame)
+		left := left0 + (left1-left0)*t
+		right := right0 + (right1-right0)*t
+
+		volume := v.params.Volume * v.gain
+		if v.envelope != nil {
+			volume *= v.envelope.gain()
+			v.envelope.update()
+			if v.envelope.phase == ENVELOPE_OFF {
+				v.active = false
+			}
+		}
+		out[i] += left * volume * leftPan
+		out[i+1] += right * volume * rightPan
+
+		v.updateFade()
+		if !v.active {
+			return
+		}
+
+		v.position += step
+		if v.position >= float64(loopEnd) {
+			if !v.params.Loop {
+				v.active = false
+				return
+			}
+			loopLength := float64(loopEnd - loopStart)
+			for v.position >= float64(loopEnd) {
+				v.position -= loopLength
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+const WAV_HEADER_SIZE = 44
+
+type wavHeader struct {
+	RiffId        [4]byte
+	RiffSize      uint32
+	WaveId        [4]byte
+	FmtId         [4]byte
+	FmtSize       uint32
+	AudioFormat   uint16
+	Channels      uint16
+	SampleRate    uint32
+	ByteRate      uint32
+	BlockAlign    uint16
+	BitsPerSample uint16
+	DataId        [4]byte
+	DataSize      uint32
+}
+
+func newWAVHeader(channels int, sampleRate int, dataSize int) wavHeader {
+	return wavHeader{
+		RiffId:        [4]byte{'R', 'I', 'F', 'F'},
+		RiffSize:      uint32(WAV_HEADER_SIZE - 8 + dataSize),
+		WaveId:        [4]byte{'W', 'A', 'V', 'E'},
+		FmtId:         [4]byte{'f', 'm', 't', ' '},
+		FmtSize:       16,
+		AudioFormat:   1, // PCM
+		Channels:      uint16(channels),
+		SampleRate:    uint32(sampleRate),
+		ByteRate:      uint32(sampleRate * channels * 2),
+		BlockAlign:    uint16(channels * 2),
+		BitsPerSample: 16,
+		DataId:        [4]byte{'d', 'a', 't', 'a'},
+		DataSize:      uint32(dataSize),
+	}
+}
+
+// Writes a complete 16 bit PCM .wav file
+func WriteWAV(writer io.Writer, samples []int16, channels int, sampleRate int) error {
+	header := newWAVHeader(channels, sampleRate, len(samples)*2)
+	if err := binary.Write(writer, binary.LittleEndian, header); err != nil {
+		return fmt.Errorf("failed to write wav header: %w", err)
+	}
+	if err := binary.Write(writer, binary.LittleEndian, samples); err != nil {
+		return fmt.Errorf("failed to write wav samples: %w", err)
+	}
+	return nil
+}
+
+// Records the mixer output to a .wav file, used for headless tests
+// The sizes in the header are written when the backend is closed
+type WAVBackend struct {
+	Filename   string
+	file       *os.File
+	channels   int
+	sampleRate int
+	dataSize   int
+}
+
+func NewWAVBackend(filename string) *WAVBackend {
+	return &WAVBackend{Filename: filename}
+}
+
+func (backend *WAVBackend) Open(sampleRate int, channels int) error {
+	file, err := os.Create(backend.Filename)
+	if err != nil {
+		return fmt.Errorf("failed to create wav file %s: %w", backend.Filename, err)
+	}
+	backend.file = file
+	backend.channels = channels
+	backend.sampleRate = sampleRate
+	backend.dataSize = 0
+	return backend.writeHeader()
+}
+
+func (backend *WAVBackend) writeHeader() error {
+	if _, err := backend.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek wav file %s: %w", backend.Filename, err)
+	}
+	header := newWAVHeader(backend.channels, backend.sampleRate, backend.dataSize)
+	if err := binary.Write(backend.file, binary.LittleEndian, header); err != nil {
+		return fmt.Errorf("failed to write wav header %s: %w", backend.Filename, err)
+	}
+	return nil
+}
+
+func (backend *WAVBackend) Write(samples []int16) error {
+	if err := binary.Write(backend.file, binary.LittleEndian, samples); err != nil {
+		return fmt.Errorf("failed to write wav samples %s: %w", backend.Filename, err)
+	}
+	backend.dataSize += len(samples) * 2
+	return nil
+}
+
+func (backend *WAVBackend) Close() error {
+	if backend.file == nil {
+		return nil
+	}
+	defer func() {
+		backend.file = nil
+	}()
+	if err := backend.writeHeader(); err != nil {
+		backend.file.Close()
+		return err
+	}
+	return backend.file.Close()
+}
+
+func (backend *WAVBackend) IsRealtime() bool {
+	return false
+}
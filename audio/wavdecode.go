@@ -0,0 +1,173 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	WAV_FORMAT_PCM      = 1
+	WAV_FORMAT_MS_ADPCM = 2
+)
+
+type wavFormat struct {
+	AudioFormat   uint16
+	Channels      uint16
+	SampleRate    uint32
+	ByteRate      uint32
+	BlockAlign    uint16
+	BitsPerSample uint16
+}
+
+// Microsoft ADPCM step size changes
+var msAdpcmAdaptationTable = [16]int{
+	230, 230, 230, 230, 307, 409, 512, 614,
+	768, 614, 512, 409, 307, 230, 230, 230,
+}
+
+// Decodes a .wav file with PCM or Microsoft ADPCM samples
+// SAP files are the same data after an 8 byte header
+func DecodeWAV(data []byte) (*Sound, error) {
+	reader := bytes.NewReader(data)
+	riffHeader := struct {
+		RiffId   [4]byte
+		RiffSize uint32
+		WaveId   [4]byte
+	}{}
+	if err := binary.Read(reader, binary.LittleEndian, &riffHeader); err != nil {
+		return nil, fmt.Errorf("failed to read wav header: %w", err)
+	}
+	if string(riffHeader.RiffId[:]) != "RIFF" || string(riffHeader.WaveId[:]) != "WAVE" {
+		return nil, fmt.Errorf("invalid wav header %q", riffHeader.RiffId)
+	}
+
+	var format wavFormat
+	var formatExtra []byte
+	hasFormat := false
+	for {
+		chunkHeader := struct {
+			Id   [4]byte
+			Size uint32
+		}{}
+		if err := binary.Read(reader, binary.LittleEndian, &chunkHeader); err != nil {
+			return nil, fmt.Errorf("wav data chunk is missing: %w", err)
+		}
+		// Some files have a data size larger than the file
+		chunkData := make([]byte, min(int(chunkHeader.Size), reader.Len()))
+		io.ReadFull(reader, chunkData)
+		// Chunks are aligned to 2 bytes
+		if chunkHeader.Size%2 == 1 {
+			reader.ReadByte()
+		}
+
+		switch string(chunkHeader.Id[:]) {
+		case "fmt ":
+			if err := binary.Read(bytes.NewReader(chunkData), binary.LittleEndian, &format); err != nil {
+				return nil, fmt.Errorf("failed to read wav format: %w", err)
+			}
+			if len(chunkData) > 16 {
+				formatExtra = chunkData[16:]
+			}
+			hasFormat = true
+		case "data":
+			if !hasFormat {
+				return nil, fmt.Errorf("wav format chunk is missing")
+			}
+			return decodeWAVSamples(format, formatExtra, chunkData)
+		}
+	}
+}
+
+func decodeWAVSamples(format wavFormat, formatExtra []byte, data []byte) (*Sound, error) {
+	channels := int(format.Channels)
+	if channels < 1 || channels > 2 {
+		return nil, fmt.Errorf("unsupported wav channel count %d", channels)
+	}
+
+	switch {
+	case format.AudioFormat == WAV_FORMAT_PCM && format.BitsPerSample == 16:
+		samples := make([]int16, len(data)/2)
+		binary.Read(bytes.NewReader(data), binary.LittleEndian, samples)
+		return NewSound(samples, channels, int(format.SampleRate)), nil
+	case format.AudioFormat == WAV_FORMAT_PCM && format.BitsPerSample == 8:
+		samples := make([]int16, len(data))
+		for i, value := range data {
+			samples[i] = int16(int(value)-128) << 8
+		}
+		return NewSound(samples, channels, int(format.SampleRate)), nil
+	case format.AudioFormat == WAV_FORMAT_MS_ADPCM:
+		samples, err := decodeMSADPCM(format, formatExtra, data)
+		if err != nil {
+			return nil, err
+		}
+		return NewSound(samples, channels, int(format.SampleRate)), nil
+	}
+	return nil, fmt.Errorf("unsupported wav format %d with %d bits", format.AudioFormat, format.BitsPerSample)
+}
+
+func decodeMSADPCM(format wavFormat, formatExtra []byte, data []byte) ([]int16, error) {
+	// Extra format data has the block size and the prediction coefficients
+	extra := struct {
+		ExtraSize       uint16
+		SamplesPerBlock uint16
+		NumCoefficients uint16
+	}{}
+	extraReader := bytes.NewReader(formatExtra)
+	if err := binary.Read(extraReader, binary.LittleEndian, &extra); err != nil {
+		return nil, fmt.Errorf("failed to read adpcm format: %w", err)
+	}
+	coefficients := make([][2]int16, extra.NumCoefficients)
+	if err := binary.Read(extraReader, binary.LittleEndian, coefficients); err != nil {
+		return nil, fmt.Errorf("failed to read adpcm coefficients: %w", err)
+	}
+
+	channels := int(format.Channels)
+	blockSize := int(format.BlockAlign)
+	headerSize := 7 * channels
+	if blockSize <= headerSize {
+		return nil, fmt.Errorf("invalid adpcm block size %d", blockSize)
+	}
+
+	samples := make([]int16, 0, len(data)/blockSize*int(extra.SamplesPerBlock)*channels)
+	predictors := make([][2]int, channels)
+	deltas := make([]int, channels)
+	sample1 := make([]int, channels)
+	sample2 := make([]int, channels)
+	for offset := 0; offset+headerSize <= len(data); offset += blockSize {
+		block := data[offset:min(offset+blockSize, len(data))]
+		for c := 0; c < channels; c++ {
+			predictor := min(int(block[c]), len(coefficients)-1)
+			predictors[c] = [2]int{int(coefficients[predictor][0]), int(coefficients[predictor][1])}
+			deltas[c] = int(int16(binary.LittleEndian.Uint16(block[channels+c*2:])))
+			sample1[c] = int(int16(binary.LittleEndian.Uint16(block[channels*3+c*2:])))
+			sample2[c] = int(int16(binary.LittleEndian.Uint16(block[channels*5+c*2:])))
+		}
+		for c := 0; c < channels; c++ {
+			samples = append(samples, int16(sample2[c]))
+		}
+		for c := 0; c < channels; c++ {
+			samples = append(samples, int16(sample1[c]))
+		}
+
+		channel := 0
+		for _, value := range block[headerSize:] {
+			for _, nibble := range [2]uint8{value >> 4, value & 0x0F} {
+				signedNibble := int(nibble)
+				if signedNibble >= 8 {
+					signedNibble -= 16
+				}
+				predicted := (sample1[channel]*predictors[channel][0] + sample2[channel]*predictors[channel][1]) >> 8
+				sample := min(max(predicted+signedNibble*deltas[channel], -32768), 32767)
+				samples = append(samples, int16(sample))
+
+				sample2[channel] = sample1[channel]
+				sample1[channel] = sample
+				deltas[channel] = max(msAdpcmAdaptationTable[nibble]*deltas[channel]>>8, 16)
+				channel = (channel + 1) % channels
+			}
+		}
+	}
+	return samples, nil
+}
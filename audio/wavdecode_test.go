@@ -0,0 +1,73 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestDecodeWAVPCM(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	if err := WriteWAV(buffer, []int16{1, -2, 3, -4}, 2, 22050); err != nil {
+		t.Fatal(err)
+	}
+
+	sound, err := DecodeWAV(buffer.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sound.Channels != 2 || sound.SampleRate != 22050 || sound.FrameCount() != 2 {
+		t.Errorf("Unexpected sound %+v", sound)
+	}
+	if sound.Samples[3] != -4 {
+		t.Errorf("Expected last sample -4, got %d", sound.Samples[3])
+	}
+}
+
+func TestDecodeWAVMSADPCM(t *testing.T) {
+	// Mono, one block with 6 samples
+	coefficients := [][2]int16{{256, 0}, {512, -256}, {0, 0}, {192, 64}, {240, 0}, {460, -208}, {392, -232}}
+	formatChunk := &bytes.Buffer{}
+	binary.Write(formatChunk, binary.LittleEndian, wavFormat{
+		AudioFormat:   WAV_FORMAT_MS_ADPCM,
+		Channels:      1,
+		SampleRate:    22050,
+		BlockAlign:    9,
+		BitsPerSample: 4,
+	})
+	binary.Write(formatChunk, binary.LittleEndian, []uint16{32, 6, uint16(len(coefficients))})
+	binary.Write(formatChunk, binary.LittleEndian, coefficients)
+
+	// Predictor, delta, sample 1, sample 2, then nibbles +1, 0, -1, 0
+	block := []byte{0, 16, 0, 100, 0, 50, 0, 0x10, 0xF0}
+
+	file := &bytes.Buffer{}
+	file.WriteString("RIFF")
+	binary.Write(file, binary.LittleEndian, uint32(4+8+formatChunk.Len()+8+len(block)))
+	file.WriteString("WAVEfmt ")
+	binary.Write(file, binary.LittleEndian, uint32(formatChunk.Len()))
+	file.Write(formatChunk.Bytes())
+	file.WriteString("data")
+	binary.Write(file, binary.LittleEndian, uint32(len(block)))
+	file.Write(block)
+
+	sound, err := DecodeWAV(file.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []int16{50, 100, 116, 116, 100, 100}
+	if len(sound.Samples) != len(expected) {
+		t.Fatalf("Expected %d samples, got %v", len(expected), sound.Samples)
+	}
+	for i, value := range expected {
+		if sound.Samples[i] != value {
+			t.Errorf("Sample %d: expected %d, got %d", i, value, sound.Samples[i])
+		}
+	}
+}
+
+func TestDecodeWAVInvalid(t *testing.T) {
+	if _, err := DecodeWAV([]byte("not a wav file")); err == nil {
+		t.Error("Expected error for invalid data")
+	}
+}
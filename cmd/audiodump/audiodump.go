@@ -0,0 +1,341 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/audio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+)
+
+const INDEX_FILENAME = "index.json"
+
+type audioDumper struct {
+	dataFolder   string
+	outputFolder string
+	index        *AudioIndexJSON
+	banksByHash  map[string]*BankJSON
+	failedFiles  int
+}
+
+func main() {
+	var dataFolder string
+	var outputFolder string
+
+	flag.StringVar(&dataFolder, "data", "data", "Game data folder")
+	flag.StringVar(&outputFolder, "output", "audiodump", "Output folder for the .wav files and the index")
+	flag.Parse()
+
+	if _, err := os.Stat(dataFolder); os.IsNotExist(err) {
+		fmt.Println("Usage: audiodump [-data <data_folder>] [-output <output_folder>]")
+		fmt.Println("Writes every room, enemy and door sound bank, BGM and SAP sound to .wav files")
+		fmt.Println("Example: audiodump -data data -output audiodump")
+		fmt.Printf("Error: Data folder '%s' does not exist\n", dataFolder)
+		os.Exit(1)
+	}
+
+	dumper := &audioDumper{
+		dataFolder:   dataFolder,
+		outputFolder: outputFolder,
+		index: &AudioIndexJSON{
+			Banks:  make([]*BankJSON, 0),
+			Sounds: make([]*SoundJSON, 0),
+			Rooms:  make([]*RoomJSON, 0),
+		},
+		banksByHash: make(map[string]*BankJSON),
+	}
+	if err := filepath.WalkDir(dataFolder, dumper.visit); err != nil {
+		log.Fatalf("Failed to walk data folder: %v", err)
+	}
+
+	indexFilename := filepath.Join(outputFolder, INDEX_FILENAME)
+	if err := dumper.writeIndex(indexFilename); err != nil {
+		log.Fatalf("Failed to write index: %v", err)
+	}
+	fmt.Printf("Dumped %d banks, %d sounds and %d rooms to %s\n",
+		len(dumper.index.Banks), len(dumper.index.Sounds), len(dumper.index.Rooms), outputFolder)
+	if dumper.failedFiles > 0 {
+		fmt.Printf("%d files could not be read\n", dumper.failedFiles)
+	}
+}
+
+// Unreadable files are skipped so one bad file doesn't stop the dump
+func (dumper *audioDumper) visit(path string, entry fs.DirEntry, err error) error {
+	if err != nil {
+		return err
+	}
+	if entry.IsDir() {
+		return nil
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".rdt":
+		err = dumper.dumpRoom(path)
+	case ".do2":
+		err = dumper.dumpDoor(path)
+	case ".vh":
+		err = dumper.dumpVABFiles(path)
+	case ".sap":
+		err = dumper.dumpSAP(path)
+	}
+	if err != nil {
+		log.Printf("Skipping %s: %v", path, err)
+		dumper.failedFiles++
+	}
+	return nil
+}
+
+// Room files are named ROOM + stage + room id in hex + player
+func parseRoomFilename(path string) (int, int, int, bool) {
+	name := strings.ToUpper(filenameWithoutExtension(path))
+	var stageId, roomId, playerId int
+	if len(name) != 8 || !strings.HasPrefix(name, "ROOM") {
+		return 0, 0, 0, false
+	}
+	if _, err := fmt.Sscanf(name[4:], "%1d%2x%1d", &stageId, &roomId, &playerId); err != nil {
+		return 0, 0, 0, false
+	}
+	return stageId, roomId, playerId, true
+}
+
+func (dumper *audioDumper) dumpRoom(path string) error {
+	stageId, roomId, playerId, ok := parseRoomFilename(path)
+	if !ok {
+		return fmt.Errorf("unexpected room filename")
+	}
+	rdtOutput, err := fileio.LoadRDTFile(path)
+	if err != nil {
+		return err
+	}
+
+	name := strings.ToUpper(filenameWithoutExtension(path))
+	room := &RoomJSON{
+		Name:       name,
+		Source:     dumper.relativeSource(path),
+		StageId:    stageId,
+		RoomId:     roomId,
+		PlayerId:   playerId,
+		ReverbType: int(rdtOutput.Header.NumReverb),
+	}
+	if rdtOutput.RoomVABData != nil {
+		room.RoomBank, err = dumper.addBank("room", name, path, rdtOutput.RoomVABData, name)
+		if err != nil {
+			return err
+		}
+	}
+	// The room bank is still dumped if the enemy bank can't be read
+	enemyVABData, err := fileio.LoadRDT_EnemyVABFile(path)
+	if err != nil {
+		log.Printf("Skipping enemy sounds in %s: %v", path, err)
+	} else if enemyVABData != nil {
+		room.EnemyBank, err = dumper.addBank("enemy", name, path, enemyVABData, name)
+		if err != nil {
+			return err
+		}
+	}
+	dumper.index.Rooms = append(dumper.index.Rooms, room)
+	return nil
+}
+
+func (dumper *audioDumper) dumpDoor(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	do2Output, err := fileio.LoadDO2Stream(file, fileInfo.Size())
+	if err != nil {
+		return err
+	}
+
+	name := strings.ToUpper(filenameWithoutExtension(path))
+	vabOutput := &fileio.VABOutput{
+		VABHeaderOutput: do2Output.VABHeaderOutput,
+		VABDataOutput:   do2Output.VABDataOutput,
+	}
+	_, err = dumper.addBank("door", name, path, vabOutput, name)
+	return err
+}
+
+// Banks stored as a .vh header next to a .vb file with the same name
+func (dumper *audioDumper) dumpVABFiles(headerPath string) error {
+	dataPath := strings.TrimSuffix(headerPath, filepath.Ext(headerPath)) + ".vb"
+	if _, err := os.Stat(dataPath); err != nil {
+		dataPath = strings.TrimSuffix(headerPath, filepath.Ext(headerPath)) + ".VB"
+	}
+
+	headerData, err := os.ReadFile(headerPath)
+	if err != nil {
+		return err
+	}
+	if !bytes.HasPrefix(headerData, []byte("pBAV")) {
+		return fmt.Errorf("not a VAB header")
+	}
+	vabHeaderOutput, err := fileio.LoadVABHeaderStream(bytes.NewReader(headerData), int64(len(headerData)))
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(dataPath)
+	if err != nil {
+		return err
+	}
+	vabDataOutput, err := fileio.LoadVABDataStream(bytes.NewReader(data), int64(len(data)), vabHeaderOutput)
+	if err != nil {
+		return err
+	}
+
+	name := filenameWithoutExtension(headerPath)
+	vabOutput := &fileio.VABOutput{
+		VABHeaderOutput: vabHeaderOutput,
+		VABDataOutput:   vabDataOutput,
+	}
+	_, err = dumper.addBank("file", name, headerPath, vabOutput, dumper.relativeSource(headerPath))
+	return err
+}
+
+// SAP files are .wav files, which are decoded so every output is 16 bit PCM
+func (dumper *audioDumper) dumpSAP(path string) error {
+	sapOutput, err := fileio.LoadSAPFile(path)
+	if err != nil {
+		return err
+	}
+	sound, err := audio.DecodeWAV(sapOutput.AudioData)
+	if err != nil {
+		return err
+	}
+
+	soundType := "sound"
+	lowerPath := strings.ToLower(filepath.ToSlash(path))
+	if strings.Contains(lowerPath, "/bgm/") {
+		soundType = "bgm"
+	} else if strings.Contains(lowerPath, "/voice/") {
+		soundType = "voice"
+	}
+
+	source := dumper.relativeSource(path)
+	outputFile := filepath.Join("sounds", strings.TrimSuffix(source, filepath.Ext(source))+".wav")
+	if err := dumper.writeWAV(outputFile, sound.Samples, sound.Channels, sound.SampleRate); err != nil {
+		return err
+	}
+
+	dumper.index.Sounds = append(dumper.index.Sounds, &SoundJSON{
+		Type:       soundType,
+		Source:     source,
+		File:       filepath.ToSlash(outputFile),
+		Channels:   sound.Channels,
+		SampleRate: sound.SampleRate,
+		Seconds:    float64(sound.FrameCount()) / float64(sound.SampleRate),
+	})
+	return nil
+}
+
+// Returns the name of the bank in the index
+func (dumper *audioDumper) addBank(bankType string, name string, path string, vabOutput *fileio.VABOutput, reference string) (string, error) {
+	hash := hashBank(vabOutput)
+	if bank, exists := dumper.banksByHash[hash]; exists {
+		bank.References = append(bank.References, reference)
+		return bank.Name, nil
+	}
+
+	bank := &BankJSON{
+		Name:       bankType + "/" + name,
+		Type:       bankType,
+		Source:     dumper.relativeSource(path),
+		Programs:   convertProgramsToJSON(vabOutput.VABHeaderOutput),
+		Waveforms:  make([]WaveformJSON, 0),
+		References: []string{reference},
+	}
+	for vag, adpcmData := range vabOutput.VABDataOutput.VagData {
+		if adpcmData == nil {
+			continue
+		}
+		vagOutput := fileio.DecodeVAG(adpcmData)
+		outputFile := filepath.Join("banks", bankType, name, fmt.Sprintf("vag_%03d.wav", vag))
+		if err := dumper.writeWAV(outputFile, vagOutput.Samples, 1, audio.SPU_SAMPLE_RATE); err != nil {
+			return "", err
+		}
+
+		waveform := WaveformJSON{
+			Vag:        vag,
+			File:       filepath.ToSlash(outputFile),
+			Samples:    len(vagOutput.Samples),
+			SampleRate: audio.SPU_SAMPLE_RATE,
+			HasLoop:    vagOutput.HasLoop,
+		}
+		if vagOutput.HasLoop {
+			waveform.LoopStart = vagOutput.LoopStart
+			waveform.LoopEnd = len(vagOutput.Samples)
+		}
+		bank.Waveforms = append(bank.Waveforms, waveform)
+	}
+
+	dumper.banksByHash[hash] = bank
+	dumper.index.Banks = append(dumper.index.Banks, bank)
+	return bank.Name, nil
+}
+
+// Banks are the same if their tones and waveforms are the same
+func hashBank(vabOutput *fileio.VABOutput) string {
+	hasher := sha1.New()
+	binary.Write(hasher, binary.LittleEndian, vabOutput.VABHeaderOutput.Programs)
+	for _, tones := range vabOutput.VABHeaderOutput.Tones {
+		binary.Write(hasher, binary.LittleEndian, tones)
+	}
+	for _, adpcmData := range vabOutput.VABDataOutput.VagData {
+		binary.Write(hasher, binary.LittleEndian, uint32(len(adpcmData)))
+		hasher.Write(adpcmData)
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// Output filenames are relative to the output folder
+func (dumper *audioDumper) writeWAV(outputFile string, samples []int16, channels int, sampleRate int) error {
+	filename := filepath.Join(dumper.outputFolder, outputFile)
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return audio.WriteWAV(file, samples, channels, sampleRate)
+}
+
+func (dumper *audioDumper) writeIndex(filename string) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+	jsonData, err := json.MarshalIndent(dumper.index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, jsonData, 0644)
+}
+
+func (dumper *audioDumper) relativeSource(path string) string {
+	relativePath, err := filepath.Rel(dumper.dataFolder, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(relativePath)
+}
+
+func filenameWithoutExtension(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+)
+
+// JSON index of everything written by the dump
+
+type AudioIndexJSON struct {
+	Banks  []*BankJSON  `json:"banks"`
+	Sounds []*SoundJSON `json:"sounds"`
+	Rooms  []*RoomJSON  `json:"rooms"`
+}
+
+// Sound bank with its waveforms written as separate .wav files
+// Identical banks in different files are only written once
+type BankJSON struct {
+	Name       string         `json:"name"`
+	Type       string         `json:"type"` // room, enemy, door or file
+	Source     string         `json:"source"`
+	Programs   []ProgramJSON  `json:"programs"`
+	Waveforms  []WaveformJSON `json:"waveforms"`
+	References []string       `json:"references"` // Rooms and doors that use the bank
+}
+
+type ProgramJSON struct {
+	Index  int        `json:"index"`
+	Volume int        `json:"volume"`
+	Pan    int        `json:"pan"`
+	Tones  []ToneJSON `json:"tones"`
+}
+
+type ToneJSON struct {
+	Index        int  `json:"index"`
+	Vag          int  `json:"vag"`
+	NoteMin      int  `json:"note_min"`
+	NoteMax      int  `json:"note_max"`
+	Center       int  `json:"center"`
+	Shift        int  `json:"shift"`
+	Volume       int  `json:"volume"`
+	Pan          int  `json:"pan"`
+	Reverb       bool `json:"reverb"`
+	PitchBendMin int  `json:"pitch_bend_min"`
+	PitchBendMax int  `json:"pitch_bend_max"`
+	Adsr1        int  `json:"adsr1"`
+	Adsr2        int  `json:"adsr2"`
+}
+
+// Loop points are in samples, the loop ends at the last sample
+type WaveformJSON struct {
+	Vag        int    `json:"vag"`
+	File       string `json:"file"`
+	Samples    int    `json:"samples"`
+	SampleRate int    `json:"sample_rate"`
+	HasLoop    bool   `json:"has_loop"`
+	LoopStart  int    `json:"loop_start"`
+	LoopEnd    int    `json:"loop_end"`
+}
+
+// BGM, voice or other SAP file
+type SoundJSON struct {
+	Type       string  `json:"type"`
+	Source     string  `json:"source"`
+	File       string  `json:"file"`
+	Channels   int     `json:"channels"`
+	SampleRate int     `json:"sample_rate"`
+	Seconds    float64 `json:"seconds"`
+}
+
+type RoomJSON struct {
+	Name       string `json:"name"`
+	Source     string `json:"source"`
+	StageId    int    `json:"stage_id"`
+	RoomId     int    `json:"room_id"`
+	PlayerId   int    `json:"player_id"`
+	ReverbType int    `json:"reverb_type"`
+	RoomBank   string `json:"room_bank,omitempty"`
+	EnemyBank  string `json:"enemy_bank,omitempty"`
+}
+
+func convertProgramsToJSON(vabHeaderOutput *fileio.VABHeaderOutput) []ProgramJSON {
+	programs := make([]ProgramJSON, 0)
+	for i, program := range vabHeaderOutput.Programs {
+		tones := vabHeaderOutput.GetProgramTones(i)
+		if len(tones) == 0 {
+			continue
+		}
+
+		tonesJSON := make([]ToneJSON, len(tones))
+		for j, tone := range tones {
+			tonesJSON[j] = ToneJSON{
+				Index:        j,
+				Vag:          int(tone.Vag),
+				NoteMin:      int(tone.NoteMin),
+				NoteMax:      int(tone.NoteMax),
+				Center:       int(tone.Center),
+				Shift:        int(tone.Shift),
+				Volume:       int(tone.Volume),
+				Pan:          int(tone.Pan),
+				Reverb:       tone.Mode == fileio.VAB_TONE_MODE_REVERB,
+				PitchBendMin: int(tone.PitchBendMin),
+				PitchBendMax: int(tone.PitchBendMax),
+				Adsr1:        int(tone.Adsr1),
+				Adsr2:        int(tone.Adsr2),
+			}
+		}
+		programs = append(programs, ProgramJSON{
+			Index:  i,
+			Volume: int(program.Volume),
+			Pan:    int(program.Pan),
+			Tones:  tonesJSON,
+		})
+	}
+	return programs
+}
@@ -22,6 +22,7 @@ type DO2FileFormat struct {
 
 type DO2Output struct {
 	VABHeaderOutput *VABHeaderOutput
+	VABDataOutput   *VABDataOutput
 	MD1Output       *MD1Output
 	TIMOutput       *TIMOutput
 	DO2FileFormat   *DO2FileFormat
@@ -124,6 +125,7 @@ func LoadDO2Stream(r io.ReaderAt, fileLength int64) (*DO2Output, error) {
 
 	output := &DO2Output{
 		VABHeaderOutput: vabHeaderOutput,
+		VABDataOutput:   vabDataOutput,
 		MD1Output:       md1Output,
 		TIMOutput:       timOutput,
 		DO2FileFormat:   do2FileFormat,
@@ -17,7 +17,7 @@ type RDTHeader struct {
 	NumItems   uint8
 	NumDoors   uint8
 	NumRooms   uint8
-	NumReverb  uint8 // related to sound
+	NumReverb  uint8 // reverb type of the room sounds, see audio.REVERB_TYPE_*
 	SpriteMax  uint8 // max number of .pri sprites used by one of the room's cameras
 }
 
@@ -63,6 +63,25 @@ type RDTOutput struct {
 	SpriteOutput     *ESPOutput
 	ItemTextureData  []*TIMOutput
 	ItemModelData    []*MD1Output
+	MessageData      *MSGOutput
+	RoomVABData      *VABOutput
+	FloorSoundData   *FLROutput
+}
+
+// The offsets of every section in the room file
+func LoadRDTHeader(r io.ReaderAt, fileLength int64) (RDTHeader, RDTOffsets, error) {
+	reader := io.NewSectionReader(r, int64(0), fileLength)
+
+	rdtHeader := RDTHeader{}
+	if err := binary.Read(reader, binary.LittleEndian, &rdtHeader); err != nil {
+		return RDTHeader{}, RDTOffsets{}, err
+	}
+
+	offsets := RDTOffsets{}
+	if err := binary.Read(reader, binary.LittleEndian, &offsets); err != nil {
+		return RDTHeader{}, RDTOffsets{}, err
+	}
+	return rdtHeader, offsets, nil
 }
 
 func LoadRDTFile(filename string) (*RDTOutput, error) {
@@ -82,15 +101,8 @@ func LoadRDTFile(filename string) (*RDTOutput, error) {
 }
 
 func LoadRDT(r io.ReaderAt, fileLength int64) (*RDTOutput, error) {
-	reader := io.NewSectionReader(r, int64(0), fileLength)
-
-	rdtHeader := RDTHeader{}
-	if err := binary.Read(reader, binary.LittleEndian, &rdtHeader); err != nil {
-		return nil, err
-	}
-
-	offsets := RDTOffsets{}
-	if err := binary.Read(reader, binary.LittleEndian, &offsets); err != nil {
+	rdtHeader, offsets, err := LoadRDTHeader(r, fileLength)
+	if err != nil {
 		return nil, err
 	}
 
@@ -158,10 +170,14 @@ func LoadRDT(r io.ReaderAt, fileLength int64) (*RDTOutput, error) {
 		}
 	}
 
+	var msgOutput *MSGOutput
 	offset := int64(offsets.OffsetLang1)
 	if offset > 0 {
 		lang1MsgReader := io.NewSectionReader(r, offset, fileLength-offset)
-		LoadRDT_MSGStream(lang1MsgReader, fileLength)
+		msgOutput, err = LoadRDT_MSGStream(lang1MsgReader, fileLength)
+		if err != nil {
+			log.Printf("Warning: failed to read room messages: %v", err)
+		}
 	}
 
 	// Script data
@@ -188,12 +204,12 @@ func LoadRDT(r io.ReaderAt, fileLength int64) (*RDTOutput, error) {
 	}
 
 	// Audio
-	_, err = LoadRDT_VABStream(r, fileLength, offsets)
+	roomVABOutput, err := LoadRDT_VABStream(r, fileLength, offsets)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = LoadRDT_FLRStream(r, fileLength, offsets)
+	flrOutput, err := LoadRDT_FLRStream(r, fileLength, offsets)
 	if err != nil {
 		return nil, err
 	}
@@ -209,6 +225,9 @@ func LoadRDT(r io.ReaderAt, fileLength int64) (*RDTOutput, error) {
 		SpriteOutput:     espOutput,
 		ItemTextureData:  itemTextureData,
 		ItemModelData:    itemModelData,
+		MessageData:      msgOutput,
+		RoomVABData:      roomVABOutput,
+		FloorSoundData:   flrOutput,
 	}
 	return output, nil
 }
@@ -20,6 +20,7 @@ type LITLightColor struct {
 }
 
 type LITCameraLight struct {
+	// The meaning of the values isn't known, so the renderer treats every light as a point light
 	LightType    [2]uint16
 	Colors       [3]LITLightColor // Color for each light
 	AmbientColor LITLightColor    // Ambient color for camera
@@ -6,9 +6,31 @@ import (
 	"encoding/binary"
 	"io"
 	"log"
+	"strings"
+)
+
+const (
+	MSG_CODE_QUESTION_MARK = 0xF3
+	MSG_CODE_ITEM_NAME     = 0xF8 // followed by item id
+	MSG_CODE_COLOR         = 0xF9 // followed by color
+	MSG_CODE_START         = 0xFA // followed by 1 byte
+	MSG_CODE_YES_NO        = 0xFB // followed by 1 byte
+	MSG_CODE_NEWLINE       = 0xFC
+	MSG_CODE_PAGE_BREAK    = 0xFD // followed by 1 byte
+	MSG_CODE_END           = 0xFE // followed by 1 byte
+
+	// Characters after this are control codes
+	MSG_MAX_CHARACTER = 96
 )
 
 type MSGOutput struct {
+	Messages []MSGMessage
+}
+
+// Each page is shown until the player presses a button
+type MSGMessage struct {
+	Pages     []string // Lines are separated by newlines
+	HasChoice bool     // Message ends with a yes/no question
 }
 
 var (
@@ -40,6 +62,7 @@ func LoadRDT_MSGStream(fileReader io.ReaderAt, fileLength int64) (*MSGOutput, er
 		offsets = append(offsets, nextOffset)
 	}
 
+	messages := make([]MSGMessage, 0, len(offsets))
 	for i := 0; i < len(offsets)-1; i++ {
 		if offsets[i] >= offsets[i+1] {
 			log.Fatal("MSG offsets are not sorted")
@@ -49,7 +72,7 @@ func LoadRDT_MSGStream(fileReader io.ReaderAt, fileLength int64) (*MSGOutput, er
 		if err := binary.Read(streamReader, binary.LittleEndian, &textData); err != nil {
 			return nil, err
 		}
-		convertBytesToText(textData)
+		messages = append(messages, ConvertBytesToMessage(textData))
 	}
 
 	// Read last message
@@ -61,34 +84,69 @@ func LoadRDT_MSGStream(fileReader io.ReaderAt, fileLength int64) (*MSGOutput, er
 		}
 
 		textData = append(textData, nextChar)
-		if nextChar == 0xFE {
+		if nextChar == MSG_CODE_END {
 			break
 		}
 	}
-	convertBytesToText(textData)
+	messages = append(messages, ConvertBytesToMessage(textData))
 
-	return nil, nil
+	return &MSGOutput{
+		Messages: messages,
+	}, nil
 }
 
-func convertBytesToText(byteData []uint8) []string {
-	textData := make([]string, len(byteData))
-
-	for i, number := range byteData {
-		if number >= 96 {
-			if number == 0xF3 {
-				textData[i] = string("?")
-			}
-			if number == 0xFC {
-				textData[i] = string("\n")
-			}
+func ConvertBytesToMessage(byteData []uint8) MSGMessage {
+	message := MSGMessage{
+		Pages:     make([]string, 0),
+		HasChoice: false,
+	}
 
+	var page strings.Builder
+	for i := 0; i < len(byteData); i++ {
+		number := byteData[i]
+		if number < MSG_MAX_CHARACTER {
+			row := number / 16
+			column := number % 16
+			page.WriteByte(convertText[row][column])
 			continue
 		}
 
-		row := number / 16
-		column := number % 16
-		textData[i] = string(convertText[row][column])
+		switch number {
+		case MSG_CODE_QUESTION_MARK:
+			page.WriteString("?")
+		case MSG_CODE_NEWLINE:
+			page.WriteString("\n")
+		case MSG_CODE_ITEM_NAME, MSG_CODE_COLOR, MSG_CODE_START:
+			// Skip parameter
+			i++
+		case MSG_CODE_YES_NO:
+			message.HasChoice = true
+			i++
+		case MSG_CODE_PAGE_BREAK:
+			message.Pages = append(message.Pages, page.String())
+			page.Reset()
+			i++
+		case MSG_CODE_END:
+			i = len(byteData)
+		}
 	}
 
-	return textData
+	if page.Len() > 0 || len(message.Pages) == 0 {
+		message.Pages = append(message.Pages, page.String())
+	}
+	return message
+}
+
+// Returns the position of the character in the font
+func GetMSGCharacterIndex(character byte) (int, bool) {
+	if character == '_' {
+		return 0, false
+	}
+	for row, rowText := range convertText {
+		column := strings.IndexByte(rowText, character)
+		if column >= 0 {
+			return row*16 + column, true
+		}
+	}
+	return 0, false
 }
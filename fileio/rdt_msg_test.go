@@ -0,0 +1,53 @@
+package fileio
+
+import (
+	"testing"
+)
+
+func TestConvertBytesToMessage(t *testing.T) {
+	// "Hi" newline "Hi" page break "Hi?" with a yes/no question
+	byteData := []uint8{
+		MSG_CODE_START, 0,
+		0x24, 0x45, MSG_CODE_NEWLINE, 0x24, 0x45,
+		MSG_CODE_PAGE_BREAK, 0,
+		0x24, 0x45, 0x1b,
+		MSG_CODE_YES_NO, 0,
+		MSG_CODE_END, 0,
+	}
+
+	message := ConvertBytesToMessage(byteData)
+	expectedPages := []string{"Hi\nHi", "Hi?"}
+	if len(message.Pages) != len(expectedPages) {
+		t.Fatalf("Expected %d pages, got %d: %q", len(expectedPages), len(message.Pages), message.Pages)
+	}
+	for i, expectedPage := range expectedPages {
+		if message.Pages[i] != expectedPage {
+			t.Errorf("Expected page %d to be %q, got %q", i, expectedPage, message.Pages[i])
+		}
+	}
+	if !message.HasChoice {
+		t.Error("Expected message to have a yes/no choice")
+	}
+}
+
+func TestGetMSGCharacterIndex(t *testing.T) {
+	tests := []struct {
+		character     byte
+		expectedIndex int
+		expectedFound bool
+	}{
+		{' ', 0, true},
+		{'0', 0x0c, true},
+		{'H', 0x24, true},
+		{'i', 0x45, true},
+		{'_', 0, false},
+		{'@', 0, false},
+	}
+
+	for _, test := range tests {
+		index, found := GetMSGCharacterIndex(test.character)
+		if index != test.expectedIndex || found != test.expectedFound {
+			t.Errorf("Character %q: expected (%d, %v), got (%d, %v)", test.character, test.expectedIndex, test.expectedFound, index, found)
+		}
+	}
+}
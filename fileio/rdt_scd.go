@@ -110,6 +110,7 @@ const (
 	OP_SCE_SCR_MOVE     = 109
 	OP_PARTS_SET        = 110
 	OP_MOVIE_ON         = 111
+	OP_SCE_ITEM_GET     = 118
 	OP_SCE_PARTS_BOMB   = 122
 	OP_SCE_PARTS_DOWN   = 123
 )
@@ -217,6 +218,7 @@ var (
 		OP_SCE_SCR_MOVE:     4,
 		OP_PARTS_SET:        6,
 		OP_MOVIE_ON:         2,
+		OP_SCE_ITEM_GET:     3,
 		OP_SCE_PARTS_BOMB:   16,
 		OP_SCE_PARTS_DOWN:   16,
 	}
@@ -378,12 +380,50 @@ type ScriptInstrPosSet struct {
 	Z      int16
 }
 
+type ScriptInstrMessageOn struct {
+	Opcode    uint8 // 0x2b
+	Dummy     uint8
+	MessageId uint8 // Index in the room messages
+	Unknown0  uint8
+	Unknown1  uint16
+}
+
+type ScriptInstrSpeedSet struct {
+	Opcode uint8 // 0x2f
+	Id     uint8 // 0-2 is speed x/y/z, 3-5 is angular speed x/y/z
+	Value  int16
+}
+
+type ScriptInstrDirSet struct {
+	Opcode uint8 // 0x33
+	Dummy  uint8
+	X      int16
+	Y      int16
+	Z      int16
+}
+
 type ScriptInstrMemberSet struct {
 	Opcode      uint8 // 0x34
 	MemberIndex uint8
 	Value       uint16
 }
 
+type ScriptInstrMemberSet2 struct {
+	Opcode      uint8 // 0x35
+	MemberIndex uint8
+	VarId       uint8 // Script variable with the new value
+}
+
+type ScriptInstrSeOn struct {
+	Opcode uint8 // 0x36
+	Vab    uint8 // Sound bank
+	Edt    uint16
+	Data0  uint16
+	X      int16
+	Y      int16
+	Z      int16
+}
+
 type ScriptInstrScaIdSet struct {
 	Opcode uint8 // 0x37
 	Id     uint8
@@ -426,6 +466,25 @@ type ScriptInstrCutAuto struct {
 	FlagOn uint8
 }
 
+type ScriptInstrCutReplace struct {
+	Opcode      uint8 // 0x4b
+	OldCameraId uint8
+	NewCameraId uint8
+}
+
+type ScriptInstrCutBeSet struct {
+	Opcode   uint8 // 0x61
+	CameraId uint8
+	Dummy    uint8
+	On       uint8 // 0: switches to the camera are ignored, 1: switches are used
+}
+
+type ScriptInstrMemberCopy struct {
+	Opcode      uint8 // 0x3d
+	VarId       uint8 // Script variable to copy the member to
+	MemberIndex uint8
+}
+
 type ScriptInstrMemberCompare struct {
 	Opcode           uint8 // 0x3e
 	Unknown0         uint8
@@ -490,6 +549,27 @@ type ScriptInstrAotReset struct {
 	Data   [6]uint8
 }
 
+type ScriptInstrAotOn struct {
+	Opcode uint8 // 0x47
+	Aot    uint8
+}
+
+type ScriptInstrSuperSet struct {
+	Opcode        uint8 // 0x48
+	Super         uint8 // Aots with this super number move with the entity
+	WorkComponent uint8
+	WorkIndex     uint8
+	Offset        [3]int16 // Position relative to the entity
+	Rotation      [3]int16
+}
+
+type ScriptInstrSceTrgCk struct {
+	Opcode uint8 // 0x50
+	Aot    uint8
+	Dummy  uint8
+	On     uint8 // 0: checks if the aot is disabled, 1: checks if the aot is enabled
+}
+
 type ScriptInstrSceEsprKill struct {
 	Opcode        uint8 // 0x4c
 	Id            uint8
@@ -498,6 +578,11 @@ type ScriptInstrSceEsprKill struct {
 	WorkIndex     uint8
 }
 
+type ScriptInstrSceEsprKill2 struct {
+	Opcode uint8 // 0x65
+	Id     uint8
+}
+
 type ScriptInstrDoorModelSet struct {
 	Opcode      uint8 // 0x4d
 	Index       uint8
@@ -545,10 +630,19 @@ type ScriptInstrSceEsprControl struct {
 	WorkIndex     uint8
 }
 
+type ScriptInstrSceFadeSet struct {
+	Opcode    uint8 // 0x53
+	Direction uint8 // 0: fade in, 1: fade out
+	Color     uint8 // Bit 0: red, bit 1: green, bit 2: blue
+	BlendMode uint8
+	Duration  uint16 // Number of frames
+}
+
 type ScriptInstrSceEspr3DOn struct {
 	Opcode   uint8 // 0x54
 	Dummy    uint8
-	Unknown0 uint16
+	Id       uint8
+	Type     uint8
 	Work     uint16
 	Unknown1 uint16
 	Vector1  [3]int16
@@ -556,21 +650,56 @@ type ScriptInstrSceEspr3DOn struct {
 	DirY     uint16
 }
 
+type ScriptInstrSceBgmTblSet struct {
+	Opcode  uint8 // 0x57
+	Dummy   uint8
+	RoomId  uint8
+	StageId uint8  // Starts at 0
+	Data0   uint16 // Main track in the low byte, sub track 0 in the high byte
+	Data1   uint16 // Sub track 1 in the low byte
+}
+
 type ScriptInstrPlcRot struct {
 	Opcode uint8 // 0x58
 	Index  uint8 // 0 or 1
 	Value  int16
 }
 
+type ScriptInstrPlcCnt struct {
+	Opcode uint8 // 0x5b
+	Count  uint8
+}
+
 type ScriptInstrXaOn struct {
 	Opcode  uint8 // 0x59
 	Channel uint8 // channel on which to play sound
 	Id      int16 // ID of sound to play
 }
 
+type ScriptInstrWeaponChg struct {
+	Opcode   uint8 // 0x5a
+	WeaponId uint8 // 0 puts the weapon away
+}
+
+type ScriptInstrSceShakeOn struct {
+	Opcode    uint8 // 0x5c
+	Amplitude uint8 // Pixels
+	Duration  uint8 // Number of frames
+}
+
 type ScriptInstrMizuDivSet struct {
 	Opcode     uint8 // 0x5d
-	MizuDivMax uint8
+	MizuDivMax uint8 // Water level, 0 removes the water
+}
+
+type ScriptInstrKeepItemCk struct {
+	Opcode uint8 // 0x5e
+	ItemId uint8
+}
+
+type ScriptInstrXaVol struct {
+	Opcode uint8 // 0x5f
+	Volume uint8 // 0-127
 }
 
 type ScriptInstrKageSet struct {
@@ -582,6 +711,11 @@ type ScriptInstrKageSet struct {
 	OffsetX, OffsetZ int16
 }
 
+type ScriptInstrSceItemLost struct {
+	Opcode uint8 // 0x62
+	ItemId uint8
+}
+
 type ScriptInstrAotSet4p struct {
 	Opcode uint8 // 0x67
 	Aot    uint8
@@ -636,6 +770,26 @@ type ScriptInstrItemAotSet4p struct {
 	Act             uint8
 }
 
+type ScriptInstrLightPosSet struct {
+	Opcode   uint8 // 0x6a
+	Dummy    uint8
+	Index    uint8 // Light number
+	Axis     uint8 // 0: x, 1: y, 2: z
+	Position int16
+}
+
+type ScriptInstrLightKidoSet struct {
+	Opcode     uint8 // 0x6b
+	Index      uint8 // Light number
+	Brightness int16
+}
+
+type ScriptInstrSceItemGet struct {
+	Opcode uint8 // 0x76
+	ItemId uint8
+	Amount uint8
+}
+
 type SCDOutput struct {
 	ScriptData ScriptFunction
 }
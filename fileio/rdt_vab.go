@@ -1,26 +1,74 @@
 package fileio
 
 import (
+	"fmt"
 	"io"
+	"os"
 )
 
+// Sound bank with the instruments used by the room
 type VABOutput struct {
+	VABHeaderOutput *VABHeaderOutput
+	VABDataOutput   *VABDataOutput
 }
 
 func LoadRDT_VABStream(r io.ReaderAt, fileLength int64, offsets RDTOffsets) (*VABOutput, error) {
-	offset := int64(offsets.OffsetRoomVABHeader)
-	vabHeaderReader := io.NewSectionReader(r, offset, fileLength-offset)
+	return loadRDT_VAB(r, fileLength, int64(offsets.OffsetRoomVABHeader), int64(offsets.OffsetRoomVABData))
+}
+
+// The game doesn't use the enemy sound bank yet, so LoadRDT skips it
+// Returns nil if the room has no enemy sound bank
+func LoadRDT_EnemyVABFile(filename string) (*VABOutput, error) {
+	rdtFile, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open RDT file %s: %w", filename, err)
+	}
+	defer rdtFile.Close()
+
+	fi, err := rdtFile.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat RDT file %s: %w", filename, err)
+	}
+
+	fileLength := fi.Size()
+	_, offsets, err := LoadRDTHeader(rdtFile, fileLength)
+	if err != nil {
+		return nil, err
+	}
+	return LoadRDT_EnemyVABStream(rdtFile, fileLength, offsets)
+}
+
+// Returns nil if the room has no enemy sound bank
+func LoadRDT_EnemyVABStream(r io.ReaderAt, fileLength int64, offsets RDTOffsets) (*VABOutput, error) {
+	offset := int64(offsets.OffsetEnemyVABHeader)
+	if offset == 0 || offsets.OffsetEnemyVABData == 0 || offset+4 > fileLength {
+		return nil, nil
+	}
+	magic := make([]byte, 4)
+	if _, err := r.ReadAt(magic, offset); err != nil {
+		return nil, err
+	}
+	if string(magic) != "pBAV" {
+		return nil, nil
+	}
+	return loadRDT_VAB(r, fileLength, offset, int64(offsets.OffsetEnemyVABData))
+}
+
+func loadRDT_VAB(r io.ReaderAt, fileLength int64, headerOffset int64, dataOffset int64) (*VABOutput, error) {
+	vabHeaderReader := io.NewSectionReader(r, headerOffset, fileLength-headerOffset)
 	vabHeaderOutput, err := LoadVABHeaderStream(vabHeaderReader, fileLength)
 	if err != nil {
 		return nil, err
 	}
 
-	offset = int64(offsets.OffsetRoomVABData)
-	vabDataReader := io.NewSectionReader(r, int64(offset), fileLength-int64(offset))
-	_, err = LoadVABDataStream(vabDataReader, fileLength, vabHeaderOutput)
+	vabDataReader := io.NewSectionReader(r, dataOffset, fileLength-dataOffset)
+	vabDataOutput, err := LoadVABDataStream(vabDataReader, fileLength, vabHeaderOutput)
 	if err != nil {
 		return nil, err
 	}
 
-	return &VABOutput{}, nil
+	return &VABOutput{
+		VABHeaderOutput: vabHeaderOutput,
+		VABDataOutput:   vabDataOutput,
+	}, nil
 }
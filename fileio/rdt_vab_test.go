@@ -0,0 +1,22 @@
+package fileio
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLoadRDT_EnemyVABStreamWithoutBank(t *testing.T) {
+	data := make([]byte, 64)
+	reader := bytes.NewReader(data)
+
+	vabOutput, err := LoadRDT_EnemyVABStream(reader, int64(len(data)), RDTOffsets{})
+	if err != nil || vabOutput != nil {
+		t.Errorf("Expected no bank without an offset, got %v, %v", vabOutput, err)
+	}
+
+	offsets := RDTOffsets{OffsetEnemyVABHeader: 16, OffsetEnemyVABData: 32}
+	vabOutput, err = LoadRDT_EnemyVABStream(reader, int64(len(data)), offsets)
+	if err != nil || vabOutput != nil {
+		t.Errorf("Expected no bank without a VAB header, got %v, %v", vabOutput, err)
+	}
+}
@@ -19,6 +19,9 @@ func LoadSAPFile(filename string) (*SAPOutput, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read SAP file %s: %w", filename, err)
 	}
+	if len(buffer) < 8 {
+		return nil, fmt.Errorf("SAP file %s is too small", filename)
+	}
 
 	return &SAPOutput{
 		AudioData: buffer[8:],
@@ -0,0 +1,113 @@
+package fileio
+
+// .srt - Subtitle file placed next to a voice file
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+type SRTOutput struct {
+	Subtitles []SRTSubtitle
+}
+
+// Times are in seconds from the start of the voice line
+type SRTSubtitle struct {
+	Start float64
+	End   float64
+	Text  string // Lines are separated by newlines
+}
+
+func LoadSRTFile(filename string) (*SRTOutput, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	srtOutput, err := LoadSRTStream(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subtitle file %s: %w", filename, err)
+	}
+	return srtOutput, nil
+}
+
+// Each block is an index line, a time range line and the text lines
+// Blocks are separated by blank lines
+func LoadSRTStream(r io.Reader) (*SRTOutput, error) {
+	subtitles := make([]SRTSubtitle, 0)
+	scanner := bufio.NewScanner(r)
+
+	lineNumber := 0
+	var block []string
+	flushBlock := func() error {
+		defer func() { block = block[:0] }()
+		if len(block) == 0 {
+			return nil
+		}
+		if len(block) < 2 {
+			return fmt.Errorf("incomplete subtitle before line %d", lineNumber)
+		}
+		start, end, err := parseSRTTimeRange(block[1])
+		if err != nil {
+			return fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		subtitles = append(subtitles, SRTSubtitle{
+			Start: start,
+			End:   end,
+			Text:  strings.Join(block[2:], "\n"),
+		})
+		return nil
+	}
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if line == "" {
+			if err := flushBlock(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		block = append(block, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flushBlock(); err != nil {
+		return nil, err
+	}
+
+	return &SRTOutput{
+		Subtitles: subtitles,
+	}, nil
+}
+
+// Format is "00:00:01,500 --> 00:00:03,000"
+func parseSRTTimeRange(line string) (float64, float64, error) {
+	parts := strings.Split(line, "-->")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid time range %q", line)
+	}
+	start, err := parseSRTTime(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := parseSRTTime(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+func parseSRTTime(value string) (float64, error) {
+	var hours, minutes, seconds, milliseconds int
+	_, err := fmt.Sscanf(strings.Replace(value, ".", ",", 1), "%d:%d:%d,%d", &hours, &minutes, &seconds, &milliseconds)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", value)
+	}
+	return float64(hours*3600+minutes*60+seconds) + float64(milliseconds)/1000.0, nil
+}
@@ -0,0 +1,34 @@
+package fileio
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadSRTStream(t *testing.T) {
+	data := "1\n00:00:00,500 --> 00:00:02,000\nLeon!\n\n2\r\n00:00:02.250 --> 00:01:03,000\r\nOver here.\r\nHurry!\r\n"
+
+	srtOutput, err := LoadSRTStream(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []SRTSubtitle{
+		{Start: 0.5, End: 2.0, Text: "Leon!"},
+		{Start: 2.25, End: 63.0, Text: "Over here.\nHurry!"},
+	}
+	if len(srtOutput.Subtitles) != len(expected) {
+		t.Fatalf("Expected %d subtitles, got %d", len(expected), len(srtOutput.Subtitles))
+	}
+	for i, subtitle := range srtOutput.Subtitles {
+		if subtitle != expected[i] {
+			t.Errorf("Subtitle %d: expected %+v, got %+v", i, expected[i], subtitle)
+		}
+	}
+}
+
+func TestLoadSRTStreamInvalidTime(t *testing.T) {
+	_, err := LoadSRTStream(strings.NewReader("1\nnot a time\nText\n"))
+	if err == nil {
+		t.Error("Expected an error for an invalid time range")
+	}
+}
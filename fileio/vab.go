@@ -8,6 +8,11 @@ import (
 	"log"
 )
 
+const (
+	VAB_TONE_MODE_NORMAL = 0
+	VAB_TONE_MODE_REVERB = 4
+)
+
 type VABHeader struct {
 	Magic          [4]byte // "pBAV"
 	Version        uint32  // format version
@@ -61,12 +66,15 @@ type VABTone struct {
 
 type VABHeaderOutput struct {
 	VABHeader  VABHeader
+	Programs   []VABProgram
+	Tones      [][]VABTone // 16 tones for each program
 	AudioSizes []uint16
 	NumBytes   int
 }
 
 type VABDataOutput struct {
 	RawADPCMData [][]uint8
+	VagData      [][]uint8 // Indexed by VABTone.Vag, nil if the waveform is empty
 	NumBytes     int
 }
 
@@ -90,11 +98,13 @@ func LoadVABHeaderStream(r io.ReaderAt, fileLength int64) (*VABHeaderOutput, err
 		return nil, err
 	}
 
+	programTones := make([][]VABTone, vabHeader.ProgramCount)
 	for i := 0; i < int(vabHeader.ProgramCount); i++ {
 		tones := make([]VABTone, 16)
 		if err := binary.Read(vabHeaderReader, binary.LittleEndian, &tones); err != nil {
 			return nil, err
 		}
+		programTones[i] = tones
 	}
 
 	audioSizes := make([]uint16, vabHeader.WaveformCount+1)
@@ -109,6 +119,8 @@ func LoadVABHeaderStream(r io.ReaderAt, fileLength int64) (*VABHeaderOutput, err
 	totalVabHeaderSize := headerSize + totalProgramSize + totalToneSize + totalWaveformSize
 	vabHeaderOutput := &VABHeaderOutput{
 		VABHeader:  vabHeader,
+		Programs:   programData[:vabHeader.ProgramCount],
+		Tones:      programTones,
 		AudioSizes: audioSizes,
 		NumBytes:   totalVabHeaderSize,
 	}
@@ -119,6 +131,7 @@ func LoadVABDataStream(r io.ReaderAt, fileLength int64, vabHeaderOutput *VABHead
 	vabDataReader := io.NewSectionReader(r, int64(0), fileLength)
 
 	rawADPCMData := make([][]uint8, 0)
+	vagData := make([][]uint8, len(vabHeaderOutput.AudioSizes))
 	totalBytes := 0
 	for i := 0; i < len(vabHeaderOutput.AudioSizes); i++ {
 		rawAudioSize := int(vabHeaderOutput.AudioSizes[i])
@@ -131,13 +144,24 @@ func LoadVABDataStream(r io.ReaderAt, fileLength int64, vabHeaderOutput *VABHead
 			return nil, err
 		}
 		rawADPCMData = append(rawADPCMData, adpcmData)
+		vagData[i] = adpcmData
 		totalBytes += len(adpcmData)
 	}
 
 	vabDataOutput := &VABDataOutput{
 		RawADPCMData: rawADPCMData,
+		VagData:      vagData,
 		NumBytes:     totalBytes,
 	}
 
 	return vabDataOutput, nil
 }
+
+// Returns the program's tones that are in use
+func (vabHeaderOutput *VABHeaderOutput) GetProgramTones(program int) []VABTone {
+	if program < 0 || program >= len(vabHeaderOutput.Tones) {
+		return nil
+	}
+	toneCount := min(int(vabHeaderOutput.Programs[program].Tones), len(vabHeaderOutput.Tones[program]))
+	return vabHeaderOutput.Tones[program][:toneCount]
+}
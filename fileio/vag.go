@@ -0,0 +1,66 @@
+package fileio
+
+// .vag - Playstation 1 ADPCM waveform
+
+const (
+	VAG_BLOCK_SIZE        = 16
+	VAG_SAMPLES_PER_BLOCK = 28
+
+	VAG_FLAG_LOOP_END   = 0x01
+	VAG_FLAG_LOOP       = 0x02
+	VAG_FLAG_LOOP_START = 0x04
+)
+
+// Prediction filters used by the SPU
+var vagFilters = [5][2]int{
+	{0, 0},
+	{60, 0},
+	{115, -52},
+	{98, -55},
+	{122, -60},
+}
+
+type VAGOutput struct {
+	Samples   []int16
+	LoopStart int // Sample where the loop starts
+	HasLoop   bool
+}
+
+func DecodeVAG(adpcmData []uint8) *VAGOutput {
+	output := &VAGOutput{
+		Samples: make([]int16, 0, len(adpcmData)/VAG_BLOCK_SIZE*VAG_SAMPLES_PER_BLOCK),
+	}
+
+	previous1 := 0
+	previous2 := 0
+	for offset := 0; offset+VAG_BLOCK_SIZE <= len(adpcmData); offset += VAG_BLOCK_SIZE {
+		block := adpcmData[offset : offset+VAG_BLOCK_SIZE]
+		shift := int(block[0] & 0x0F)
+		if shift > 12 {
+			shift = 9
+		}
+		filter := vagFilters[min(int(block[0]>>4), len(vagFilters)-1)]
+		flags := block[1]
+
+		if flags&VAG_FLAG_LOOP_START != 0 {
+			output.LoopStart = len(output.Samples)
+		}
+
+		for _, value := range block[2:] {
+			for _, nibble := range [2]uint8{value & 0x0F, value >> 4} {
+				sample := int(int16(uint16(nibble)<<12)) >> shift
+				sample += (previous1*filter[0] + previous2*filter[1] + 32) / 64
+				sample = min(max(sample, -32768), 32767)
+				output.Samples = append(output.Samples, int16(sample))
+				previous2 = previous1
+				previous1 = sample
+			}
+		}
+
+		if flags&VAG_FLAG_LOOP_END != 0 {
+			output.HasLoop = flags&VAG_FLAG_LOOP != 0
+			break
+		}
+	}
+	return output
+}
@@ -0,0 +1,45 @@
+package fileio
+
+import (
+	"testing"
+)
+
+func TestDecodeVAG(t *testing.T) {
+	block := make([]uint8, VAG_BLOCK_SIZE)
+	block[0] = 0x0C // Shift 12, no filter
+	block[2] = 0x71 // Samples 1 and 7
+
+	output := DecodeVAG(block)
+	if len(output.Samples) != VAG_SAMPLES_PER_BLOCK {
+		t.Fatalf("Expected %d samples, got %d", VAG_SAMPLES_PER_BLOCK, len(output.Samples))
+	}
+	if output.Samples[0] != 1 || output.Samples[1] != 7 || output.Samples[2] != 0 {
+		t.Errorf("Unexpected samples %v", output.Samples[:3])
+	}
+}
+
+func TestDecodeVAGFilter(t *testing.T) {
+	block := make([]uint8, VAG_BLOCK_SIZE)
+	block[0] = 0x10 // Shift 0, filter 1
+	block[2] = 0x01 // First sample is 4096
+
+	output := DecodeVAG(block)
+	// Filter 1 keeps 60/64 of the previous sample
+	if output.Samples[0] != 4096 || output.Samples[1] != 3840 {
+		t.Errorf("Unexpected samples %v", output.Samples[:2])
+	}
+}
+
+func TestDecodeVAGLoop(t *testing.T) {
+	data := make([]uint8, VAG_BLOCK_SIZE*4)
+	data[VAG_BLOCK_SIZE+1] = VAG_FLAG_LOOP_START
+	data[VAG_BLOCK_SIZE*2+1] = VAG_FLAG_LOOP_END | VAG_FLAG_LOOP
+
+	output := DecodeVAG(data)
+	if !output.HasLoop || output.LoopStart != VAG_SAMPLES_PER_BLOCK {
+		t.Errorf("Expected loop at %d, got %v %d", VAG_SAMPLES_PER_BLOCK, output.HasLoop, output.LoopStart)
+	}
+	if len(output.Samples) != VAG_SAMPLES_PER_BLOCK*3 {
+		t.Errorf("Expected decoding to stop at the loop end, got %d samples", len(output.Samples))
+	}
+}
@@ -0,0 +1,86 @@
+package game
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// Each disabled aot is saved as stage, room and aot number
+const aotStateEntrySize = 3
+
+type roomKey struct {
+	StageId int
+	RoomId  int
+}
+
+// Aots disabled by scripts in every room
+// Kept in the save so events don't fire again when the player returns
+type AotStates struct {
+	disabledAots map[roomKey]map[uint8]bool
+}
+
+func NewAotStates() *AotStates {
+	return &AotStates{
+		disabledAots: make(map[roomKey]map[uint8]bool),
+	}
+}
+
+// The aot manager for the room uses this map directly
+func (aotStates *AotStates) GetRoomDisabledAots(stageId int, roomId int) map[uint8]bool {
+	key := roomKey{StageId: stageId, RoomId: roomId}
+	disabledAots, exists := aotStates.disabledAots[key]
+	if !exists {
+		disabledAots = make(map[uint8]bool)
+		aotStates.disabledAots[key] = disabledAots
+	}
+	return disabledAots
+}
+
+func (aotStates *AotStates) MarshalBinary() ([]byte, error) {
+	entries := make([][aotStateEntrySize]byte, 0)
+	for key, disabledAots := range aotStates.disabledAots {
+		for aot, disabled := range disabledAots {
+			if disabled {
+				entries = append(entries, [aotStateEntrySize]byte{uint8(key.StageId), uint8(key.RoomId), aot})
+			}
+		}
+	}
+
+	// Sort so the same state always gives the same bytes
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i][:], entries[j][:]) < 0
+	})
+
+	data := make([]byte, 2, 2+len(entries)*aotStateEntrySize)
+	binary.LittleEndian.PutUint16(data[0:2], uint16(len(entries)))
+	for _, entry := range entries {
+		data = append(data, entry[:]...)
+	}
+	return data, nil
+}
+
+func (aotStates *AotStates) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 {
+		return fmt.Errorf("aot state should be at least 2 bytes, got %d", len(data))
+	}
+	numEntries := int(binary.LittleEndian.Uint16(data[0:2]))
+	if len(data) != 2+numEntries*aotStateEntrySize {
+		return fmt.Errorf("aot state with %d entries should be %d bytes, got %d",
+			numEntries, 2+numEntries*aotStateEntrySize, len(data))
+	}
+
+	// Clear the existing maps instead of replacing them since the aot manager may be using them
+	for _, disabledAots := range aotStates.disabledAots {
+		for aot := range disabledAots {
+			delete(disabledAots, aot)
+		}
+	}
+	for i := 0; i < numEntries; i++ {
+		entry := data[2+i*aotStateEntrySize:]
+		disabledAots := aotStates.GetRoomDisabledAots(int(entry[0]), int(entry[1]))
+		disabledAots[entry[2]] = true
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package game
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAotStatesSaveAndRestore(t *testing.T) {
+	aotStates := NewAotStates()
+	aotStates.GetRoomDisabledAots(1, 4)[2] = true
+	aotStates.GetRoomDisabledAots(2, 0)[7] = true
+	aotStates.GetRoomDisabledAots(1, 4)[5] = false
+
+	data, err := aotStates.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Failed to save aot state: %v", err)
+	}
+	expected := []byte{2, 0, 1, 4, 2, 2, 0, 7}
+	if !bytes.Equal(data, expected) {
+		t.Errorf("Expected %v, got %v", expected, data)
+	}
+
+	// The room map keeps working after loading
+	roomAots := aotStates.GetRoomDisabledAots(2, 0)
+	restored := NewAotStates()
+	restored.GetRoomDisabledAots(2, 0)[9] = true
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Failed to restore aot state: %v", err)
+	}
+	if !restored.GetRoomDisabledAots(1, 4)[2] || !restored.GetRoomDisabledAots(2, 0)[7] {
+		t.Error("Expected disabled aots to be restored")
+	}
+	if restored.GetRoomDisabledAots(2, 0)[9] {
+		t.Error("Expected aots that weren't saved to be enabled")
+	}
+	if len(roomAots) != 1 {
+		t.Errorf("Expected original room map to be unchanged, got %v", roomAots)
+	}
+}
+
+func TestAotStatesInvalidData(t *testing.T) {
+	aotStates := NewAotStates()
+	if err := aotStates.UnmarshalBinary([]byte{1}); err == nil {
+		t.Error("Expected error for short data")
+	}
+	if err := aotStates.UnmarshalBinary([]byte{2, 0, 1, 4, 2}); err == nil {
+		t.Error("Expected error for missing entry")
+	}
+}
@@ -0,0 +1,381 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/audio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/resource"
+)
+
+const (
+	BGM_CHANNEL_MAIN  = 0
+	BGM_CHANNEL_SUB0  = 1
+	BGM_CHANNEL_SUB1  = 2
+	BGM_CHANNEL_COUNT = 3
+
+	// SCE_BGM_CONTROL operations
+	BGM_OPERATION_NOP     = 0
+	BGM_OPERATION_START   = 1
+	BGM_OPERATION_STOP    = 2
+	BGM_OPERATION_RESTART = 3
+	BGM_OPERATION_PAUSE   = 4
+	BGM_OPERATION_FADEOUT = 5
+
+	// Volume type 0 is the main volume, types 1-3 are the channel volumes
+	BGM_VOLUME_MAIN = 0
+
+	BGM_NO_TRACK          = 0xFF
+	BGM_MAX_VOLUME        = 127
+	BGM_CROSSFADE_SECONDS = 1.0
+	BGM_FADE_OUT_SECONDS  = 2.0
+	BGM_DUCK_VOLUME       = 0.4 // Music volume while a voice line plays
+)
+
+// Music played in a room, set by SCE_BGMTBL_SET
+type BGMTableEntry struct {
+	Tracks [BGM_CHANNEL_COUNT]int // BGM_NO_TRACK if the channel is silent
+}
+
+// The channel is playing as soon as the track is chosen
+// The voice only starts once the track has been decoded
+type BGMChannel struct {
+	Track     int
+	VoiceId   audio.VoiceId
+	HasVoice  bool // Voice of the track, or of the old track while the new one loads
+	IsPlaying bool
+	IsPaused  bool
+	IsLoading bool
+	Volume    float32
+}
+
+type bgmTrackKey struct {
+	IsMain bool
+	Track  int
+}
+
+type bgmLoadResult struct {
+	Key   bgmTrackKey
+	Sound *audio.Sound
+	Err   error
+}
+
+// Plays the background music through the mixer
+// Music keeps playing through doors unless the next room uses a different track
+type BGMController struct {
+	Engine       *audio.Engine
+	Table        map[RoomMapKey]BGMTableEntry
+	Channels     [BGM_CHANNEL_COUNT]BGMChannel
+	MasterVolume float32
+	IsDucked     bool
+	CurrentRoom  RoomMapKey
+
+	// Replaced by tests that don't have the game files
+	// Tracks are decoded in the background so the game loop doesn't wait for them
+	LoadTrack    func(channel int, track int) (*audio.Sound, error)
+	tracks       map[bgmTrackKey]*audio.Sound
+	loading      map[bgmTrackKey]bool
+	loadResults  chan bgmLoadResult
+	loadsRunning sync.WaitGroup
+}
+
+func NewBGMController(engine *audio.Engine) *BGMController {
+	bgmController := &BGMController{
+		Engine:       engine,
+		Table:        make(map[RoomMapKey]BGMTableEntry),
+		MasterVolume: 1.0,
+		LoadTrack:    loadMusicTrack,
+		tracks:       make(map[bgmTrackKey]*audio.Sound),
+		loading:      make(map[bgmTrackKey]bool),
+		loadResults:  make(chan bgmLoadResult, BGM_CHANNEL_COUNT),
+	}
+	for i := range bgmController.Channels {
+		bgmController.Channels[i] = BGMChannel{Track: BGM_NO_TRACK, Volume: 1.0}
+	}
+	return bgmController
+}
+
+// The main channel and the sub channels use different files
+func loadMusicTrack(channel int, track int) (*audio.Sound, error) {
+	filename := fmt.Sprintf(resource.BGM_SUB_FILE, track)
+	if channel == BGM_CHANNEL_MAIN {
+		filename = fmt.Sprintf(resource.BGM_MAIN_FILE, track)
+	}
+	return resource.LoadMusicTrack(filename)
+}
+
+func getTrackKey(channel int, track int) bgmTrackKey {
+	return bgmTrackKey{IsMain: channel == BGM_CHANNEL_MAIN, Track: track}
+}
+
+func (bgmController *BGMController) startLoading(channel int, track int) {
+	key := getTrackKey(channel, track)
+	if bgmController.loading[key] {
+		return
+	}
+	bgmController.loading[key] = true
+	bgmController.loadsRunning.Add(1)
+	go func() {
+		defer bgmController.loadsRunning.Done()
+		sound, err := bgmController.LoadTrack(channel, track)
+		bgmController.loadResults <- bgmLoadResult{Key: key, Sound: sound, Err: err}
+	}()
+}
+
+// Starts the tracks that finished loading, called once per frame
+func (bgmController *BGMController) Update() {
+	for {
+		select {
+		case result := <-bgmController.loadResults:
+			bgmController.finishLoading(result)
+		default:
+			return
+		}
+	}
+}
+
+func (bgmController *BGMController) finishLoading(result bgmLoadResult) {
+	delete(bgmController.loading, result.Key)
+	if result.Err != nil {
+		log.Printf("BGM: Failed to load track %d: %v", result.Key.Track, result.Err)
+	} else {
+		bgmController.tracks[result.Key] = result.Sound
+	}
+
+	for channel := range bgmController.Channels {
+		bgmChannel := &bgmController.Channels[channel]
+		if !bgmChannel.IsLoading || getTrackKey(channel, bgmChannel.Track) != result.Key {
+			continue
+		}
+		if result.Err != nil {
+			bgmController.Stop(channel)
+			continue
+		}
+		bgmController.startVoice(channel, result.Sound)
+	}
+	bgmController.dropUnusedTracks()
+}
+
+// Only the tracks that are playing are kept, so memory doesn't grow over a playthrough
+func (bgmController *BGMController) dropUnusedTracks() {
+	for key := range bgmController.tracks {
+		isUsed := false
+		for channel, bgmChannel := range bgmController.Channels {
+			if bgmChannel.IsPlaying && getTrackKey(channel, bgmChannel.Track) == key {
+				isUsed = true
+				break
+			}
+		}
+		if !isUsed {
+			delete(bgmController.tracks, key)
+		}
+	}
+}
+
+// Used by tests to wait for the background loads
+// Results are taken while waiting, because loads block once the results channel is full
+func (bgmController *BGMController) waitForLoads() {
+	done := make(chan struct{})
+	go func() {
+		bgmController.loadsRunning.Wait()
+		close(done)
+	}()
+	for {
+		select {
+		case result := <-bgmController.loadResults:
+			bgmController.finishLoading(result)
+		case <-done:
+			bgmController.Update()
+			return
+		}
+	}
+}
+
+func (bgmController *BGMController) SetRoomTracks(stageId int, roomId int, entry BGMTableEntry) {
+	bgmController.Table[RoomMapKey{StageId: stageId, RoomId: roomId}] = entry
+}
+
+// Returns BGM_NO_TRACK if the room has no music on the channel
+func (bgmController *BGMController) GetRoomTrack(room RoomMapKey, channel int) int {
+	entry, exists := bgmController.Table[room]
+	if !exists {
+		return BGM_NO_TRACK
+	}
+	return entry.Tracks[channel]
+}
+
+// Fades out the channels that the new room doesn't share with the old room
+// Rooms without a table entry keep the music that is playing
+func (bgmController *BGMController) EnterRoom(stageId int, roomId int) {
+	bgmController.CurrentRoom = RoomMapKey{StageId: stageId, RoomId: roomId}
+	entry, exists := bgmController.Table[bgmController.CurrentRoom]
+	if !exists {
+		return
+	}
+	for channel := range bgmController.Channels {
+		if bgmController.Channels[channel].IsPlaying && entry.Tracks[channel] != bgmController.Channels[channel].Track {
+			bgmController.FadeOut(channel, BGM_FADE_OUT_SECONDS)
+		}
+	}
+}
+
+// Handles an SCE_BGM_CONTROL operation on a channel
+func (bgmController *BGMController) Control(channel int, operation int) {
+	if channel < 0 || channel >= BGM_CHANNEL_COUNT {
+		log.Printf("BGM: Invalid channel %d", channel)
+		return
+	}
+	switch operation {
+	case BGM_OPERATION_NOP:
+	case BGM_OPERATION_START:
+		bgmController.Play(channel, bgmController.GetRoomTrack(bgmController.CurrentRoom, channel))
+	case BGM_OPERATION_STOP:
+		bgmController.Stop(channel)
+	case BGM_OPERATION_RESTART:
+		if bgmController.Channels[channel].IsPlaying {
+			bgmController.Resume(channel)
+		} else {
+			bgmController.Play(channel, bgmController.GetRoomTrack(bgmController.CurrentRoom, channel))
+		}
+	case BGM_OPERATION_PAUSE:
+		bgmController.Pause(channel)
+	case BGM_OPERATION_FADEOUT:
+		bgmController.FadeOut(channel, BGM_FADE_OUT_SECONDS)
+	default:
+		log.Printf("BGM: Unknown operation %d", operation)
+	}
+}
+
+// Crossfades from the track that is playing on the channel
+// Tracks that aren't loaded start after Update picks them up
+// Returns false if there is no track to play
+func (bgmController *BGMController) Play(channel int, track int) bool {
+	if track == BGM_NO_TRACK {
+		return false
+	}
+	bgmChannel := &bgmController.Channels[channel]
+	if bgmChannel.IsPlaying && bgmChannel.Track == track {
+		if bgmChannel.IsPaused {
+			bgmController.Resume(channel)
+		}
+		return true
+	}
+
+	bgmChannel.Track = track
+	bgmChannel.IsPlaying = true
+	bgmChannel.IsPaused = false
+	if sound, exists := bgmController.tracks[getTrackKey(channel, track)]; exists {
+		bgmController.startVoice(channel, sound)
+	} else {
+		bgmChannel.IsLoading = true
+		bgmController.startLoading(channel, track)
+	}
+	bgmController.dropUnusedTracks()
+	return true
+}
+
+func (bgmController *BGMController) startVoice(channel int, sound *audio.Sound) {
+	bgmChannel := &bgmController.Channels[channel]
+	params := audio.DefaultVoiceParams()
+	params.Volume = bgmController.getChannelVolume(channel)
+	params.Loop = true
+	if bgmChannel.HasVoice {
+		bgmController.Engine.Fade(bgmChannel.VoiceId, 0.0, BGM_CROSSFADE_SECONDS, true)
+		params.FadeInSeconds = BGM_CROSSFADE_SECONDS
+	}
+	bgmChannel.VoiceId = bgmController.Engine.Play(sound, params)
+	bgmChannel.HasVoice = true
+	bgmChannel.IsLoading = false
+	if bgmChannel.IsPaused {
+		bgmController.Engine.Pause(bgmChannel.VoiceId)
+	}
+}
+
+func (bgmController *BGMController) Stop(channel int) {
+	bgmChannel := &bgmController.Channels[channel]
+	if bgmChannel.HasVoice {
+		bgmController.Engine.Stop(bgmChannel.VoiceId)
+	}
+	bgmController.clearChannel(channel)
+}
+
+func (bgmController *BGMController) FadeOut(channel int, seconds float64) {
+	bgmChannel := &bgmController.Channels[channel]
+	if bgmChannel.HasVoice {
+		bgmController.Engine.Fade(bgmChannel.VoiceId, 0.0, seconds, true)
+	}
+	bgmController.clearChannel(channel)
+}
+
+func (bgmController *BGMController) clearChannel(channel int) {
+	bgmChannel := &bgmController.Channels[channel]
+	bgmChannel.HasVoice = false
+	bgmChannel.IsPlaying = false
+	bgmChannel.IsPaused = false
+	bgmChannel.IsLoading = false
+	bgmChannel.Track = BGM_NO_TRACK
+	bgmController.dropUnusedTracks()
+}
+
+// A track that is still loading starts paused
+func (bgmController *BGMController) Pause(channel int) {
+	bgmChannel := &bgmController.Channels[channel]
+	if bgmChannel.IsPlaying && !bgmChannel.IsPaused {
+		if bgmChannel.HasVoice {
+			bgmController.Engine.Pause(bgmChannel.VoiceId)
+		}
+		bgmChannel.IsPaused = true
+	}
+}
+
+func (bgmController *BGMController) Resume(channel int) {
+	bgmChannel := &bgmController.Channels[channel]
+	if bgmChannel.IsPlaying && bgmChannel.IsPaused {
+		if bgmChannel.HasVoice {
+			bgmController.Engine.Resume(bgmChannel.VoiceId)
+		}
+		bgmChannel.IsPaused = false
+	}
+}
+
+// Volumes are 0-127 for each side
+// The main volume scales every channel
+func (bgmController *BGMController) SetVolume(volumeType int, leftVolume int, rightVolume int) {
+	volume := float32(leftVolume+rightVolume) / (2 * BGM_MAX_VOLUME)
+	if volumeType == BGM_VOLUME_MAIN {
+		bgmController.MasterVolume = volume
+	} else if volumeType-1 < BGM_CHANNEL_COUNT {
+		bgmController.Channels[volumeType-1].Volume = volume
+	} else {
+		log.Printf("BGM: Unknown volume type %d", volumeType)
+		return
+	}
+
+	bgmController.updateVolumes()
+}
+
+// Lowers the music so voices can be heard
+func (bgmController *BGMController) SetDucked(isDucked bool) {
+	if bgmController.IsDucked == isDucked {
+		return
+	}
+	bgmController.IsDucked = isDucked
+	bgmController.updateVolumes()
+}
+
+func (bgmController *BGMController) getChannelVolume(channel int) float32 {
+	volume := bgmController.MasterVolume * bgmController.Channels[channel].Volume
+	if bgmController.IsDucked {
+		volume *= BGM_DUCK_VOLUME
+	}
+	return volume
+}
+
+func (bgmController *BGMController) updateVolumes() {
+	for channel, bgmChannel := range bgmController.Channels {
+		if bgmChannel.IsPlaying && bgmChannel.HasVoice {
+			bgmController.Engine.SetVolume(bgmChannel.VoiceId, bgmController.getChannelVolume(channel))
+		}
+	}
+}
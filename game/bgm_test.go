@@ -0,0 +1,186 @@
+package game
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/audio"
+)
+
+func newTestBGMController() (*BGMController, *[]int) {
+	loadedTracks := make([]int, 0)
+	var loadedTracksLock sync.Mutex
+	bgmController := NewBGMController(audio.NewEngine(audio.NewNullBackend()))
+	bgmController.LoadTrack = func(channel int, track int) (*audio.Sound, error) {
+		// Tracks load on their own goroutines
+		loadedTracksLock.Lock()
+		defer loadedTracksLock.Unlock()
+		loadedTracks = append(loadedTracks, track)
+		return audio.NewSound(make([]int16, 100), 2, audio.SAMPLE_RATE), nil
+	}
+	return bgmController, &loadedTracks
+}
+
+func TestBGMControllerStartUsesRoomTable(t *testing.T) {
+	bgmController, loadedTracks := newTestBGMController()
+	bgmController.SetRoomTracks(1, 0, BGMTableEntry{Tracks: [BGM_CHANNEL_COUNT]int{3, BGM_NO_TRACK, BGM_NO_TRACK}})
+	bgmController.EnterRoom(1, 0)
+
+	bgmController.Control(BGM_CHANNEL_MAIN, BGM_OPERATION_START)
+	if !bgmController.Channels[BGM_CHANNEL_MAIN].IsPlaying || bgmController.Channels[BGM_CHANNEL_MAIN].Track != 3 {
+		t.Errorf("Expected track 3 to play, got %+v", bgmController.Channels[BGM_CHANNEL_MAIN])
+	}
+
+	bgmController.Control(BGM_CHANNEL_SUB0, BGM_OPERATION_START)
+	if bgmController.Channels[BGM_CHANNEL_SUB0].IsPlaying {
+		t.Error("Expected no music on a channel without a track")
+	}
+
+	// Starting the same track again doesn't restart it
+	bgmController.Control(BGM_CHANNEL_MAIN, BGM_OPERATION_START)
+	bgmController.waitForLoads()
+	if len(*loadedTracks) != 1 {
+		t.Errorf("Expected track to load once, got %v", *loadedTracks)
+	}
+}
+
+func TestBGMControllerKeepsMusicThroughDoors(t *testing.T) {
+	bgmController, _ := newTestBGMController()
+	bgmController.SetRoomTracks(1, 0, BGMTableEntry{Tracks: [BGM_CHANNEL_COUNT]int{3, BGM_NO_TRACK, BGM_NO_TRACK}})
+	bgmController.SetRoomTracks(1, 1, BGMTableEntry{Tracks: [BGM_CHANNEL_COUNT]int{3, BGM_NO_TRACK, BGM_NO_TRACK}})
+	bgmController.SetRoomTracks(1, 2, BGMTableEntry{Tracks: [BGM_CHANNEL_COUNT]int{4, BGM_NO_TRACK, BGM_NO_TRACK}})
+	bgmController.EnterRoom(1, 0)
+	bgmController.Control(BGM_CHANNEL_MAIN, BGM_OPERATION_START)
+	bgmController.waitForLoads()
+	voiceId := bgmController.Channels[BGM_CHANNEL_MAIN].VoiceId
+
+	bgmController.EnterRoom(1, 1)
+	if !bgmController.Channels[BGM_CHANNEL_MAIN].IsPlaying || bgmController.Channels[BGM_CHANNEL_MAIN].VoiceId != voiceId {
+		t.Error("Expected music to continue in a room with the same track")
+	}
+
+	bgmController.EnterRoom(1, 2)
+	if bgmController.Channels[BGM_CHANNEL_MAIN].IsPlaying {
+		t.Error("Expected music to fade out in a room with a different track")
+	}
+}
+
+func TestBGMControllerPauseAndVolume(t *testing.T) {
+	bgmController, _ := newTestBGMController()
+	bgmController.Play(BGM_CHANNEL_MAIN, 1)
+
+	bgmController.Control(BGM_CHANNEL_MAIN, BGM_OPERATION_PAUSE)
+	if !bgmController.Channels[BGM_CHANNEL_MAIN].IsPaused {
+		t.Error("Expected music to be paused")
+	}
+	bgmController.Control(BGM_CHANNEL_MAIN, BGM_OPERATION_RESTART)
+	if bgmController.Channels[BGM_CHANNEL_MAIN].IsPaused {
+		t.Error("Expected music to resume")
+	}
+
+	bgmController.SetVolume(BGM_VOLUME_MAIN, 127, 0)
+	if bgmController.MasterVolume != 0.5 {
+		t.Errorf("Expected main volume 0.5, got %f", bgmController.MasterVolume)
+	}
+	bgmController.SetVolume(BGM_CHANNEL_SUB1+1, 127, 127)
+	if bgmController.Channels[BGM_CHANNEL_SUB1].Volume != 1.0 {
+		t.Errorf("Expected sub channel volume 1, got %f", bgmController.Channels[BGM_CHANNEL_SUB1].Volume)
+	}
+
+	bgmController.Control(BGM_CHANNEL_MAIN, BGM_OPERATION_STOP)
+	if bgmController.Channels[BGM_CHANNEL_MAIN].IsPlaying {
+		t.Error("Expected music to stop")
+	}
+}
+
+func TestBGMControllerCrossfade(t *testing.T) {
+	bgmController, _ := newTestBGMController()
+	bgmController.Play(BGM_CHANNEL_MAIN, 1)
+	bgmController.waitForLoads()
+	oldVoiceId := bgmController.Channels[BGM_CHANNEL_MAIN].VoiceId
+
+	// The old track keeps playing until the new one is loaded
+	bgmController.Play(BGM_CHANNEL_MAIN, 2)
+	if !bgmController.Channels[BGM_CHANNEL_MAIN].IsLoading || bgmController.Channels[BGM_CHANNEL_MAIN].VoiceId != oldVoiceId {
+		t.Errorf("Expected old voice while track 2 loads, got %+v", bgmController.Channels[BGM_CHANNEL_MAIN])
+	}
+	bgmController.waitForLoads()
+	if bgmController.Channels[BGM_CHANNEL_MAIN].Track != 2 || bgmController.Channels[BGM_CHANNEL_MAIN].VoiceId == oldVoiceId {
+		t.Errorf("Expected new voice for track 2, got %+v", bgmController.Channels[BGM_CHANNEL_MAIN])
+	}
+}
+
+func TestBGMControllerDucking(t *testing.T) {
+	bgmController, _ := newTestBGMController()
+	bgmController.Play(BGM_CHANNEL_MAIN, 3)
+
+	bgmController.SetDucked(true)
+	if volume := bgmController.getChannelVolume(BGM_CHANNEL_MAIN); volume != BGM_DUCK_VOLUME {
+		t.Errorf("Expected ducked volume %f, got %f", BGM_DUCK_VOLUME, volume)
+	}
+	bgmController.SetDucked(false)
+	if volume := bgmController.getChannelVolume(BGM_CHANNEL_MAIN); volume != 1.0 {
+		t.Errorf("Expected full volume, got %f", volume)
+	}
+}
+
+func TestBGMControllerLoadsInBackground(t *testing.T) {
+	bgmController, _ := newTestBGMController()
+	bgmController.Play(BGM_CHANNEL_MAIN, 1)
+	bgmController.Pause(BGM_CHANNEL_MAIN)
+	if bgmController.Channels[BGM_CHANNEL_MAIN].HasVoice {
+		t.Fatal("Expected no voice before the track is loaded")
+	}
+
+	bgmController.waitForLoads()
+	bgmChannel := bgmController.Channels[BGM_CHANNEL_MAIN]
+	if !bgmChannel.HasVoice || bgmChannel.IsLoading || !bgmChannel.IsPaused {
+		t.Errorf("Expected paused voice after loading, got %+v", bgmChannel)
+	}
+}
+
+func TestBGMControllerDropsStoppedTracks(t *testing.T) {
+	bgmController, loadedTracks := newTestBGMController()
+	bgmController.Play(BGM_CHANNEL_MAIN, 1)
+	bgmController.waitForLoads()
+	bgmController.Play(BGM_CHANNEL_MAIN, 2)
+	bgmController.waitForLoads()
+	if len(bgmController.tracks) != 1 {
+		t.Errorf("Expected only the playing track to be kept, got %v", bgmController.tracks)
+	}
+
+	bgmController.Play(BGM_CHANNEL_MAIN, 1)
+	bgmController.waitForLoads()
+	if len(*loadedTracks) != 3 {
+		t.Errorf("Expected dropped track to load again, got %v", *loadedTracks)
+	}
+
+	bgmController.Stop(BGM_CHANNEL_MAIN)
+	if len(bgmController.tracks) != 0 {
+		t.Errorf("Expected no tracks after stopping, got %v", bgmController.tracks)
+	}
+}
+
+func TestBGMControllerStopWhileLoading(t *testing.T) {
+	bgmController, _ := newTestBGMController()
+	bgmController.Play(BGM_CHANNEL_MAIN, 1)
+	bgmController.Stop(BGM_CHANNEL_MAIN)
+	bgmController.waitForLoads()
+	if bgmChannel := bgmController.Channels[BGM_CHANNEL_MAIN]; bgmChannel.IsPlaying || bgmChannel.HasVoice {
+		t.Errorf("Expected stopped track not to start after loading, got %+v", bgmChannel)
+	}
+}
+
+func TestBGMControllerWaitsForMoreLoadsThanChannels(t *testing.T) {
+	bgmController, loadedTracks := newTestBGMController()
+	for track := 0; track < BGM_CHANNEL_COUNT*3; track++ {
+		bgmController.Play(BGM_CHANNEL_MAIN, track)
+	}
+	bgmController.waitForLoads()
+	if len(*loadedTracks) != BGM_CHANNEL_COUNT*3 {
+		t.Errorf("Expected every track to load, got %v", *loadedTracks)
+	}
+	if bgmChannel := bgmController.Channels[BGM_CHANNEL_MAIN]; bgmChannel.Track != BGM_CHANNEL_COUNT*3-1 || !bgmChannel.HasVoice {
+		t.Errorf("Expected the last track to play, got %+v", bgmChannel)
+	}
+}
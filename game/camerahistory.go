@@ -0,0 +1,38 @@
+package game
+
+const (
+	CAMERA_HISTORY_SIZE = 8
+)
+
+// Cameras shown before the current one, used by CUT_OLD to go back
+type CameraHistory struct {
+	CameraIds []int // Most recent camera is last
+}
+
+func NewCameraHistory() *CameraHistory {
+	return &CameraHistory{
+		CameraIds: make([]int, 0, CAMERA_HISTORY_SIZE),
+	}
+}
+
+// The oldest camera is dropped when the history is full
+func (history *CameraHistory) Push(cameraId int) {
+	if len(history.CameraIds) >= CAMERA_HISTORY_SIZE {
+		history.CameraIds = history.CameraIds[1:]
+	}
+	history.CameraIds = append(history.CameraIds, cameraId)
+}
+
+func (history *CameraHistory) Pop() (int, bool) {
+	if len(history.CameraIds) == 0 {
+		return 0, false
+	}
+	lastIndex := len(history.CameraIds) - 1
+	cameraId := history.CameraIds[lastIndex]
+	history.CameraIds = history.CameraIds[:lastIndex]
+	return cameraId, true
+}
+
+func (history *CameraHistory) Clear() {
+	history.CameraIds = history.CameraIds[:0]
+}
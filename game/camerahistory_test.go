@@ -0,0 +1,43 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/world"
+)
+
+func TestCameraHistory_PushPop(t *testing.T) {
+	history := NewCameraHistory()
+	if _, exists := history.Pop(); exists {
+		t.Error("Expected empty history")
+	}
+
+	for i := 0; i < CAMERA_HISTORY_SIZE+2; i++ {
+		history.Push(i)
+	}
+	if len(history.CameraIds) != CAMERA_HISTORY_SIZE {
+		t.Errorf("Expected history size %d, got %d", CAMERA_HISTORY_SIZE, len(history.CameraIds))
+	}
+
+	cameraId, exists := history.Pop()
+	if !exists || cameraId != CAMERA_HISTORY_SIZE+1 {
+		t.Errorf("Expected most recent camera %d, got %d", CAMERA_HISTORY_SIZE+1, cameraId)
+	}
+}
+
+func TestGameDef_RestorePreviousCamera(t *testing.T) {
+	gameDef := NewGame(1, 0, 0)
+	gameDef.GameWorld.GameRoom = &world.Room{MaxCamerasInRoom: 4}
+
+	gameDef.ChangeCamera(2)
+	gameDef.ChangeCamera(3)
+	if !gameDef.RestorePreviousCamera() || gameDef.CameraId != 2 {
+		t.Errorf("Expected camera 2, got %d", gameDef.CameraId)
+	}
+	if !gameDef.RestorePreviousCamera() || gameDef.CameraId != 0 {
+		t.Errorf("Expected camera 0, got %d", gameDef.CameraId)
+	}
+	if gameDef.RestorePreviousCamera() {
+		t.Error("Expected no previous camera")
+	}
+}
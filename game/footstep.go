@@ -0,0 +1,35 @@
+package game
+
+// Finds the walk animation frames where a foot touches the ground
+type FootstepTracker struct {
+	LastFrameIndex int
+	StepCount      int
+}
+
+func NewFootstepTracker() *FootstepTracker {
+	return &FootstepTracker{
+		LastFrameIndex: -1,
+	}
+}
+
+func isFootstepPose(poseNumber int) bool {
+	return poseNumber == PLAYER_WALKING_POSE || poseNumber == PLAYER_BACKWARD_POSE
+}
+
+// Returns true when the animation reaches the first or middle frame of the walk cycle
+func (tracker *FootstepTracker) Update(poseNumber int, frameIndex int, frameCount int) bool {
+	if !isFootstepPose(poseNumber) || frameCount <= 0 {
+		tracker.LastFrameIndex = -1
+		return false
+	}
+	if frameIndex == tracker.LastFrameIndex {
+		return false
+	}
+	tracker.LastFrameIndex = frameIndex
+
+	if frameIndex == 0 || frameIndex == frameCount/2 {
+		tracker.StepCount++
+		return true
+	}
+	return false
+}
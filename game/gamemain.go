@@ -3,6 +3,7 @@ package game
 import (
 	"fmt"
 
+	"github.com/OpenBiohazard2/OpenBiohazard2/audio"
 	"github.com/OpenBiohazard2/OpenBiohazard2/world"
 	"github.com/go-gl/mathgl/mgl32"
 )
@@ -27,44 +28,136 @@ type GameDef struct {
 	RoomScript  RoomScript
 	GameWorld   *world.GameWorld
 	Player      *Player
+	Random      *RandomSource
+	MessageBox  *MessageBox
+	Inventory   *Inventory
+	Audio       *audio.Engine
+	BGM         *BGMController
+	Voice       *VoiceChannel
+
+	// Sound effects
+	CoreSoundBank *audio.Sampler
+	RoomSoundBank *audio.Sampler
+	Footsteps     *FootstepTracker
+	ReverbType    int // Reverb preset of the current room
+
+	CameraHistory    *CameraHistory
+	AutoCameraSwitch bool // Disabled by scripts that hold the camera during an event
+
+	AotStates     *AotStates
+	ActionPressed bool // Action button was pressed this frame
+
+	// Door the player is going through after its model opens
+	PendingDoor *world.AotDoor
 }
 
 func NewGame(stageId int, roomId int, cameraId int) *GameDef {
+	audioEngine := audio.NewEngine(audio.NewNullBackend())
+	bgmController := NewBGMController(audioEngine)
 	return &GameDef{
 		StageId:     stageId,
 		RoomId:      roomId,
 		CameraId:    cameraId,
 		StateStatus: GAME_LOAD_ROOM,
 		GameWorld:   world.NewGameWorld(),
+		Random:      NewRandomSource(DEFAULT_RANDOM_SEED),
+		MessageBox:  NewMessageBox(),
+		Inventory:   NewInventory(),
+		Audio:       audioEngine,
+		BGM:         bgmController,
+		Voice:       NewVoiceChannel(audioEngine, bgmController),
+		Footsteps:   NewFootstepTracker(),
+
+		CameraHistory:    NewCameraHistory(),
+		AutoCameraSwitch: true,
+
+		AotStates: NewAotStates(),
 	}
 }
 
+// Sends all game audio to the engine
+func (gameDef *GameDef) SetAudioEngine(audioEngine *audio.Engine) {
+	gameDef.Audio = audioEngine
+	gameDef.BGM.Engine = audioEngine
+	gameDef.Voice.Engine = audioEngine
+	gameDef.ReverbType = audio.REVERB_TYPE_OFF
+}
+
+// Restores the aots that scripts disabled in the current room
+func (gameDef *GameDef) LoadRoomAotStates() {
+	gameDef.GameWorld.AotManager.DisabledAots = gameDef.AotStates.GetRoomDisabledAots(gameDef.StageId, gameDef.RoomId)
+}
+
+// Use the same seed to replay a game
+func (gameDef *GameDef) SetRandomSeed(seed uint32) {
+	gameDef.Random = NewRandomSource(seed)
+}
+
 func (gameDef *GameDef) ChangeCamera(newCamera int) {
+	newCameraId := gameDef.GameWorld.GameRoom.ClampNewCameraId(newCamera)
+	if newCameraId != gameDef.CameraId {
+		gameDef.CameraHistory.Push(gameDef.CameraId)
+	}
 	gameDef.StateStatus = GAME_LOAD_CAMERA
-	gameDef.CameraId = gameDef.GameWorld.GameRoom.ClampNewCameraId(newCamera)
+	gameDef.CameraId = newCameraId
+}
+
+// Goes back to the camera before the last camera change
+func (gameDef *GameDef) RestorePreviousCamera() bool {
+	previousCameraId, exists := gameDef.CameraHistory.Pop()
+	if !exists {
+		return false
+	}
+	gameDef.StateStatus = GAME_LOAD_CAMERA
+	gameDef.CameraId = previousCameraId
+	return true
 }
 
 func (gameDef *GameDef) HandleCameraSwitch(position mgl32.Vec3) {
+	if !gameDef.AutoCameraSwitch {
+		return
+	}
+
 	// Check is player entered a new region
 	cameraSwitchHandler := gameDef.GameWorld.GameRoom.CameraSwitchHandler
 	cameraSwitchNewRegion := cameraSwitchHandler.GetCameraSwitchNewRegion(gameDef.Player.Position, gameDef.CameraId)
 	if cameraSwitchNewRegion != nil {
 		// Switch to a new camera
-		gameDef.ChangeCamera(int(cameraSwitchNewRegion.Cam1))
+		gameDef.ChangeCamera(cameraSwitchHandler.GetTargetCamera(*cameraSwitchNewRegion))
 	}
 }
 
 func (gameDef *GameDef) HandleRoomSwitch(position mgl32.Vec3) {
+	if gameDef.PendingDoor != nil {
+		return
+	}
+
 	door := gameDef.GameWorld.AotManager.GetDoorNearPlayer(position)
-	if door != nil {
-		// Switch to a new room
-		gameDef.StageId = 1 + int(door.Stage)
-		gameDef.RoomId = int(door.Room)
-		gameDef.CameraId = int(door.Camera)
-		gameDef.Player.Position = mgl32.Vec3{float32(door.NextX), float32(door.NextY), float32(door.NextZ)}
-		fmt.Println("New player position = ", gameDef.Player.Position)
-
-		gameDef.StateStatus = GAME_LOAD_ROOM
-		gameDef.GameWorld.AotManager = world.NewAotManager()
+	if door == nil {
+		return
 	}
+
+	// Wait for the door model to open
+	if gameDef.GameWorld.AotManager.HasDoorModel(door.Header.Aot) {
+		gameDef.PendingDoor = door
+		return
+	}
+	gameDef.EnterDoor(door)
+}
+
+// Switch to a new room
+func (gameDef *GameDef) EnterDoor(door *world.AotDoor) {
+	gameDef.StageId = 1 + int(door.Stage)
+	gameDef.RoomId = int(door.Room)
+	gameDef.CameraId = int(door.Camera)
+	gameDef.Player.Position = mgl32.Vec3{float32(door.NextX), float32(door.NextY), float32(door.NextZ)}
+	fmt.Println("New player position = ", gameDef.Player.Position)
+
+	gameDef.StateStatus = GAME_LOAD_ROOM
+	gameDef.GameWorld.AotManager = world.NewAotManager()
+	gameDef.PendingDoor = nil
+
+	// Camera ids are different in the new room
+	gameDef.CameraHistory.Clear()
+	gameDef.AutoCameraSwitch = true
 }
@@ -0,0 +1,170 @@
+package game
+
+const (
+	INVENTORY_SIZE          = 11
+	INVENTORY_DEFAULT_SLOTS = 8  // Slots available without the side pack
+	INVENTORY_RESERVED_SLOT = 10 // Character specific item, such as the lighter
+	INVENTORY_NO_EQUIP      = -1
+
+	ITEM_NONE            = 0
+	ITEM_KNIFE           = 1
+	ITEM_HAND_GUN        = 2
+	ITEM_SUB_MACHINE_GUN = 15
+	ITEM_FLAMETHROWER    = 16
+	ITEM_ROCKET_LAUNCHER = 17
+	ITEM_GATLING_GUN     = 18
+	ITEM_LIGHTER         = 47
+
+	// Number of slots an item takes
+	// Large items take the left and right slot of a row
+	ITEM_SIZE_SINGLE = 1
+	ITEM_SIZE_DOUBLE = 2
+)
+
+// Items that don't take a single slot
+var itemSizes = map[int]int{
+	ITEM_SUB_MACHINE_GUN: ITEM_SIZE_DOUBLE,
+	ITEM_FLAMETHROWER:    ITEM_SIZE_DOUBLE,
+	ITEM_ROCKET_LAUNCHER: ITEM_SIZE_DOUBLE,
+	ITEM_GATLING_GUN:     ITEM_SIZE_DOUBLE,
+}
+
+// InventoryItem represents an item in the player's inventory
+type InventoryItem struct {
+	Id   int
+	Num  int
+	Size int
+}
+
+func GetItemSize(itemId int) int {
+	if size, exists := itemSizes[itemId]; exists {
+		return size
+	}
+	return ITEM_SIZE_SINGLE
+}
+
+type Inventory struct {
+	Items          []InventoryItem
+	AvailableSlots int
+	EquippedSlot   int
+}
+
+func NewInventory() *Inventory {
+	items := make([]InventoryItem, INVENTORY_SIZE)
+	items[0] = InventoryItem{Id: ITEM_HAND_GUN, Num: 18, Size: GetItemSize(ITEM_HAND_GUN)}
+	items[1] = InventoryItem{Id: ITEM_KNIFE, Num: 1, Size: GetItemSize(ITEM_KNIFE)}
+	items[INVENTORY_RESERVED_SLOT] = InventoryItem{Id: ITEM_LIGHTER, Num: 1, Size: GetItemSize(ITEM_LIGHTER)}
+	return &Inventory{
+		Items:          items,
+		AvailableSlots: INVENTORY_DEFAULT_SLOTS,
+		EquippedSlot:   0,
+	}
+}
+
+// Returns -1 if the item is not in the inventory
+func (inventory *Inventory) FindItemSlot(itemId int) int {
+	if itemId == ITEM_NONE {
+		return -1
+	}
+	for slot, item := range inventory.Items {
+		if item.Id == itemId {
+			return slot
+		}
+	}
+	return -1
+}
+
+// Items of the same type are stacked in one slot
+// Returns false if there is no free slot
+func (inventory *Inventory) AddItem(itemId int, amount int) bool {
+	if itemId == ITEM_NONE {
+		return false
+	}
+
+	slot := inventory.FindItemSlot(itemId)
+	if slot >= 0 {
+		inventory.Items[slot].Num += amount
+		return true
+	}
+
+	size := GetItemSize(itemId)
+	for slot := 0; slot+size <= inventory.AvailableSlots; slot++ {
+		if inventory.hasFreeSlots(slot, size) {
+			inventory.Items[slot] = InventoryItem{Id: itemId, Num: amount, Size: size}
+			return true
+		}
+	}
+	return false
+}
+
+// Double items have to start a row
+func (inventory *Inventory) hasFreeSlots(slot int, size int) bool {
+	if size == ITEM_SIZE_DOUBLE && slot%2 != 0 {
+		return false
+	}
+	for i := slot; i < slot+size; i++ {
+		if !inventory.isSlotFree(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// The right slot of a row is taken by a double item in the left slot
+func (inventory *Inventory) isSlotFree(slot int) bool {
+	if inventory.Items[slot].Id != ITEM_NONE {
+		return false
+	}
+	return slot%2 == 0 || inventory.Items[slot-1].Size < ITEM_SIZE_DOUBLE
+}
+
+// Removes the whole stack of an item
+// Returns false if the player doesn't have the item
+func (inventory *Inventory) RemoveItem(itemId int) bool {
+	slot := inventory.FindItemSlot(itemId)
+	if slot < 0 {
+		return false
+	}
+
+	inventory.Items[slot] = InventoryItem{}
+	if inventory.EquippedSlot == slot {
+		inventory.EquippedSlot = INVENTORY_NO_EQUIP
+	}
+	return true
+}
+
+func (inventory *Inventory) HasItem(itemId int) bool {
+	return inventory.FindItemSlot(itemId) >= 0
+}
+
+func (inventory *Inventory) CountItem(itemId int) int {
+	slot := inventory.FindItemSlot(itemId)
+	if slot < 0 {
+		return 0
+	}
+	return inventory.Items[slot].Num
+}
+
+// Equipping ITEM_NONE puts the weapon away
+// Returns false if the player doesn't have the item
+func (inventory *Inventory) EquipItem(itemId int) bool {
+	if itemId == ITEM_NONE {
+		inventory.EquippedSlot = INVENTORY_NO_EQUIP
+		return true
+	}
+
+	slot := inventory.FindItemSlot(itemId)
+	if slot < 0 {
+		return false
+	}
+	inventory.EquippedSlot = slot
+	return true
+}
+
+// Returns ITEM_NONE if nothing is equipped
+func (inventory *Inventory) GetEquippedItemId() int {
+	if inventory.EquippedSlot == INVENTORY_NO_EQUIP {
+		return ITEM_NONE
+	}
+	return inventory.Items[inventory.EquippedSlot].Id
+}
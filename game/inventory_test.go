@@ -0,0 +1,125 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestNewInventory(t *testing.T) {
+	inventory := NewInventory()
+
+	if len(inventory.Items) != INVENTORY_SIZE {
+		t.Errorf("Expected %d slots, got %d", INVENTORY_SIZE, len(inventory.Items))
+	}
+	if inventory.GetEquippedItemId() != ITEM_HAND_GUN {
+		t.Errorf("Expected hand gun to be equipped, got %d", inventory.GetEquippedItemId())
+	}
+	if !inventory.HasItem(ITEM_LIGHTER) {
+		t.Error("Expected lighter in the reserved slot")
+	}
+}
+
+func TestInventoryAddItem(t *testing.T) {
+	inventory := NewInventory()
+
+	if !inventory.AddItem(30, 1) {
+		t.Fatal("Expected item to be added")
+	}
+	if inventory.FindItemSlot(30) != 2 {
+		t.Errorf("Expected item in first free slot 2, got %d", inventory.FindItemSlot(30))
+	}
+
+	inventory.AddItem(ITEM_HAND_GUN, 15)
+	if inventory.CountItem(ITEM_HAND_GUN) != 33 {
+		t.Errorf("Expected stacked count 33, got %d", inventory.CountItem(ITEM_HAND_GUN))
+	}
+
+	if inventory.AddItem(ITEM_NONE, 1) {
+		t.Error("Expected empty item to be rejected")
+	}
+}
+
+func TestInventoryAddItemFull(t *testing.T) {
+	inventory := NewInventory()
+	for itemId := 30; itemId < 36; itemId++ {
+		if !inventory.AddItem(itemId, 1) {
+			t.Fatalf("Expected item %d to be added", itemId)
+		}
+	}
+
+	if inventory.AddItem(40, 1) {
+		t.Error("Expected full inventory to reject the item")
+	}
+}
+
+func TestInventoryAddItemDoubleSize(t *testing.T) {
+	inventory := NewInventory()
+
+	// The first row is taken, so the double item starts the next row
+	inventory.AddItem(30, 1)
+	if !inventory.AddItem(ITEM_ROCKET_LAUNCHER, 4) {
+		t.Fatal("Expected double item to be added")
+	}
+	slot := inventory.FindItemSlot(ITEM_ROCKET_LAUNCHER)
+	if slot != 4 || inventory.Items[slot].Size != ITEM_SIZE_DOUBLE {
+		t.Fatalf("Expected double item in slot 4, got slot %d with size %d", slot, inventory.Items[slot].Size)
+	}
+
+	// The slot next to the double item is taken
+	inventory.AddItem(31, 1)
+	if inventory.FindItemSlot(31) != 3 {
+		t.Errorf("Expected item in slot 3, got %d", inventory.FindItemSlot(31))
+	}
+	inventory.AddItem(32, 1)
+	if inventory.FindItemSlot(32) != 6 {
+		t.Errorf("Expected item after the double item in slot 6, got %d", inventory.FindItemSlot(32))
+	}
+
+	// Only one slot is left in the last row
+	if inventory.AddItem(ITEM_GATLING_GUN, 1) {
+		t.Error("Expected double item not to fit")
+	}
+}
+
+func TestInventoryRemoveItem(t *testing.T) {
+	inventory := NewInventory()
+
+	if !inventory.RemoveItem(ITEM_HAND_GUN) {
+		t.Fatal("Expected hand gun to be removed")
+	}
+	if inventory.HasItem(ITEM_HAND_GUN) {
+		t.Error("Expected hand gun to be gone")
+	}
+	if inventory.CountItem(ITEM_HAND_GUN) != 0 {
+		t.Errorf("Expected count 0, got %d", inventory.CountItem(ITEM_HAND_GUN))
+	}
+	if inventory.GetEquippedItemId() != ITEM_NONE {
+		t.Errorf("Expected removed weapon to be unequipped, got %d", inventory.GetEquippedItemId())
+	}
+
+	if inventory.RemoveItem(ITEM_HAND_GUN) {
+		t.Error("Expected second removal to fail")
+	}
+}
+
+func TestInventoryEquipItem(t *testing.T) {
+	inventory := NewInventory()
+
+	if !inventory.EquipItem(ITEM_KNIFE) {
+		t.Fatal("Expected knife to be equipped")
+	}
+	if inventory.GetEquippedItemId() != ITEM_KNIFE {
+		t.Errorf("Expected knife, got %d", inventory.GetEquippedItemId())
+	}
+
+	if inventory.EquipItem(30) {
+		t.Error("Expected missing item to fail")
+	}
+	if inventory.GetEquippedItemId() != ITEM_KNIFE {
+		t.Errorf("Expected knife to stay equipped, got %d", inventory.GetEquippedItemId())
+	}
+
+	inventory.EquipItem(ITEM_NONE)
+	if inventory.GetEquippedItemId() != ITEM_NONE {
+		t.Errorf("Expected nothing equipped, got %d", inventory.GetEquippedItemId())
+	}
+}
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+)
+
+const (
+	MESSAGE_CHARACTERS_PER_SECOND = 60
+
+	MESSAGE_CHOICE_YES = 0
+	MESSAGE_CHOICE_NO  = 1
+)
+
+// Message box shown by MESSAGE_ON
+// The script thread that opened it waits until it is closed
+type MessageBox struct {
+	IsOpen     bool
+	Message    fileio.MSGMessage
+	PageIndex  int
+	Choice     int
+	revealTime float64 // Seconds since the page was shown
+}
+
+func NewMessageBox() *MessageBox {
+	return &MessageBox{}
+}
+
+func (messageBox *MessageBox) Open(message fileio.MSGMessage) {
+	messageBox.IsOpen = true
+	messageBox.Message = message
+	messageBox.PageIndex = 0
+	messageBox.Choice = MESSAGE_CHOICE_YES
+	messageBox.revealTime = 0
+}
+
+func (messageBox *MessageBox) Close() {
+	messageBox.IsOpen = false
+}
+
+// Reveals the text one character at a time
+func (messageBox *MessageBox) Update(timeElapsedSeconds float64) {
+	if !messageBox.IsOpen {
+		return
+	}
+	messageBox.revealTime += timeElapsedSeconds
+}
+
+func (messageBox *MessageBox) GetCurrentPage() string {
+	if messageBox.PageIndex >= len(messageBox.Message.Pages) {
+		return ""
+	}
+	return messageBox.Message.Pages[messageBox.PageIndex]
+}
+
+// Part of the current page that has been revealed so far
+func (messageBox *MessageBox) GetVisibleText() string {
+	page := messageBox.GetCurrentPage()
+	numCharacters := int(messageBox.revealTime * MESSAGE_CHARACTERS_PER_SECOND)
+	if numCharacters > len(page) {
+		return page
+	}
+	return page[:numCharacters]
+}
+
+func (messageBox *MessageBox) IsPageRevealed() bool {
+	return len(messageBox.GetVisibleText()) == len(messageBox.GetCurrentPage())
+}
+
+func (messageBox *MessageBox) IsLastPage() bool {
+	return messageBox.PageIndex >= len(messageBox.Message.Pages)-1
+}
+
+// The question is shown after the last page is revealed
+func (messageBox *MessageBox) IsChoiceVisible() bool {
+	return messageBox.Message.HasChoice && messageBox.IsLastPage() && messageBox.IsPageRevealed()
+}
+
+// Called when the player presses the action button
+// Shows the rest of the page, then goes to the next page, then closes the message
+func (messageBox *MessageBox) Advance() {
+	if !messageBox.IsOpen {
+		return
+	}
+
+	if !messageBox.IsPageRevealed() {
+		messageBox.revealTime = float64(len(messageBox.GetCurrentPage())) / MESSAGE_CHARACTERS_PER_SECOND
+		return
+	}
+
+	if !messageBox.IsLastPage() {
+		messageBox.PageIndex++
+		messageBox.revealTime = 0
+		return
+	}
+
+	messageBox.Close()
+}
+
+func (messageBox *MessageBox) ToggleChoice() {
+	if !messageBox.IsChoiceVisible() {
+		return
+	}
+
+	if messageBox.Choice == MESSAGE_CHOICE_YES {
+		messageBox.Choice = MESSAGE_CHOICE_NO
+	} else {
+		messageBox.Choice = MESSAGE_CHOICE_YES
+	}
+}
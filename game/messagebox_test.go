@@ -0,0 +1,64 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+)
+
+func TestMessageBoxTypewriterAndPages(t *testing.T) {
+	messageBox := NewMessageBox()
+	messageBox.Open(fileio.MSGMessage{Pages: []string{"Hello", "World"}})
+
+	if messageBox.GetVisibleText() != "" {
+		t.Errorf("Expected no text before any time passes, got %q", messageBox.GetVisibleText())
+	}
+
+	messageBox.Update(2.0 / MESSAGE_CHARACTERS_PER_SECOND)
+	if messageBox.GetVisibleText() != "He" {
+		t.Errorf("Expected 2 characters to be revealed, got %q", messageBox.GetVisibleText())
+	}
+
+	// First press reveals the whole page
+	messageBox.Advance()
+	if messageBox.GetVisibleText() != "Hello" || messageBox.PageIndex != 0 {
+		t.Errorf("Expected whole first page, got %q on page %d", messageBox.GetVisibleText(), messageBox.PageIndex)
+	}
+
+	// Second press goes to the next page
+	messageBox.Advance()
+	if messageBox.PageIndex != 1 || messageBox.GetVisibleText() != "" {
+		t.Errorf("Expected empty second page, got %q on page %d", messageBox.GetVisibleText(), messageBox.PageIndex)
+	}
+
+	messageBox.Advance()
+	messageBox.Advance()
+	if messageBox.IsOpen {
+		t.Error("Expected message box to be closed after the last page")
+	}
+}
+
+func TestMessageBoxChoice(t *testing.T) {
+	messageBox := NewMessageBox()
+	messageBox.Open(fileio.MSGMessage{Pages: []string{"Use it?"}, HasChoice: true})
+
+	// Choice can't be changed before the question is shown
+	messageBox.ToggleChoice()
+	if messageBox.Choice != MESSAGE_CHOICE_YES {
+		t.Errorf("Expected choice to stay yes, got %d", messageBox.Choice)
+	}
+
+	messageBox.Update(1.0)
+	if !messageBox.IsChoiceVisible() {
+		t.Fatal("Expected choice to be visible")
+	}
+	messageBox.ToggleChoice()
+	if messageBox.Choice != MESSAGE_CHOICE_NO {
+		t.Errorf("Expected choice to be no, got %d", messageBox.Choice)
+	}
+
+	messageBox.Advance()
+	if messageBox.IsOpen {
+		t.Error("Expected message box to be closed after answering")
+	}
+}
@@ -14,8 +14,18 @@ const (
 	PLAYER_BACKWARD_SPEED = 1000
 
 	// Player Animation States
-	PLAYER_IDLE_POSE    = -1
-	PLAYER_WALKING_POSE = 0
+	PLAYER_IDLE_POSE     = -1
+	PLAYER_WALKING_POSE  = 0
+	PLAYER_BACKWARD_POSE = 1
+
+	// Player health when the game starts
+	PLAYER_MAX_HEALTH = 200
+
+	// Radius of the collision circle around the player on the floor
+	PLAYER_COLLISION_RADIUS = 400
+
+	// The player stops instead of sliding if the wall leaves less than this part of the step
+	PLAYER_MIN_SLIDE_RATIO = 0.2
 
 	// Collision Shape Types
 	COLLISION_SHAPE_RAMP  = 9
@@ -26,6 +36,8 @@ type Player struct {
 	Position      mgl32.Vec3
 	RotationAngle float32
 	PoseNumber    int
+	ScriptWork    ScriptEntityWork
+	ScriptControl PlayerScriptControl
 }
 
 // Position is in world space
@@ -35,9 +47,38 @@ func NewPlayer(initialPosition mgl32.Vec3, initialRotationAngle float32) *Player
 		Position:      initialPosition,
 		RotationAngle: initialRotationAngle,
 		PoseNumber:    PLAYER_IDLE_POSE,
+		ScriptWork:    ScriptEntityWork{Life: PLAYER_MAX_HEALTH},
 	}
 }
 
+func (p *Player) GetPosition() mgl32.Vec3 {
+	return p.Position
+}
+
+func (p *Player) SetPosition(position mgl32.Vec3) {
+	p.Position = position
+}
+
+func (p *Player) GetRotationAngle() float32 {
+	return p.RotationAngle
+}
+
+func (p *Player) SetRotationAngle(rotationAngle float32) {
+	p.RotationAngle = rotationAngle
+}
+
+func (p *Player) GetMotion() int {
+	return p.PoseNumber
+}
+
+func (p *Player) SetMotion(motion int) {
+	p.PoseNumber = motion
+}
+
+func (p *Player) GetScriptWork() *ScriptEntityWork {
+	return &p.ScriptWork
+}
+
 func (p *Player) GetModelMatrix() mgl32.Mat4 {
 	modelMatrix := mgl32.Ident4()
 	modelMatrix = modelMatrix.Mul4(mgl32.Translate3D(p.Position.X(), p.Position.Y(), p.Position.Z()))
@@ -45,38 +86,56 @@ func (p *Player) GetModelMatrix() mgl32.Mat4 {
 	return modelMatrix
 }
 
-func (player *Player) HandlePlayerInputForward(collisionEntities []fileio.CollisionEntity, timeElapsedSeconds float64) {
+func (player *Player) HandlePlayerInputForward(collision *world.CollisionIndex, timeElapsedSeconds float64) {
 	predictPosition := player.PredictPositionForward(timeElapsedSeconds)
-	collidingEntity := world.CheckCollision(predictPosition, collisionEntities)
-	if collidingEntity == nil {
-		player.Position = predictPosition
-		player.PoseNumber = PLAYER_WALKING_POSE
+	collidingEntity := collision.CheckCollision(predictPosition)
+	if collidingEntity != nil && world.CheckRamp(collidingEntity) {
+		predictPosition = player.PredictPositionForwardSlope(collidingEntity, timeElapsedSeconds)
+		player.MoveWithCollision(predictPosition, collision, PLAYER_WALKING_POSE)
+	} else if collidingEntity != nil && (collidingEntity.Shape == COLLISION_SHAPE_RAMP || collidingEntity.Shape == COLLISION_SHAPE_CLIMB) {
+		player.Position = player.PredictPositionClimbBox()
 	} else {
-		if world.CheckRamp(collidingEntity) {
-			player.Position = player.PredictPositionForwardSlope(collidingEntity, timeElapsedSeconds)
-			player.PoseNumber = PLAYER_WALKING_POSE
-		} else if collidingEntity.Shape == COLLISION_SHAPE_RAMP || collidingEntity.Shape == COLLISION_SHAPE_CLIMB {
-			player.Position = player.PredictPositionClimbBox()
-		} else {
-			player.PoseNumber = PLAYER_IDLE_POSE
-		}
+		player.MoveWithCollision(predictPosition, collision, PLAYER_WALKING_POSE)
 	}
 }
 
-func (player *Player) HandlePlayerInputBackward(collisionEntities []fileio.CollisionEntity, timeElapsedSeconds float64) {
+func (player *Player) HandlePlayerInputBackward(collision *world.CollisionIndex, timeElapsedSeconds float64) {
 	predictPosition := player.PredictPositionBackward(timeElapsedSeconds)
-	collidingEntity := world.CheckCollision(predictPosition, collisionEntities)
-	if collidingEntity == nil {
-		player.Position = predictPosition
-		player.PoseNumber = 1
+	collidingEntity := collision.CheckCollision(predictPosition)
+	if collidingEntity != nil && world.CheckRamp(collidingEntity) {
+		predictPosition = player.PredictPositionBackwardSlope(collidingEntity, timeElapsedSeconds)
+		player.MoveWithCollision(predictPosition, collision, PLAYER_BACKWARD_POSE)
+	} else if collidingEntity != nil && (collidingEntity.Shape == COLLISION_SHAPE_RAMP || collidingEntity.Shape == COLLISION_SHAPE_CLIMB) {
+		// Boxes can only be climbed facing them
+		player.PoseNumber = PLAYER_IDLE_POSE
 	} else {
-		if world.CheckRamp(collidingEntity) {
-			player.Position = player.PredictPositionBackwardSlope(collidingEntity, timeElapsedSeconds)
-			player.PoseNumber = 1
-		} else {
-			player.PoseNumber = PLAYER_IDLE_POSE
-		}
+		player.MoveWithCollision(predictPosition, collision, PLAYER_BACKWARD_POSE)
+	}
+}
+
+// Moves towards the predicted position and slides along any wall in the way
+// The player stops when walking almost straight into a wall or when squeezed between walls
+func (player *Player) MoveWithCollision(predictPosition mgl32.Vec3, collision *world.CollisionIndex, movingPose int) {
+	newPosition, contact := collision.ResolveCircleCollision(predictPosition, PLAYER_COLLISION_RADIUS)
+	if contact == nil {
+		player.Position = predictPosition
+		player.PoseNumber = movingPose
+		return
+	}
+
+	stepDistance := getFloorDistance(player.Position, predictPosition)
+	slideDistance := getFloorDistance(player.Position, newPosition)
+	if collision.CheckCircleCollision(newPosition, PLAYER_COLLISION_RADIUS) != nil ||
+		slideDistance < stepDistance*PLAYER_MIN_SLIDE_RATIO {
+		player.PoseNumber = PLAYER_IDLE_POSE
+		return
 	}
+	player.Position = newPosition
+	player.PoseNumber = movingPose
+}
+
+func getFloorDistance(position1 mgl32.Vec3, position2 mgl32.Vec3) float32 {
+	return float32(math.Hypot(float64(position2.X()-position1.X()), float64(position2.Z()-position1.Z())))
 }
 
 func (player *Player) PredictPositionForward(timeElapsedSeconds float64) mgl32.Vec3 {
@@ -125,19 +184,7 @@ func (player *Player) PredictPositionBackwardSlope(
 
 // Player walks up or down the stairs or ramp
 func (player *Player) PredictPositionSlope(predictPositionFlat mgl32.Vec3, slopedEntity *fileio.CollisionEntity) mgl32.Vec3 {
-	distanceFromRampBottom := 0.0
-
-	// Check slope type orientation
-	if slopedEntity.SlopeType == 0 || slopedEntity.SlopeType == 1 {
-		// ramp bottom is on the x-axis
-		distanceFromRampBottom = math.Abs(float64(predictPositionFlat.X()-slopedEntity.RampBottom)) / float64(slopedEntity.Width)
-	} else if slopedEntity.SlopeType == 2 || slopedEntity.SlopeType == 3 {
-		// ramp bottom is on the z-axis
-		distanceFromRampBottom = math.Abs(float64(predictPositionFlat.Z()-slopedEntity.RampBottom)) / float64(slopedEntity.Density)
-	}
-	predictPositionY := float64(slopedEntity.SlopeHeight) * distanceFromRampBottom
-
-	return mgl32.Vec3{predictPositionFlat.X(), float32(predictPositionY), predictPositionFlat.Z()}
+	return mgl32.Vec3{predictPositionFlat.X(), world.GetRampHeight(predictPositionFlat, slopedEntity), predictPositionFlat.Z()}
 }
 
 func (player *Player) PredictPositionClimbBox() mgl32.Vec3 {
@@ -157,4 +204,5 @@ func (player *Player) PredictPositionClimbBox() mgl32.Vec3 {
 }
 
 func (gameDef *GameDef) HandlePlayerActionButton(collisionEntities []fileio.CollisionEntity) {
+	gameDef.ActionPressed = true
 }
@@ -0,0 +1,53 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/world"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Wall along the z axis with its right side at x=0
+func newTestWall() *world.CollisionIndex {
+	return world.NewCollisionIndex([]fileio.CollisionEntity{
+		{Shape: 0, X: -1000, Z: -10000, Width: 1000, Density: 20000, FloorCheck: []bool{true, false}},
+	})
+}
+
+func TestPlayerSlidesAlongWall(t *testing.T) {
+	// Facing diagonally into the wall, 0 degrees is +x and the rotation turns towards -z
+	player := NewPlayer(mgl32.Vec3{PLAYER_COLLISION_RADIUS + 10, 0, 0}, 135)
+	player.HandlePlayerInputForward(newTestWall(), 0.1)
+
+	if player.PoseNumber != PLAYER_WALKING_POSE {
+		t.Fatalf("Expected player to keep walking, got pose %d", player.PoseNumber)
+	}
+	if player.Position.X() < PLAYER_COLLISION_RADIUS {
+		t.Errorf("Expected player to stay out of the wall, got %v", player.Position)
+	}
+	if player.Position.Z() == 0 {
+		t.Errorf("Expected player to slide along the wall, got %v", player.Position)
+	}
+}
+
+func TestPlayerStopsFacingWall(t *testing.T) {
+	player := NewPlayer(mgl32.Vec3{PLAYER_COLLISION_RADIUS + 10, 0, 0}, 180)
+	player.HandlePlayerInputForward(newTestWall(), 0.1)
+
+	if player.PoseNumber != PLAYER_IDLE_POSE {
+		t.Errorf("Expected player to stop, got pose %d", player.PoseNumber)
+	}
+	if player.Position.X() != PLAYER_COLLISION_RADIUS+10 {
+		t.Errorf("Expected player not to move, got %v", player.Position)
+	}
+}
+
+func TestPlayerWalksAwayFromWall(t *testing.T) {
+	player := NewPlayer(mgl32.Vec3{PLAYER_COLLISION_RADIUS + 10, 0, 0}, 0)
+	player.HandlePlayerInputForward(newTestWall(), 0.1)
+
+	if player.PoseNumber != PLAYER_WALKING_POSE || player.Position.X() <= PLAYER_COLLISION_RADIUS+10 {
+		t.Errorf("Expected player to walk away, got pose %d at %v", player.PoseNumber, player.Position)
+	}
+}
@@ -0,0 +1,168 @@
+package game
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Scripted control is used by cutscenes to move the player
+// Player input is ignored until the script releases control with PLC_RET
+
+const (
+	// Rotation speeds in degrees per second
+	PLAYER_SCRIPT_ROTATION_SPEED = 360
+	PLAYER_NECK_ROTATION_SPEED   = 180
+
+	// The head can't turn further than this from the body
+	PLAYER_NECK_MAX_ANGLE = 60
+
+	// Distance at which the player has reached the destination
+	PLAYER_DESTINATION_RADIUS = 10
+)
+
+type PlayerScriptControl struct {
+	Enabled bool
+
+	HasDestination    bool
+	Destination       mgl32.Vec3
+	DestinationMotion int // Animation played while walking to the destination
+
+	IsRotating          bool
+	TargetRotationAngle float32
+
+	NeckEnabled bool
+	NeckTarget  mgl32.Vec3
+	NeckAngle   float32 // Head rotation relative to the body in degrees
+}
+
+func (player *Player) IsInputLocked() bool {
+	return player.ScriptControl.Enabled
+}
+
+func (player *Player) BeginScriptControl() {
+	player.ScriptControl.Enabled = true
+}
+
+// Returns control to the player
+func (player *Player) EndScriptControl() {
+	player.StopScriptMovement()
+	player.ScriptControl.Enabled = false
+	player.ScriptControl.NeckEnabled = false
+	player.ScriptControl.NeckAngle = 0
+}
+
+func (player *Player) SetScriptMotion(motion int) {
+	player.BeginScriptControl()
+	player.PoseNumber = motion
+}
+
+// Walks to the destination on the floor with the motion, the height is unchanged
+func (player *Player) SetScriptDestination(destinationX float32, destinationZ float32, motion int) {
+	player.BeginScriptControl()
+	player.ScriptControl.HasDestination = true
+	player.ScriptControl.Destination = mgl32.Vec3{destinationX, player.Position.Y(), destinationZ}
+	player.ScriptControl.DestinationMotion = motion
+	player.ScriptControl.IsRotating = false
+	player.PoseNumber = motion
+}
+
+func (player *Player) HasReachedDestination() bool {
+	return !player.ScriptControl.HasDestination
+}
+
+// Rotates in place until the player faces the target angle in degrees
+func (player *Player) SetScriptRotation(targetRotationAngle float32) {
+	player.BeginScriptControl()
+	player.ScriptControl.IsRotating = true
+	player.ScriptControl.TargetRotationAngle = normalizeAngle(targetRotationAngle)
+}
+
+func (player *Player) SetNeckTarget(target mgl32.Vec3) {
+	player.ScriptControl.NeckEnabled = true
+	player.ScriptControl.NeckTarget = target
+}
+
+func (player *Player) StopScriptMovement() {
+	player.ScriptControl.HasDestination = false
+	player.ScriptControl.IsRotating = false
+	player.PoseNumber = PLAYER_IDLE_POSE
+}
+
+func (player *Player) UpdateScriptControl(timeElapsedSeconds float64) {
+	if !player.ScriptControl.Enabled {
+		return
+	}
+
+	if player.ScriptControl.IsRotating {
+		player.RotationAngle = normalizeAngle(rotateTowards(player.RotationAngle, player.ScriptControl.TargetRotationAngle,
+			PLAYER_SCRIPT_ROTATION_SPEED*float32(timeElapsedSeconds)))
+		if player.RotationAngle == player.ScriptControl.TargetRotationAngle {
+			player.ScriptControl.IsRotating = false
+		}
+	}
+
+	if player.ScriptControl.HasDestination {
+		player.walkToDestination(timeElapsedSeconds)
+	}
+
+	targetNeckAngle := float32(0)
+	if player.ScriptControl.NeckEnabled {
+		targetNeckAngle = player.getNeckAngleToTarget()
+	}
+	player.ScriptControl.NeckAngle = rotateTowards(player.ScriptControl.NeckAngle, targetNeckAngle,
+		PLAYER_NECK_ROTATION_SPEED*float32(timeElapsedSeconds))
+}
+
+func (player *Player) walkToDestination(timeElapsedSeconds float64) {
+	destination := player.ScriptControl.Destination
+	deltaX := destination.X() - player.Position.X()
+	deltaZ := destination.Z() - player.Position.Z()
+	distance := float32(math.Hypot(float64(deltaX), float64(deltaZ)))
+
+	stepDistance := PLAYER_FORWARD_SPEED * float32(timeElapsedSeconds)
+	if distance <= PLAYER_DESTINATION_RADIUS || distance <= stepDistance {
+		player.Position = mgl32.Vec3{destination.X(), player.Position.Y(), destination.Z()}
+		player.StopScriptMovement()
+		return
+	}
+
+	player.RotationAngle = getAngleToPoint(deltaX, deltaZ)
+	player.Position = player.Position.Add(mgl32.Vec3{deltaX / distance * stepDistance, 0, deltaZ / distance * stepDistance})
+	player.PoseNumber = player.ScriptControl.DestinationMotion
+}
+
+func (player *Player) getNeckAngleToTarget() float32 {
+	deltaX := player.ScriptControl.NeckTarget.X() - player.Position.X()
+	deltaZ := player.ScriptControl.NeckTarget.Z() - player.Position.Z()
+	neckAngle := normalizeAngle(getAngleToPoint(deltaX, deltaZ) - player.RotationAngle)
+	if neckAngle > 180 {
+		neckAngle -= 360
+	}
+	return mgl32.Clamp(neckAngle, -PLAYER_NECK_MAX_ANGLE, PLAYER_NECK_MAX_ANGLE)
+}
+
+// The player faces the x axis when the rotation angle is 0
+func getAngleToPoint(deltaX float32, deltaZ float32) float32 {
+	return normalizeAngle(mgl32.RadToDeg(float32(math.Atan2(float64(-deltaZ), float64(deltaX)))))
+}
+
+func normalizeAngle(angle float32) float32 {
+	angle = float32(math.Mod(float64(angle), 360.0))
+	if angle < 0 {
+		angle += 360
+	}
+	return angle
+}
+
+// Turns in the shortest direction without going past the target
+func rotateTowards(angle float32, targetAngle float32, maxStep float32) float32 {
+	difference := float32(math.Mod(float64(targetAngle-angle)+540.0, 360.0)) - 180
+	if float32(math.Abs(float64(difference))) <= maxStep {
+		return targetAngle
+	}
+	if difference > 0 {
+		return angle + maxStep
+	}
+	return angle - maxStep
+}
@@ -0,0 +1,67 @@
+package game
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// All gameplay randomness comes from one random source
+// The state is saved with the game, so a playthrough with the same seed and inputs is reproducible
+
+const (
+	DEFAULT_RANDOM_SEED = 0x12345678
+
+	// Same linear congruential generator as the PlayStation C library
+	RANDOM_MULTIPLIER = 1103515245
+	RANDOM_INCREMENT  = 12345
+	RANDOM_MAX        = 0x7fff
+
+	randomSourceStateSize = 8
+)
+
+type RandomSource struct {
+	Seed  uint32
+	State uint32
+}
+
+func NewRandomSource(seed uint32) *RandomSource {
+	return &RandomSource{
+		Seed:  seed,
+		State: seed,
+	}
+}
+
+// Restarts the sequence from the seed
+func (random *RandomSource) Reset() {
+	random.State = random.Seed
+}
+
+// Returns a value from 0 to RANDOM_MAX
+func (random *RandomSource) Next() int {
+	random.State = random.State*RANDOM_MULTIPLIER + RANDOM_INCREMENT
+	return int((random.State >> 16) & RANDOM_MAX)
+}
+
+// Returns a value from 0 to n-1
+func (random *RandomSource) Intn(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return random.Next() % n
+}
+
+func (random *RandomSource) MarshalBinary() ([]byte, error) {
+	data := make([]byte, randomSourceStateSize)
+	binary.LittleEndian.PutUint32(data[0:4], random.Seed)
+	binary.LittleEndian.PutUint32(data[4:8], random.State)
+	return data, nil
+}
+
+func (random *RandomSource) UnmarshalBinary(data []byte) error {
+	if len(data) != randomSourceStateSize {
+		return fmt.Errorf("random source state should be %d bytes, got %d", randomSourceStateSize, len(data))
+	}
+	random.Seed = binary.LittleEndian.Uint32(data[0:4])
+	random.State = binary.LittleEndian.Uint32(data[4:8])
+	return nil
+}
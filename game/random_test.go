@@ -0,0 +1,57 @@
+package game
+
+import "testing"
+
+func TestRandomSourceIsReproducible(t *testing.T) {
+	random1 := NewRandomSource(42)
+	random2 := NewRandomSource(42)
+
+	for i := 0; i < 100; i++ {
+		value1 := random1.Next()
+		value2 := random2.Next()
+		if value1 != value2 {
+			t.Fatalf("Expected same value at index %d, got %d and %d", i, value1, value2)
+		}
+		if value1 < 0 || value1 > RANDOM_MAX {
+			t.Fatalf("Expected value between 0 and %d, got %d", RANDOM_MAX, value1)
+		}
+	}
+}
+
+func TestRandomSourceSaveAndRestore(t *testing.T) {
+	random := NewRandomSource(7)
+	random.Next()
+	random.Next()
+
+	data, err := random.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error saving random source: %v", err)
+	}
+	expectedValues := []int{random.Next(), random.Next(), random.Next()}
+
+	restoredRandom := &RandomSource{}
+	if err := restoredRandom.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Unexpected error restoring random source: %v", err)
+	}
+	for i, expectedValue := range expectedValues {
+		if value := restoredRandom.Next(); value != expectedValue {
+			t.Errorf("Expected value %d at index %d after restoring, got %d", expectedValue, i, value)
+		}
+	}
+
+	if err := restoredRandom.UnmarshalBinary(data[:4]); err == nil {
+		t.Error("Expected error when restoring from truncated data")
+	}
+}
+
+func TestRandomSourceIntn(t *testing.T) {
+	random := NewRandomSource(DEFAULT_RANDOM_SEED)
+	for i := 0; i < 100; i++ {
+		if value := random.Intn(6); value < 0 || value >= 6 {
+			t.Fatalf("Expected value between 0 and 5, got %d", value)
+		}
+	}
+	if value := random.Intn(0); value != 0 {
+		t.Errorf("Expected 0 for an empty range, got %d", value)
+	}
+}
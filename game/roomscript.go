@@ -10,12 +10,19 @@ import (
 type RoomScript struct {
 	InitScriptData fileio.ScriptFunction
 	RoomScriptData fileio.ScriptFunction
+	Messages       []fileio.MSGMessage
 }
 
 func (gameDef *GameDef) NewRoomScript(rdtOutput *fileio.RDTOutput) RoomScript {
+	var messages []fileio.MSGMessage
+	if rdtOutput.MessageData != nil {
+		messages = rdtOutput.MessageData.Messages
+	}
+
 	return RoomScript{
 		InitScriptData: rdtOutput.InitScriptData.ScriptData,
 		RoomScriptData: rdtOutput.RoomScriptData.ScriptData,
+		Messages:       messages,
 	}
 }
 
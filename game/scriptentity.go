@@ -0,0 +1,256 @@
+package game
+
+import (
+	"math"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/world"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Member indices used by MEMBER_SET, MEMBER_SET2, MEMBER_COPY and MEMBER_CMP
+// They are the same for the player, enemies and objects
+const (
+	MEMBER_BE_FLAG     = 0
+	MEMBER_ROUTINE_0   = 1
+	MEMBER_ROUTINE_1   = 2
+	MEMBER_ROUTINE_2   = 3
+	MEMBER_ROUTINE_3   = 4
+	MEMBER_ID          = 5
+	MEMBER_TYPE        = 6
+	MEMBER_LIFE        = 7
+	MEMBER_TIMER_0     = 8
+	MEMBER_TIMER_1     = 9
+	MEMBER_MOTION      = 10
+	MEMBER_POSITION_X  = 11
+	MEMBER_POSITION_Y  = 12
+	MEMBER_POSITION_Z  = 13
+	MEMBER_DIRECTION_X = 14
+	MEMBER_DIRECTION_Y = 15
+	MEMBER_DIRECTION_Z = 16
+	MEMBER_FLOOR       = 17
+	MEMBER_STATUS      = 18
+	MEMBER_GROUND      = 19
+	MEMBER_SPEED_X     = 20
+	MEMBER_SPEED_Y     = 21
+	MEMBER_SPEED_Z     = 22
+	MEMBER_SCE_FLAG    = 23
+
+	// A full rotation is 4096 units in the script
+	SCRIPT_ANGLE_UNITS = 4096
+
+	// Speeds in the script are per frame at this rate
+	SCRIPT_ENTITY_FRAMES_PER_SECOND = 30.0
+
+	// Radius of the collision circle around an enemy on the floor
+	ENEMY_COLLISION_RADIUS = 400
+)
+
+// Script state of an entity that isn't used by the renderer
+type ScriptEntityWork struct {
+	BeFlag     int
+	Routine    [4]int
+	Id         int
+	Type       int
+	Life       int
+	Timer      [2]int
+	Floor      int
+	Status     int
+	Ground     int
+	Speed      [3]int
+	SceFlag    int
+	DirectionX int
+	DirectionZ int
+	// Speed is in units per frame and angular speed is in script angle units per frame
+	AngularSpeed [3]int
+	// Time left to move, set to one frame by each ADD_SPEED and ADD_ASPEED
+	// Scripts keep calling them while the entity should move, so it stops when they stop
+	SpeedSeconds        float64
+	AngularSpeedSeconds float64
+}
+
+// Entity that can be modified by the work set in a script
+type ScriptableEntity interface {
+	GetPosition() mgl32.Vec3
+	SetPosition(position mgl32.Vec3)
+	// Rotation angle is in degrees
+	GetRotationAngle() float32
+	SetRotationAngle(rotationAngle float32)
+	GetMotion() int
+	SetMotion(motion int)
+	GetScriptWork() *ScriptEntityWork
+}
+
+// Convert script angle units to degrees
+func ScriptAngleToDegrees(value int) float32 {
+	return (float32(value) / SCRIPT_ANGLE_UNITS) * 360.0
+}
+
+// Convert degrees to script angle units
+func DegreesToScriptAngle(rotationAngle float32) int {
+	return wrapScriptAngle(int(math.Round(float64(rotationAngle) / 360.0 * SCRIPT_ANGLE_UNITS)))
+}
+
+// Returns false if the member index is unknown
+func GetEntityMember(entity ScriptableEntity, memberIndex int) (int, bool) {
+	work := entity.GetScriptWork()
+	position := entity.GetPosition()
+
+	switch memberIndex {
+	case MEMBER_BE_FLAG:
+		return work.BeFlag, true
+	case MEMBER_ROUTINE_0, MEMBER_ROUTINE_1, MEMBER_ROUTINE_2, MEMBER_ROUTINE_3:
+		return work.Routine[memberIndex-MEMBER_ROUTINE_0], true
+	case MEMBER_ID:
+		return work.Id, true
+	case MEMBER_TYPE:
+		return work.Type, true
+	case MEMBER_LIFE:
+		return work.Life, true
+	case MEMBER_TIMER_0, MEMBER_TIMER_1:
+		return work.Timer[memberIndex-MEMBER_TIMER_0], true
+	case MEMBER_MOTION:
+		return entity.GetMotion(), true
+	case MEMBER_POSITION_X:
+		return int(position.X()), true
+	case MEMBER_POSITION_Y:
+		return int(position.Y()), true
+	case MEMBER_POSITION_Z:
+		return int(position.Z()), true
+	case MEMBER_DIRECTION_X:
+		return work.DirectionX, true
+	case MEMBER_DIRECTION_Y:
+		return DegreesToScriptAngle(entity.GetRotationAngle()), true
+	case MEMBER_DIRECTION_Z:
+		return work.DirectionZ, true
+	case MEMBER_FLOOR:
+		return work.Floor, true
+	case MEMBER_STATUS:
+		return work.Status, true
+	case MEMBER_GROUND:
+		return work.Ground, true
+	case MEMBER_SPEED_X, MEMBER_SPEED_Y, MEMBER_SPEED_Z:
+		return work.Speed[memberIndex-MEMBER_SPEED_X], true
+	case MEMBER_SCE_FLAG:
+		return work.SceFlag, true
+	}
+	return 0, false
+}
+
+// Returns false if the member index is unknown
+func SetEntityMember(entity ScriptableEntity, memberIndex int, value int) bool {
+	work := entity.GetScriptWork()
+	position := entity.GetPosition()
+
+	switch memberIndex {
+	case MEMBER_BE_FLAG:
+		work.BeFlag = value
+	case MEMBER_ROUTINE_0, MEMBER_ROUTINE_1, MEMBER_ROUTINE_2, MEMBER_ROUTINE_3:
+		work.Routine[memberIndex-MEMBER_ROUTINE_0] = value
+	case MEMBER_ID:
+		work.Id = value
+	case MEMBER_TYPE:
+		work.Type = value
+	case MEMBER_LIFE:
+		work.Life = value
+	case MEMBER_TIMER_0, MEMBER_TIMER_1:
+		work.Timer[memberIndex-MEMBER_TIMER_0] = value
+	case MEMBER_MOTION:
+		entity.SetMotion(value)
+	case MEMBER_POSITION_X:
+		entity.SetPosition(mgl32.Vec3{float32(value), position.Y(), position.Z()})
+	case MEMBER_POSITION_Y:
+		entity.SetPosition(mgl32.Vec3{position.X(), float32(value), position.Z()})
+	case MEMBER_POSITION_Z:
+		entity.SetPosition(mgl32.Vec3{position.X(), position.Y(), float32(value)})
+	case MEMBER_DIRECTION_X:
+		work.DirectionX = value
+	case MEMBER_DIRECTION_Y:
+		entity.SetRotationAngle(ScriptAngleToDegrees(value))
+	case MEMBER_DIRECTION_Z:
+		work.DirectionZ = value
+	case MEMBER_FLOOR:
+		work.Floor = value
+	case MEMBER_STATUS:
+		work.Status = value
+	case MEMBER_GROUND:
+		work.Ground = value
+	case MEMBER_SPEED_X, MEMBER_SPEED_Y, MEMBER_SPEED_Z:
+		work.Speed[memberIndex-MEMBER_SPEED_X] = value
+	case MEMBER_SCE_FLAG:
+		work.SceFlag = value
+	default:
+		return false
+	}
+	return true
+}
+
+// Keeps the entity moving with its speed for one more frame
+func StartEntitySpeed(entity ScriptableEntity) {
+	entity.GetScriptWork().SpeedSeconds = 1.0 / SCRIPT_ENTITY_FRAMES_PER_SECOND
+}
+
+// Keeps the entity turning with its angular speed for one more frame
+func StartEntityAngularSpeed(entity ScriptableEntity) {
+	entity.GetScriptWork().AngularSpeedSeconds = 1.0 / SCRIPT_ENTITY_FRAMES_PER_SECOND
+}
+
+// Moves the entity by its speed relative to the direction it is facing
+func ApplyEntitySpeed(entity ScriptableEntity, timeElapsedSeconds float64) {
+	work := entity.GetScriptWork()
+	frames := float32(timeElapsedSeconds * SCRIPT_ENTITY_FRAMES_PER_SECOND)
+	modelMatrix := mgl32.HomogRotate3DY(mgl32.DegToRad(entity.GetRotationAngle()))
+	movementDelta := modelMatrix.Mul4x1(mgl32.Vec4{
+		float32(work.Speed[0]) * frames,
+		float32(work.Speed[1]) * frames,
+		float32(work.Speed[2]) * frames,
+		0.0,
+	})
+	entity.SetPosition(entity.GetPosition().Add(movementDelta.Vec3()))
+}
+
+// Rotates the entity by its angular speed
+func ApplyEntityAngularSpeed(entity ScriptableEntity, timeElapsedSeconds float64) {
+	work := entity.GetScriptWork()
+	frames := timeElapsedSeconds * SCRIPT_ENTITY_FRAMES_PER_SECOND
+	work.DirectionX = wrapScriptAngle(work.DirectionX + int(math.Round(float64(work.AngularSpeed[0])*frames)))
+	work.DirectionZ = wrapScriptAngle(work.DirectionZ + int(math.Round(float64(work.AngularSpeed[2])*frames)))
+
+	rotationAngle := entity.GetRotationAngle() + ScriptAngleToDegrees(work.AngularSpeed[1])*float32(frames)
+	rotationAngle = float32(math.Mod(float64(rotationAngle), 360.0))
+	if rotationAngle < 0 {
+		rotationAngle += 360
+	}
+	entity.SetRotationAngle(rotationAngle)
+}
+
+// Applies the speeds started by the script for one update
+// Returns true if the entity moved
+func UpdateEntityMovement(entity ScriptableEntity, timeElapsedSeconds float64) bool {
+	work := entity.GetScriptWork()
+	if work.AngularSpeedSeconds > 0 {
+		ApplyEntityAngularSpeed(entity, timeElapsedSeconds)
+		work.AngularSpeedSeconds -= timeElapsedSeconds
+	}
+	if work.SpeedSeconds <= 0 {
+		return false
+	}
+	ApplyEntitySpeed(entity, timeElapsedSeconds)
+	work.SpeedSeconds -= timeElapsedSeconds
+	return true
+}
+
+// Pushes the entity out of the walls it walked into, keeping the movement along them
+func ResolveEntityCollision(entity ScriptableEntity, radius float32, collision *world.CollisionIndex) {
+	newPosition, contact := collision.ResolveCircleCollision(entity.GetPosition(), radius)
+	if contact != nil {
+		entity.SetPosition(newPosition)
+	}
+}
+
+func wrapScriptAngle(value int) int {
+	value %= SCRIPT_ANGLE_UNITS
+	if value < 0 {
+		value += SCRIPT_ANGLE_UNITS
+	}
+	return value
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"log"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/audio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/world"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	// Sound bank selected by SE_ON
+	SOUND_BANK_CORE = 0
+	SOUND_BANK_ROOM = 1
+)
+
+// Y points down in the room data
+var soundCameraUp = mgl32.Vec3{0, -1, 0}
+
+// Returns nil if the bank isn't loaded
+func (gameDef *GameDef) GetSoundBank(bank int) *audio.Sampler {
+	if bank == SOUND_BANK_CORE {
+		return gameDef.CoreSoundBank
+	}
+	return gameDef.RoomSoundBank
+}
+
+// Plays a tone from a sound bank as if it comes from the position
+// Volume and pan are relative to the active fixed camera
+func (gameDef *GameDef) PlaySoundEffect(bank int, program int, toneIndex int, position mgl32.Vec3) bool {
+	sampler := gameDef.GetSoundBank(bank)
+	if sampler == nil {
+		return false
+	}
+	tone := sampler.GetTone(program, toneIndex)
+	if tone == nil {
+		return false
+	}
+	sound, params, ok := sampler.GetToneVoice(tone, int(tone.Center), audio.VAB_MAX_VOLUME)
+	if !ok {
+		return false
+	}
+
+	volume, pan := gameDef.getSoundSpatialParams(position)
+	params.Volume *= volume
+	params.Pan = min(max(params.Pan+pan, -1.0), 1.0)
+	gameDef.Audio.Play(sound, params)
+	return true
+}
+
+func (gameDef *GameDef) getSoundSpatialParams(position mgl32.Vec3) (float32, float32) {
+	gameRoom := gameDef.GameWorld.GameRoom
+	if gameRoom == nil || gameDef.CameraId < 0 || gameDef.CameraId >= len(gameRoom.CameraPositionData) {
+		return 1.0, 0.0
+	}
+	camera := gameRoom.CameraPositionData[gameDef.CameraId]
+	return audio.SpatialParams(camera.CameraFrom, camera.CameraTo, soundCameraUp, position)
+}
+
+// Footsteps use the core bank program of the floor region under the player
+// The tone switches between the left and right foot
+func (gameDef *GameDef) PlayFootstep() bool {
+	if gameDef.GameWorld.GameRoom == nil {
+		return false
+	}
+	position := gameDef.Player.Position
+	floorSound := world.GetFloorSoundEffect(position, gameDef.GameWorld.GameRoom.FloorSounds)
+
+	toneIndex := gameDef.Footsteps.StepCount % 2
+	if gameDef.CoreSoundBank != nil && toneIndex >= len(gameDef.CoreSoundBank.Header.GetProgramTones(floorSound)) {
+		toneIndex = 0
+	}
+	return gameDef.PlaySoundEffect(SOUND_BANK_CORE, floorSound, toneIndex, position)
+}
+
+// Rooms pick a reverb preset in the RDT header
+// Only tones with the reverb mode are sent through it
+func (gameDef *GameDef) SetRoomReverb(reverbType int) {
+	if audio.GetReverbPreset(reverbType) == nil && reverbType != audio.REVERB_TYPE_OFF {
+		log.Printf("Unknown reverb type %d", reverbType)
+		reverbType = audio.REVERB_TYPE_OFF
+	}
+	if reverbType == gameDef.ReverbType {
+		return
+	}
+	gameDef.ReverbType = reverbType
+	gameDef.Audio.SetReverb(reverbType, audio.REVERB_DEFAULT_DEPTH)
+}
@@ -0,0 +1,92 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/audio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/world"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func newTestSoundBank() *audio.Sampler {
+	tones := make([]fileio.VABTone, 16)
+	tones[0] = fileio.VABTone{Volume: 127, Pan: 64, Center: 60, NoteMax: 127, Vag: 1}
+	return &audio.Sampler{
+		Header: &fileio.VABHeaderOutput{
+			VABHeader: fileio.VABHeader{MasterVolume: 127},
+			Programs:  []fileio.VABProgram{{Tones: 1, Volume: 127, Pan: 64}},
+			Tones:     [][]fileio.VABTone{tones},
+		},
+		Waveforms: []*audio.Sound{nil, audio.NewSound(make([]int16, 100), 1, audio.SPU_SAMPLE_RATE)},
+	}
+}
+
+func TestPlaySoundEffect(t *testing.T) {
+	gameDef := NewGame(1, 0, 0)
+	position := mgl32.Vec3{0, 0, 0}
+
+	if gameDef.PlaySoundEffect(SOUND_BANK_ROOM, 0, 0, position) {
+		t.Error("Expected no sound without a room bank")
+	}
+
+	gameDef.RoomSoundBank = newTestSoundBank()
+	if !gameDef.PlaySoundEffect(SOUND_BANK_ROOM, 0, 0, position) {
+		t.Error("Expected room sound to play")
+	}
+	if gameDef.PlaySoundEffect(SOUND_BANK_ROOM, 0, 1, position) {
+		t.Error("Expected missing tone not to play")
+	}
+	if gameDef.PlaySoundEffect(SOUND_BANK_CORE, 0, 0, position) {
+		t.Error("Expected no sound without a core bank")
+	}
+}
+
+func TestPlayFootstepUsesFloorSound(t *testing.T) {
+	gameDef := NewGame(1, 0, 0)
+	gameDef.Player = NewPlayer(mgl32.Vec3{500, 0, 500}, 0)
+	gameDef.GameWorld.GameRoom = &world.Room{
+		FloorSounds: []fileio.FLRSound{{X: 0, Y: 0, Width: 1000, Depth: 1000, SoundEffect: 1}},
+	}
+	gameDef.CoreSoundBank = newTestSoundBank()
+
+	// The bank only has program 0
+	if gameDef.PlayFootstep() {
+		t.Error("Expected no footstep for floor sound 1")
+	}
+	gameDef.Player.Position = mgl32.Vec3{5000, 0, 5000}
+	if !gameDef.PlayFootstep() {
+		t.Error("Expected default footstep outside the floor region")
+	}
+}
+
+func TestFootstepTracker(t *testing.T) {
+	tracker := NewFootstepTracker()
+	steps := 0
+	for _, frameIndex := range []int{0, 0, 1, 2, 3, 4, 5, 6, 7, 0} {
+		if tracker.Update(PLAYER_WALKING_POSE, frameIndex, 8) {
+			steps++
+		}
+	}
+	if steps != 3 {
+		t.Errorf("Expected 3 footsteps, got %d", steps)
+	}
+
+	if tracker.Update(PLAYER_IDLE_POSE, 0, 8) {
+		t.Error("Expected no footstep while idle")
+	}
+}
+
+func TestSetRoomReverb(t *testing.T) {
+	gameDef := NewGame(1, 0, 0)
+
+	gameDef.SetRoomReverb(audio.REVERB_TYPE_HALL)
+	if gameDef.ReverbType != audio.REVERB_TYPE_HALL {
+		t.Errorf("Expected hall reverb, got %d", gameDef.ReverbType)
+	}
+
+	gameDef.SetRoomReverb(200)
+	if gameDef.ReverbType != audio.REVERB_TYPE_OFF {
+		t.Errorf("Expected unknown reverb type to turn reverb off, got %d", gameDef.ReverbType)
+	}
+}
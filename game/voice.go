@@ -0,0 +1,233 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/audio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/resource"
+)
+
+const (
+	VOICE_MAX_VOLUME = 127
+)
+
+// Plays the XA voice lines started by XA_ON
+// The music is ducked while a line plays
+type VoiceChannel struct {
+	Engine           *audio.Engine
+	BGM              *BGMController
+	Volume           float32
+	SubtitlesEnabled bool
+
+	XaId           int
+	IsPlaying      bool // Also set while the line is loading, so scripts keep waiting
+	IsLoading      bool
+	ElapsedSeconds float64
+	Subtitles      []fileio.SRTSubtitle
+	voiceId        audio.VoiceId
+	status         *audio.VoiceStatus
+
+	// Replaced by tests that don't have the game files
+	// Lines are decoded in the background so the game loop doesn't wait for them
+	LoadVoice     func(stageId int, xaId int) (*audio.Sound, error)
+	LoadSubtitles func(stageId int, xaId int) ([]fileio.SRTSubtitle, error)
+	loadResults   chan voiceLoadResult
+	loadsRunning  sync.WaitGroup
+	loadId        int // Results from lines that were stopped or replaced are ignored
+	messages      []fileio.MSGMessage
+}
+
+type voiceLoadResult struct {
+	LoadId    int
+	Sound     *audio.Sound
+	Subtitles []fileio.SRTSubtitle
+	Err       error
+	SrtErr    error
+}
+
+func NewVoiceChannel(engine *audio.Engine, bgmController *BGMController) *VoiceChannel {
+	return &VoiceChannel{
+		Engine:           engine,
+		BGM:              bgmController,
+		Volume:           1.0,
+		SubtitlesEnabled: true,
+		LoadVoice:        loadVoice,
+		LoadSubtitles:    loadSubtitles,
+		loadResults:      make(chan voiceLoadResult, 1),
+	}
+}
+
+func loadVoice(stageId int, xaId int) (*audio.Sound, error) {
+	return resource.LoadMusicTrack(fmt.Sprintf(resource.VOICE_FILE, stageId, xaId))
+}
+
+func loadSubtitles(stageId int, xaId int) ([]fileio.SRTSubtitle, error) {
+	srtOutput, err := fileio.LoadSRTFile(fmt.Sprintf(resource.SUBTITLE_FILE, stageId, xaId))
+	if err != nil {
+		return nil, err
+	}
+	return srtOutput.Subtitles, nil
+}
+
+// Stops the line that is playing and loads a new one
+// The line starts in Update once it is loaded
+func (voiceChannel *VoiceChannel) Play(stageId int, xaId int, messages []fileio.MSGMessage) {
+	voiceChannel.Stop()
+
+	voiceChannel.loadId++
+	voiceChannel.XaId = xaId
+	voiceChannel.IsPlaying = true
+	voiceChannel.IsLoading = true
+	voiceChannel.messages = messages
+
+	loadId := voiceChannel.loadId
+	voiceChannel.loadsRunning.Add(1)
+	go func() {
+		defer voiceChannel.loadsRunning.Done()
+		result := voiceLoadResult{LoadId: loadId}
+		result.Sound, result.Err = voiceChannel.LoadVoice(stageId, xaId)
+		if result.Err == nil {
+			result.Subtitles, result.SrtErr = voiceChannel.LoadSubtitles(stageId, xaId)
+		}
+		voiceChannel.loadResults <- result
+	}()
+}
+
+// Subtitles come from the sidecar file if it exists, otherwise from the room message with the same id
+func (voiceChannel *VoiceChannel) finishLoading(result voiceLoadResult) {
+	if !voiceChannel.IsLoading || result.LoadId != voiceChannel.loadId {
+		return
+	}
+	voiceChannel.IsLoading = false
+	if result.Err != nil {
+		log.Printf("Voice %d is not available: %v", voiceChannel.XaId, result.Err)
+		voiceChannel.finish()
+		return
+	}
+
+	params := audio.DefaultVoiceParams()
+	params.Volume = voiceChannel.Volume
+	voiceChannel.voiceId, voiceChannel.status = voiceChannel.Engine.PlayWithStatus(result.Sound, params)
+	voiceChannel.ElapsedSeconds = 0
+
+	subtitles := result.Subtitles
+	if result.SrtErr != nil {
+		duration := float64(result.Sound.FrameCount()) / float64(result.Sound.SampleRate)
+		subtitles = buildMessageSubtitles(voiceChannel.messages, voiceChannel.XaId, duration)
+	}
+	voiceChannel.Subtitles = subtitles
+	voiceChannel.messages = nil
+
+	if voiceChannel.BGM != nil {
+		voiceChannel.BGM.SetDucked(true)
+	}
+}
+
+// Used by tests to wait for the background loads
+// Results are taken while waiting, because loads block once the results channel is full
+func (voiceChannel *VoiceChannel) waitForLoads() {
+	done := make(chan struct{})
+	go func() {
+		voiceChannel.loadsRunning.Wait()
+		close(done)
+	}()
+	for {
+		select {
+		case result := <-voiceChannel.loadResults:
+			voiceChannel.finishLoading(result)
+		case <-done:
+			voiceChannel.Update(0)
+			return
+		}
+	}
+}
+
+// Pages of the message are shown for an equal share of the line
+func buildMessageSubtitles(messages []fileio.MSGMessage, xaId int, duration float64) []fileio.SRTSubtitle {
+	if xaId < 0 || xaId >= len(messages) || len(messages[xaId].Pages) == 0 {
+		return nil
+	}
+	pages := messages[xaId].Pages
+	pageSeconds := duration / float64(len(pages))
+	subtitles := make([]fileio.SRTSubtitle, 0, len(pages))
+	for i, page := range pages {
+		if strings.TrimSpace(page) == "" {
+			continue
+		}
+		subtitles = append(subtitles, fileio.SRTSubtitle{
+			Start: float64(i) * pageSeconds,
+			End:   float64(i+1) * pageSeconds,
+			Text:  page,
+		})
+	}
+	return subtitles
+}
+
+func (voiceChannel *VoiceChannel) Stop() {
+	if !voiceChannel.IsPlaying {
+		return
+	}
+	if !voiceChannel.IsLoading {
+		voiceChannel.Engine.Stop(voiceChannel.voiceId)
+	}
+	voiceChannel.finish()
+}
+
+func (voiceChannel *VoiceChannel) finish() {
+	voiceChannel.IsPlaying = false
+	voiceChannel.IsLoading = false
+	voiceChannel.Subtitles = nil
+	voiceChannel.messages = nil
+	if voiceChannel.BGM != nil {
+		voiceChannel.BGM.SetDucked(false)
+	}
+}
+
+// Called every frame to start loaded lines, follow the line and restore the music when it ends
+func (voiceChannel *VoiceChannel) Update(timeElapsedSeconds float64) {
+	for loading := true; loading; {
+		select {
+		case result := <-voiceChannel.loadResults:
+			voiceChannel.finishLoading(result)
+		default:
+			loading = false
+		}
+	}
+	if !voiceChannel.IsPlaying || voiceChannel.IsLoading {
+		return
+	}
+	voiceChannel.ElapsedSeconds += timeElapsedSeconds
+	if voiceChannel.status != nil && voiceChannel.status.IsFinished() {
+		voiceChannel.finish()
+	}
+}
+
+// Scripts wait on this before continuing a scene
+func (voiceChannel *VoiceChannel) IsComplete() bool {
+	return !voiceChannel.IsPlaying
+}
+
+// Returns an empty string if no subtitle is shown
+func (voiceChannel *VoiceChannel) GetSubtitle() string {
+	if !voiceChannel.IsPlaying || !voiceChannel.SubtitlesEnabled {
+		return ""
+	}
+	for _, subtitle := range voiceChannel.Subtitles {
+		if voiceChannel.ElapsedSeconds >= subtitle.Start && voiceChannel.ElapsedSeconds < subtitle.End {
+			return subtitle.Text
+		}
+	}
+	return ""
+}
+
+// Volume is 0-127 like the other script volumes
+func (voiceChannel *VoiceChannel) SetVolume(volume int) {
+	voiceChannel.Volume = float32(min(max(volume, 0), VOICE_MAX_VOLUME)) / VOICE_MAX_VOLUME
+	if voiceChannel.IsPlaying && !voiceChannel.IsLoading {
+		voiceChannel.Engine.SetVolume(voiceChannel.voiceId, voiceChannel.Volume)
+	}
+}
@@ -0,0 +1,115 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/audio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+)
+
+func newTestVoiceChannel(frameCount int) *VoiceChannel {
+	engine := audio.NewEngine(audio.NewNullBackend())
+	voiceChannel := NewVoiceChannel(engine, NewBGMController(engine))
+	voiceChannel.LoadVoice = func(stageId int, xaId int) (*audio.Sound, error) {
+		return audio.NewSound(make([]int16, frameCount*2), 2, audio.SAMPLE_RATE), nil
+	}
+	voiceChannel.LoadSubtitles = func(stageId int, xaId int) ([]fileio.SRTSubtitle, error) {
+		return nil, errors.New("no subtitle file")
+	}
+	return voiceChannel
+}
+
+func TestVoiceChannelCompletesAndRestoresMusic(t *testing.T) {
+	voiceChannel := newTestVoiceChannel(audio.MIX_BLOCK_FRAMES / 2)
+	if !voiceChannel.IsComplete() {
+		t.Error("Expected channel to be complete before a line plays")
+	}
+
+	voiceChannel.Play(1, 3, nil)
+	if voiceChannel.IsComplete() || voiceChannel.BGM.IsDucked {
+		t.Error("Expected line to wait for its load before ducking the music")
+	}
+
+	voiceChannel.waitForLoads()
+	if voiceChannel.IsComplete() || !voiceChannel.BGM.IsDucked {
+		t.Error("Expected line to play with the music ducked")
+	}
+
+	voiceChannel.Engine.MixBlock()
+	voiceChannel.Update(0.1)
+	if !voiceChannel.IsComplete() {
+		t.Error("Expected line to complete after it is mixed")
+	}
+	if voiceChannel.BGM.IsDucked {
+		t.Error("Expected music volume to be restored")
+	}
+}
+
+func TestVoiceChannelMessageSubtitles(t *testing.T) {
+	voiceChannel := newTestVoiceChannel(audio.SAMPLE_RATE * 2)
+	messages := []fileio.MSGMessage{
+		{Pages: []string{"Unused"}},
+		{Pages: []string{"First", "Second"}},
+	}
+	voiceChannel.Play(1, 1, messages)
+	voiceChannel.waitForLoads()
+
+	voiceChannel.Update(0.5)
+	if subtitle := voiceChannel.GetSubtitle(); subtitle != "First" {
+		t.Errorf("Expected first page, got %q", subtitle)
+	}
+	voiceChannel.Update(1.0)
+	if subtitle := voiceChannel.GetSubtitle(); subtitle != "Second" {
+		t.Errorf("Expected second page, got %q", subtitle)
+	}
+
+	voiceChannel.SubtitlesEnabled = false
+	if subtitle := voiceChannel.GetSubtitle(); subtitle != "" {
+		t.Errorf("Expected no subtitle when disabled, got %q", subtitle)
+	}
+}
+
+func TestVoiceChannelSidecarSubtitles(t *testing.T) {
+	voiceChannel := newTestVoiceChannel(audio.SAMPLE_RATE * 2)
+	voiceChannel.LoadSubtitles = func(stageId int, xaId int) ([]fileio.SRTSubtitle, error) {
+		return []fileio.SRTSubtitle{{Start: 1.0, End: 1.5, Text: "Sidecar"}}, nil
+	}
+	voiceChannel.Play(1, 0, []fileio.MSGMessage{{Pages: []string{"Message"}}})
+	voiceChannel.waitForLoads()
+
+	if subtitle := voiceChannel.GetSubtitle(); subtitle != "" {
+		t.Errorf("Expected no subtitle before the start time, got %q", subtitle)
+	}
+	voiceChannel.Update(1.2)
+	if subtitle := voiceChannel.GetSubtitle(); subtitle != "Sidecar" {
+		t.Errorf("Expected sidecar subtitle, got %q", subtitle)
+	}
+}
+
+func TestVoiceChannelIgnoresStoppedLoad(t *testing.T) {
+	voiceChannel := newTestVoiceChannel(audio.SAMPLE_RATE * 2)
+	voiceChannel.Play(1, 3, nil)
+	voiceChannel.Stop()
+	voiceChannel.waitForLoads()
+
+	if !voiceChannel.IsComplete() || voiceChannel.BGM.IsDucked {
+		t.Error("Expected stopped line not to start when its load finishes")
+	}
+}
+
+func TestVoiceChannelCompletesWhenLoadFails(t *testing.T) {
+	voiceChannel := newTestVoiceChannel(audio.SAMPLE_RATE * 2)
+	voiceChannel.LoadVoice = func(stageId int, xaId int) (*audio.Sound, error) {
+		return nil, errors.New("no voice file")
+	}
+	voiceChannel.Play(1, 3, nil)
+	if voiceChannel.IsComplete() {
+		t.Error("Expected line to be playing while it loads")
+	}
+
+	voiceChannel.waitForLoads()
+	if !voiceChannel.IsComplete() || voiceChannel.BGM.IsDucked {
+		t.Error("Expected line that failed to load to complete")
+	}
+}
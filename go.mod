@@ -3,7 +3,13 @@ module github.com/OpenBiohazard2/OpenBiohazard2
 go 1.23
 
 require (
+	github.com/ebitengine/oto/v3 v3.3.3
 	github.com/go-gl/gl v0.0.0-20231021071112-07e5d0ea2e71
 	github.com/go-gl/glfw v0.0.0-20250301202403-da16c1255728
 	github.com/go-gl/mathgl v1.2.0
 )
+
+require (
+	github.com/ebitengine/purego v0.8.0 // indirect
+	golang.org/x/sys v0.25.0 // indirect
+)
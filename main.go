@@ -5,6 +5,7 @@ import (
 	"log"
 	"runtime"
 
+	"github.com/OpenBiohazard2/OpenBiohazard2/audio"
 	"github.com/OpenBiohazard2/OpenBiohazard2/client"
 	"github.com/OpenBiohazard2/OpenBiohazard2/game"
 	"github.com/OpenBiohazard2/OpenBiohazard2/render"
@@ -37,6 +38,8 @@ func main() {
 
 	// Initialize game components
 	renderDef, gameDef, gameStateManager := initializeGame()
+	gameDef.SetAudioEngine(initializeAudio())
+	defer gameDef.Audio.Close()
 
 	// Create all state inputs
 	stateInputs := createStateInputs(renderDef, gameDef)
@@ -57,6 +60,17 @@ func initializeGame() (*render.RenderDef, *game.GameDef, *state.GameStateManager
 	return renderDef, gameDef, gameStateManager
 }
 
+// initializeAudio starts the mixer on the sound card, or without output if there is no device
+func initializeAudio() *audio.Engine {
+	audioEngine := audio.NewEngine(audio.NewDeviceBackend())
+	if err := audioEngine.Start(); err != nil {
+		log.Print("Audio device is not available, sound is disabled: ", err)
+		audioEngine = audio.NewEngine(audio.NewNullBackend())
+		audioEngine.Start()
+	}
+	return audioEngine
+}
+
 // createStateInputs initializes all game state input handlers
 func createStateInputs(renderDef *render.RenderDef, gameDef *game.GameDef) map[string]interface{} {
 	return map[string]interface{}{
@@ -69,9 +83,10 @@ func createStateInputs(renderDef *render.RenderDef, gameDef *game.GameDef) map[s
 		"specialMenu": &state.SpecialMenuStateInput{
 			RenderDef:  renderDef,
 			UIRenderer: ui_render.NewUIRenderer(renderDef),
-			Menu:       ui.NewMenu(2),
+			Menu:       ui.NewMenu(3),
 		},
-		"inventory": state.NewInventoryStateInput(renderDef),
+		"soundTest": state.NewSoundTestStateInput(renderDef, gameDef.Audio),
+		"inventory": state.NewInventoryStateInput(renderDef, gameDef),
 	}
 }
 
@@ -91,6 +106,8 @@ func runMainGameLoop(windowHandler *client.WindowHandler, gameStateManager *stat
 			state.HandleLoadSave(renderDef, gameStateManager, windowHandler)
 		case state.GAME_STATE_SPECIAL_MENU:
 			state.HandleSpecialMenu(stateInputs["specialMenu"].(*state.SpecialMenuStateInput), gameStateManager, windowHandler)
+		case state.GAME_STATE_SOUND_TEST:
+			state.HandleSoundTest(stateInputs["soundTest"].(*state.SoundTestStateInput), gameStateManager, windowHandler)
 		default:
 			log.Fatal("Invalid game state: ", gameStateManager.GameState)
 		}
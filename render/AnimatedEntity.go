@@ -11,6 +11,9 @@ import (
 const (
 	RENDER_TYPE_ENTITY = 3
 	VERTEX_LEN         = 8
+
+	// Skeleton component that turns when the player looks at something
+	PLAYER_HEAD_COMPONENT_ID = 2
 )
 
 type PlayerEntity struct {
@@ -25,8 +28,9 @@ type PlayerEntity struct {
 	// Pre-allocated arrays to avoid allocations every frame
 	Transforms       []mgl32.Mat4
 	ComponentOffsets []ComponentOffsets
-	LastPoseNumber   int  // Track when pose changes
-	BufferUploaded   bool // Track if buffer has been uploaded to GPU
+	LastPoseNumber   int     // Track when pose changes
+	LastNeckAngle    float32 // Track when the head turns
+	BufferUploaded   bool    // Track if buffer has been uploaded to GPU
 
 	Animation *Animation
 }
@@ -93,6 +97,15 @@ func RenderAnimatedEntity(r *RenderDef, playerEntity PlayerEntity, timeElapsedSe
 	playerEntity.cleanup()
 }
 
+// Returns 0 if the pose has no animation
+func (pe *PlayerEntity) GetPoseFrameCount(poseNumber int) int {
+	frames := pe.PLDOutput.AnimationData.AnimationIndexFrames
+	if poseNumber < 0 || poseNumber >= len(frames) {
+		return 0
+	}
+	return len(frames[poseNumber])
+}
+
 // updateAnimation handles animation frame updates
 func (pe *PlayerEntity) updateAnimation(timeElapsedSeconds float64) {
 	pe.Animation.UpdateAnimationFrame(pe.AnimationPoseNumber, pe.PLDOutput.AnimationData, timeElapsedSeconds)
@@ -100,10 +113,12 @@ func (pe *PlayerEntity) updateAnimation(timeElapsedSeconds float64) {
 
 // updateTransforms recalculates bone transforms when needed
 func (pe *PlayerEntity) updateTransforms() {
-	needsUpdate := pe.LastPoseNumber != pe.AnimationPoseNumber || !pe.BufferUploaded
+	neckAngle := pe.Player.ScriptControl.NeckAngle
+	needsUpdate := pe.LastPoseNumber != pe.AnimationPoseNumber || pe.LastNeckAngle != neckAngle || !pe.BufferUploaded
 	if needsUpdate {
-		buildComponentTransforms(pe.PLDOutput.SkeletonData, 0, -1, pe.Transforms, pe.Animation)
+		buildComponentTransforms(pe.PLDOutput.SkeletonData, 0, -1, pe.Transforms, pe.Animation, neckAngle)
 		pe.LastPoseNumber = pe.AnimationPoseNumber
+		pe.LastNeckAngle = neckAngle
 	}
 }
 
@@ -176,7 +191,8 @@ func (pe *PlayerEntity) cleanup() {
 	gl.DisableVertexAttribArray(2)
 }
 
-func buildComponentTransforms(skeletonData *fileio.EMROutput, curId int, parentId int, transforms []mgl32.Mat4, animation *Animation) {
+// Neck angle is in degrees and is applied to the head
+func buildComponentTransforms(skeletonData *fileio.EMROutput, curId int, parentId int, transforms []mgl32.Mat4, animation *Animation, neckAngle float32) {
 	transformMatrix := mgl32.Ident4()
 	if parentId != -1 {
 		transformMatrix = transforms[parentId]
@@ -198,12 +214,16 @@ func buildComponentTransforms(skeletonData *fileio.EMROutput, curId int, parentI
 		transformMatrix = transformMatrix.Mul4(quat.Mat4())
 	}
 
+	if curId == PLAYER_HEAD_COMPONENT_ID && neckAngle != 0 {
+		transformMatrix = transformMatrix.Mul4(mgl32.HomogRotate3DY(mgl32.DegToRad(neckAngle)))
+	}
+
 	transforms[curId] = transformMatrix
 
 	for i := 0; i < len(skeletonData.ArmatureChildren[curId]); i++ {
 		newParent := curId
 		newChild := int(skeletonData.ArmatureChildren[curId][i])
-		buildComponentTransforms(skeletonData, newChild, newParent, transforms, animation)
+		buildComponentTransforms(skeletonData, newChild, newParent, transforms, animation, neckAngle)
 	}
 }
 
@@ -1,6 +1,7 @@
 package render
 
 import (
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
 	"github.com/go-gl/mathgl/mgl32"
 )
 
@@ -9,6 +10,8 @@ type SceneMD1Entity struct {
 	VertexBuffer       []float32  // 3 elements for x,y,z, 2 elements for texture u,v, and 3 elements for normal x,y,z
 	ModelPosition      mgl32.Vec3 // Position in world space
 	RotationAngle      float32
+	Motion             int
+	ScriptWork         game.ScriptEntityWork
 	VertexArrayObject  uint32
 	VertexBufferObject uint32
 }
@@ -32,3 +35,31 @@ func (r *RenderDef) RenderStaticEntity(entity SceneMD1Entity, renderType int32)
 	// Render the entity
 	r.Renderer.RenderEntity(config)
 }
+
+func (entity *SceneMD1Entity) GetPosition() mgl32.Vec3 {
+	return entity.ModelPosition
+}
+
+func (entity *SceneMD1Entity) SetPosition(position mgl32.Vec3) {
+	entity.ModelPosition = position
+}
+
+func (entity *SceneMD1Entity) GetRotationAngle() float32 {
+	return entity.RotationAngle
+}
+
+func (entity *SceneMD1Entity) SetRotationAngle(rotationAngle float32) {
+	entity.RotationAngle = rotationAngle
+}
+
+func (entity *SceneMD1Entity) GetMotion() int {
+	return entity.Motion
+}
+
+func (entity *SceneMD1Entity) SetMotion(motion int) {
+	entity.Motion = motion
+}
+
+func (entity *SceneMD1Entity) GetScriptWork() *game.ScriptEntityWork {
+	return &entity.ScriptWork
+}
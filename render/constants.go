@@ -13,6 +13,8 @@ const (
 	RENDER_GAME_STATE_BACKGROUND_SOLID       = 1
 	RENDER_GAME_STATE_BACKGROUND_TRANSPARENT = 2
 	RENDER_TYPE_ITEM                         = 5
+	RENDER_TYPE_SCREEN_EFFECT                = 6
+	RENDER_TYPE_SHADOW                       = 7
 
 	// Camera Constants
 	DEFAULT_FOV_DEGREES = 60.0
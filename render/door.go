@@ -0,0 +1,135 @@
+package render
+
+import (
+	"math"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/geometry"
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	DOOR_OPEN_ANGLE        = 90.0 // Degrees the door swings when it is open
+	DOOR_ANIMATION_SECONDS = 0.5
+)
+
+// Door model placed in the room by the script
+type DoorEntity struct {
+	Model       SceneMD1Entity
+	Aot         uint8 // Door aot the model belongs to
+	ClosedAngle float32
+	Progress    float64 // 0 is closed, 1 is open
+	IsOpening   bool
+}
+
+type DoorGroupEntity struct {
+	Doors map[uint8]*DoorEntity // Key is the model index
+}
+
+func NewDoorGroupEntity() *DoorGroupEntity {
+	return &DoorGroupEntity{
+		Doors: make(map[uint8]*DoorEntity),
+	}
+}
+
+func NewDoorEntity(do2Output *fileio.DO2Output, aot uint8, position mgl32.Vec3, rotationAngle float32) *DoorEntity {
+	var vao uint32
+	gl.GenVertexArrays(1, &vao)
+
+	var vbo uint32
+	gl.GenBuffers(1, &vbo)
+
+	return &DoorEntity{
+		Model: SceneMD1Entity{
+			VertexArrayObject:  vao,
+			VertexBufferObject: vbo,
+			TextureId:          NewTextureTIM(do2Output.TIMOutput),
+			VertexBuffer:       geometry.NewMD1Geometry(do2Output.MD1Output, do2Output.TIMOutput),
+			ModelPosition:      position,
+			RotationAngle:      rotationAngle,
+		},
+		Aot:         aot,
+		ClosedAngle: rotationAngle,
+	}
+}
+
+func (dge *DoorGroupEntity) SetDoor(index uint8, door *DoorEntity) {
+	if oldDoor, exists := dge.Doors[index]; exists && oldDoor != door {
+		oldDoor.DeleteDoorEntity()
+	}
+	dge.Doors[index] = door
+}
+
+// Frees the GL objects of every door before the next room loads its own
+func (dge *DoorGroupEntity) ClearDoors() {
+	for _, door := range dge.Doors {
+		door.DeleteDoorEntity()
+	}
+	dge.Doors = make(map[uint8]*DoorEntity)
+}
+
+func (door *DoorEntity) DeleteDoorEntity() {
+	// Zero ids were never created
+	if door.Model.VertexArrayObject != 0 {
+		gl.DeleteVertexArrays(1, &door.Model.VertexArrayObject)
+	}
+	if door.Model.VertexBufferObject != 0 {
+		gl.DeleteBuffers(1, &door.Model.VertexBufferObject)
+	}
+	if door.Model.TextureId != 0 {
+		gl.DeleteTextures(1, &door.Model.TextureId)
+	}
+	door.Model.VertexArrayObject = 0
+	door.Model.VertexBufferObject = 0
+	door.Model.TextureId = 0
+}
+
+// Returns nil if no model is attached to the aot
+func (dge *DoorGroupEntity) GetDoorByAot(aot uint8) *DoorEntity {
+	for _, door := range dge.Doors {
+		if door.Aot == aot {
+			return door
+		}
+	}
+	return nil
+}
+
+func (dge *DoorGroupEntity) Update(timeElapsedSeconds float64) {
+	for _, door := range dge.Doors {
+		door.Update(timeElapsedSeconds)
+	}
+}
+
+func (door *DoorEntity) Open() {
+	door.IsOpening = true
+}
+
+func (door *DoorEntity) Close() {
+	door.IsOpening = false
+}
+
+func (door *DoorEntity) IsOpen() bool {
+	return door.Progress >= 1.0
+}
+
+func (door *DoorEntity) IsClosed() bool {
+	return door.Progress <= 0.0
+}
+
+// Swings the door towards the open or closed position
+func (door *DoorEntity) Update(timeElapsedSeconds float64) {
+	step := timeElapsedSeconds / DOOR_ANIMATION_SECONDS
+	if door.IsOpening {
+		door.Progress = math.Min(door.Progress+step, 1.0)
+	} else {
+		door.Progress = math.Max(door.Progress-step, 0.0)
+	}
+	door.Model.RotationAngle = door.ClosedAngle + float32(door.Progress)*DOOR_OPEN_ANGLE
+}
+
+func (ss *SceneSystem) RenderDoors(renderDef *RenderDef) {
+	for _, door := range ss.DoorGroupEntity.Doors {
+		renderDef.RenderStaticEntity(door.Model, RENDER_TYPE_ITEM)
+	}
+}
@@ -0,0 +1,51 @@
+package render
+
+import (
+	"testing"
+)
+
+func TestDoorEntityUpdate(t *testing.T) {
+	door := &DoorEntity{ClosedAngle: 45}
+
+	door.Open()
+	door.Update(DOOR_ANIMATION_SECONDS / 2)
+	if door.IsOpen() || door.IsClosed() {
+		t.Errorf("Expected door to be half open, got progress %f", door.Progress)
+	}
+	if door.Model.RotationAngle != 45+DOOR_OPEN_ANGLE/2 {
+		t.Errorf("Expected angle %f, got %f", 45+DOOR_OPEN_ANGLE/2, door.Model.RotationAngle)
+	}
+
+	door.Update(DOOR_ANIMATION_SECONDS)
+	if !door.IsOpen() {
+		t.Errorf("Expected door to be open, got progress %f", door.Progress)
+	}
+
+	door.Close()
+	door.Update(DOOR_ANIMATION_SECONDS * 2)
+	if !door.IsClosed() {
+		t.Errorf("Expected door to be closed, got progress %f", door.Progress)
+	}
+	if door.Model.RotationAngle != 45 {
+		t.Errorf("Expected closed angle 45, got %f", door.Model.RotationAngle)
+	}
+}
+
+func TestDoorGroupEntityGetDoorByAot(t *testing.T) {
+	doorGroup := NewDoorGroupEntity()
+	doorGroup.SetDoor(0, &DoorEntity{Aot: 3})
+	doorGroup.SetDoor(1, &DoorEntity{Aot: 5})
+
+	door := doorGroup.GetDoorByAot(5)
+	if door == nil || door.Aot != 5 {
+		t.Fatalf("Expected door for aot 5, got %v", door)
+	}
+	if doorGroup.GetDoorByAot(7) != nil {
+		t.Error("Expected no door for aot 7")
+	}
+
+	doorGroup.ClearDoors()
+	if len(doorGroup.Doors) != 0 {
+		t.Errorf("Expected no doors after clear, got %d", len(doorGroup.Doors))
+	}
+}
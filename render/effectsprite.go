@@ -0,0 +1,130 @@
+package render
+
+import (
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Effect sprites are animated billboards such as fire, smoke and sparks
+
+type EffectSprite struct {
+	Id          uint8 // Set by the script to control or kill the sprite
+	Type        uint8 // Id of the sprite animation in the ESP data
+	SpriteIndex int   // Index of the animation in the sprite group
+
+	// Sprites can follow an entity
+	WorkComponent  int
+	WorkIndex      int
+	AnchorPosition mgl32.Vec3
+
+	Origin       mgl32.Vec3 // Spawn position relative to the anchor
+	Offset       mgl32.Vec3 // Distance moved since the sprite was spawned
+	Velocity     mgl32.Vec3 // Units per second
+	Acceleration mgl32.Vec3 // Units per second squared
+
+	FrameIndex int
+	FrameTime  float64 // Seconds spent on the current frame
+	IsPaused   bool
+}
+
+func (sprite *EffectSprite) GetPosition() mgl32.Vec3 {
+	return sprite.AnchorPosition.Add(sprite.Origin).Add(sprite.Offset)
+}
+
+// Movement values from the ESP data are per frame
+// The offset starts again from the movement's translation
+func (sprite *EffectSprite) SetMovement(movement fileio.AnimMovement) {
+	sprite.Offset = mgl32.Vec3{float32(int16(movement.TranslateX)), float32(int16(movement.TranslateY)), 0}
+	sprite.setSpeed(movement)
+}
+
+// Changes how the sprite moves from where it is
+func (sprite *EffectSprite) setSpeed(movement fileio.AnimMovement) {
+	sprite.Velocity = mgl32.Vec3{
+		float32(movement.Speed[0]),
+		float32(movement.Speed[1]),
+		float32(movement.Speed[2]),
+	}.Mul(SPRITE_FRAMES_PER_SECOND)
+	sprite.Acceleration = mgl32.Vec3{
+		float32(int8(movement.Acceleration[0])),
+		float32(int8(movement.Acceleration[1])),
+		float32(int8(movement.Acceleration[2])),
+	}.Mul(SPRITE_FRAMES_PER_SECOND * SPRITE_FRAMES_PER_SECOND)
+}
+
+// Moves the sprite and plays the animation in a loop
+// Frames use the movement with the same index if there is one
+// Looping goes back to the spawn movement so the sprite doesn't drift away from its anchor
+func (sprite *EffectSprite) Update(timeElapsedSeconds float64, frames []fileio.AnimFrame, movements []fileio.AnimMovement) {
+	if sprite.IsPaused {
+		return
+	}
+
+	deltaTime := float32(timeElapsedSeconds)
+	sprite.Velocity = sprite.Velocity.Add(sprite.Acceleration.Mul(deltaTime))
+	sprite.Offset = sprite.Offset.Add(sprite.Velocity.Mul(deltaTime))
+
+	if len(frames) == 0 {
+		return
+	}
+
+	sprite.FrameTime += timeElapsedSeconds
+	for {
+		frameSeconds := float64(max(frames[sprite.FrameIndex].Time, 1)) / SPRITE_FRAMES_PER_SECOND
+		if sprite.FrameTime < frameSeconds {
+			break
+		}
+		sprite.FrameTime -= frameSeconds
+		sprite.FrameIndex = (sprite.FrameIndex + 1) % len(frames)
+		if sprite.FrameIndex >= len(movements) {
+			continue
+		}
+		if sprite.FrameIndex == 0 {
+			sprite.SetMovement(movements[0])
+		} else {
+			sprite.setSpeed(movements[sprite.FrameIndex])
+		}
+	}
+}
+
+// Returns nil if there is no animation for the sprite type
+// A sprite with the same id is replaced
+func (spriteGroupEntity *SpriteGroupEntity) SpawnSprite(id uint8, spriteType uint8, position mgl32.Vec3) *EffectSprite {
+	spriteIndex, exists := spriteGroupEntity.SpriteTextureIndexMap[int(spriteType)]
+	if !exists {
+		return nil
+	}
+
+	sprite := &EffectSprite{
+		Id:          id,
+		Type:        spriteType,
+		SpriteIndex: spriteIndex,
+		Origin:      position,
+	}
+	movements := spriteGroupEntity.SpriteData[spriteIndex].AnimMovements
+	if len(movements) > 0 {
+		sprite.SetMovement(movements[0])
+	}
+	spriteGroupEntity.Instances[id] = sprite
+	return sprite
+}
+
+// Returns nil if no sprite has the id
+func (spriteGroupEntity *SpriteGroupEntity) GetSprite(id uint8) *EffectSprite {
+	return spriteGroupEntity.Instances[id]
+}
+
+func (spriteGroupEntity *SpriteGroupEntity) KillSprite(id uint8) bool {
+	if _, exists := spriteGroupEntity.Instances[id]; !exists {
+		return false
+	}
+	delete(spriteGroupEntity.Instances, id)
+	return true
+}
+
+func (spriteGroupEntity *SpriteGroupEntity) Update(timeElapsedSeconds float64) {
+	for _, sprite := range spriteGroupEntity.Instances {
+		spriteData := &spriteGroupEntity.SpriteData[sprite.SpriteIndex]
+		sprite.Update(timeElapsedSeconds, spriteData.FrameData, spriteData.AnimMovements)
+	}
+}
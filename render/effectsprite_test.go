@@ -0,0 +1,112 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func createTestSpriteGroup() *SpriteGroupEntity {
+	spriteData := []fileio.SpriteData{
+		{
+			Id: 8,
+			FrameData: []fileio.AnimFrame{
+				{SpriteId: 0, Time: 3, SquareSide: 16},
+				{SpriteId: 1, Time: 0, SquareSide: 16},
+			},
+			AnimMovements: []fileio.AnimMovement{
+				{Speed: [3]int16{0, -2, 0}},
+			},
+		},
+	}
+	return &SpriteGroupEntity{
+		SpriteTextureIndexMap: buildSpriteTextureIndexMap(spriteData),
+		SpriteData:            spriteData,
+		Instances:             make(map[uint8]*EffectSprite),
+	}
+}
+
+func TestSpawnSprite(t *testing.T) {
+	spriteGroup := createTestSpriteGroup()
+
+	sprite := spriteGroup.SpawnSprite(1, 8, mgl32.Vec3{100, 0, 200})
+	if sprite == nil {
+		t.Fatal("Expected sprite to be spawned")
+	}
+	if spriteGroup.GetSprite(1) != sprite {
+		t.Error("Expected sprite to be found by id")
+	}
+	if sprite.Velocity.Y() != -2*SPRITE_FRAMES_PER_SECOND {
+		t.Errorf("Expected speed from the animation movement, got %v", sprite.Velocity)
+	}
+
+	if spriteGroup.SpawnSprite(2, 99, mgl32.Vec3{}) != nil {
+		t.Error("Expected unknown sprite type to fail")
+	}
+}
+
+func TestEffectSpriteUpdate(t *testing.T) {
+	spriteGroup := createTestSpriteGroup()
+	sprite := spriteGroup.SpawnSprite(1, 8, mgl32.Vec3{100, 0, 200})
+
+	// First frame lasts 3 game frames
+	spriteGroup.Update(2.0 / SPRITE_FRAMES_PER_SECOND)
+	if sprite.FrameIndex != 0 {
+		t.Errorf("Expected frame 0, got %d", sprite.FrameIndex)
+	}
+	spriteGroup.Update(1.5 / SPRITE_FRAMES_PER_SECOND)
+	if sprite.FrameIndex != 1 {
+		t.Errorf("Expected frame 1, got %d", sprite.FrameIndex)
+	}
+	position := sprite.GetPosition()
+	if position.X() != 100 || position.Z() != 200 || position.Y() >= 0 {
+		t.Errorf("Expected sprite to move up from the spawn position, got %v", position)
+	}
+
+	// Frames with no time last one game frame, then the animation loops back to the spawn position
+	spriteGroup.Update(1.0 / SPRITE_FRAMES_PER_SECOND)
+	if sprite.FrameIndex != 0 {
+		t.Errorf("Expected animation to loop to frame 0, got %d", sprite.FrameIndex)
+	}
+	if position := sprite.GetPosition(); position != (mgl32.Vec3{100, 0, 200}) {
+		t.Errorf("Expected sprite to start again from the spawn position, got %v", position)
+	}
+}
+
+func TestEffectSpriteFrameMovement(t *testing.T) {
+	spriteGroup := createTestSpriteGroup()
+	spriteGroup.SpriteData[0].AnimMovements = append(spriteGroup.SpriteData[0].AnimMovements,
+		fileio.AnimMovement{Speed: [3]int16{3, 0, 0}})
+	sprite := spriteGroup.SpawnSprite(1, 8, mgl32.Vec3{})
+
+	spriteGroup.Update(3.0 / SPRITE_FRAMES_PER_SECOND)
+	if sprite.FrameIndex != 1 || sprite.Velocity != (mgl32.Vec3{3 * SPRITE_FRAMES_PER_SECOND, 0, 0}) {
+		t.Errorf("Expected the movement of frame 1, got frame %d with speed %v", sprite.FrameIndex, sprite.Velocity)
+	}
+	// The sprite keeps its position when the frame changes
+	if sprite.Offset.Y() >= 0 {
+		t.Errorf("Expected offset from the first frame to be kept, got %v", sprite.Offset)
+	}
+}
+
+func TestEffectSpritePausedAndKill(t *testing.T) {
+	spriteGroup := createTestSpriteGroup()
+	sprite := spriteGroup.SpawnSprite(1, 8, mgl32.Vec3{})
+	sprite.IsPaused = true
+
+	spriteGroup.Update(1.0)
+	if sprite.FrameIndex != 0 || sprite.Offset.Y() != 0 {
+		t.Error("Expected paused sprite to stay still")
+	}
+
+	if !spriteGroup.KillSprite(1) {
+		t.Error("Expected sprite to be killed")
+	}
+	if spriteGroup.GetSprite(1) != nil {
+		t.Error("Expected sprite to be gone")
+	}
+	if spriteGroup.KillSprite(1) {
+		t.Error("Expected second kill to fail")
+	}
+}
@@ -2,18 +2,19 @@ package render
 
 import (
 	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
 	"github.com/go-gl/mathgl/mgl32"
 )
 
 type EnemyEntity struct {
-	EnemyType    uint8
-	ModelType    uint8
-	Status       uint8
-	Motion       uint16
-	Position     mgl32.Vec3
-	RotationY    float32
-	EMDOutput    *fileio.EMDOutput
-	DebugEntity  *DebugEntity
+	EnemyType   uint8
+	ModelType   uint8
+	Motion      uint16
+	Position    mgl32.Vec3
+	RotationY   float32
+	ScriptWork  game.ScriptEntityWork
+	EMDOutput   *fileio.EMDOutput
+	DebugEntity *DebugEntity
 }
 
 func NewEnemyEntity(emdOutput *fileio.EMDOutput) *EnemyEntity {
@@ -25,7 +26,6 @@ func NewEnemyEntity(emdOutput *fileio.EMDOutput) *EnemyEntity {
 func (enemy *EnemyEntity) SetEnemyData(instruction fileio.ScriptInstrSceEmSet) {
 	enemy.EnemyType = instruction.Type
 	enemy.ModelType = instruction.ModelType
-	enemy.Status = instruction.Status
 	enemy.Motion = instruction.Motion
 	enemy.Position = mgl32.Vec3{
 		float32(instruction.X),
@@ -33,7 +33,13 @@ func (enemy *EnemyEntity) SetEnemyData(instruction fileio.ScriptInstrSceEmSet) {
 		float32(instruction.Z),
 	}
 	enemy.RotationY = float32(instruction.DirY) * (180.0 / 32768.0) // Convert to degrees
-	
+	enemy.ScriptWork = game.ScriptEntityWork{
+		Id:     int(instruction.Id),
+		Type:   int(instruction.Type),
+		Status: int(instruction.Status),
+		Floor:  int(instruction.Floor),
+	}
+
 	enemy.DebugEntity = NewEnemyDebugEntity(enemy.Position, enemy.RotationY)
 }
 
@@ -44,3 +50,30 @@ func (enemy *EnemyEntity) GetModelMatrix() mgl32.Mat4 {
 	return modelMatrix
 }
 
+func (enemy *EnemyEntity) GetPosition() mgl32.Vec3 {
+	return enemy.Position
+}
+
+func (enemy *EnemyEntity) SetPosition(position mgl32.Vec3) {
+	enemy.Position = position
+}
+
+func (enemy *EnemyEntity) GetRotationAngle() float32 {
+	return enemy.RotationY
+}
+
+func (enemy *EnemyEntity) SetRotationAngle(rotationAngle float32) {
+	enemy.RotationY = rotationAngle
+}
+
+func (enemy *EnemyEntity) GetMotion() int {
+	return int(enemy.Motion)
+}
+
+func (enemy *EnemyEntity) SetMotion(motion int) {
+	enemy.Motion = uint16(motion)
+}
+
+func (enemy *EnemyEntity) GetScriptWork() *game.ScriptEntityWork {
+	return &enemy.ScriptWork
+}
@@ -101,3 +101,13 @@ func (renderDef *RenderDef) RenderTransparentVideoBuffer() {
 
 	renderDef.RenderEntity2D(renderDef.VideoBuffer, RENDER_GAME_STATE_BACKGROUND_TRANSPARENT)
 }
+
+// Draws the video buffer on top of the 3D scene without clearing it
+func (renderDef *RenderDef) RenderOverlayVideoBuffer() {
+	gl.Clear(gl.DEPTH_BUFFER_BIT)
+
+	renderDef.ShaderSystem.Use()
+	renderDef.ShaderSystem.SetGameState(RENDER_GAME_STATE_BACKGROUND_TRANSPARENT)
+
+	renderDef.RenderEntity2D(renderDef.VideoBuffer, RENDER_GAME_STATE_BACKGROUND_TRANSPARENT)
+}
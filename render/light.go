@@ -0,0 +1,88 @@
+package render
+
+import (
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/shader"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	LIGHT_AXIS_X = 0
+	LIGHT_AXIS_Y = 1
+	LIGHT_AXIS_Z = 2
+)
+
+// Point light from the room LIT data
+// Brightness is the distance the light reaches
+type PointLight struct {
+	Position   mgl32.Vec3
+	Color      [3]float32
+	Brightness float32
+}
+
+// All lights for the current camera
+// Scripts can change them until the next camera change resets them
+type CameraLights struct {
+	Ambient     [3]float32
+	PointLights [shader.MAX_POINT_LIGHTS]PointLight
+}
+
+func BuildCameraLights(light fileio.LITCameraLight) CameraLights {
+	cameraLights := CameraLights{
+		Ambient: BuildEnvironmentLight(light),
+	}
+	for i := 0; i < len(cameraLights.PointLights); i++ {
+		position := light.Positions[i]
+		cameraLights.PointLights[i] = PointLight{
+			Position:   mgl32.Vec3{float32(position.X), float32(position.Y), float32(position.Z)},
+			Color:      normalizeLightColor(light.Colors[i]),
+			Brightness: float32(light.Brightness[i]),
+		}
+	}
+	return cameraLights
+}
+
+// BuildEnvironmentLight converts a LIT camera light to normalized RGB values
+func BuildEnvironmentLight(light fileio.LITCameraLight) [3]float32 {
+	return normalizeLightColor(light.AmbientColor)
+}
+
+// Normalize color rgb values to be between 0.0 and 1.0
+func normalizeLightColor(lightColor fileio.LITLightColor) [3]float32 {
+	red := float32(lightColor.R) / 255.0
+	green := float32(lightColor.G) / 255.0
+	blue := float32(lightColor.B) / 255.0
+	return [3]float32{red, green, blue}
+}
+
+// Returns false if the light or axis doesn't exist
+func (cameraLights *CameraLights) SetLightPosition(lightIndex int, axis int, value float32) bool {
+	if lightIndex < 0 || lightIndex >= len(cameraLights.PointLights) || axis < LIGHT_AXIS_X || axis > LIGHT_AXIS_Z {
+		return false
+	}
+	cameraLights.PointLights[lightIndex].Position[axis] = value
+	return true
+}
+
+// Returns false if the light doesn't exist
+func (cameraLights *CameraLights) SetLightBrightness(lightIndex int, brightness float32) bool {
+	if lightIndex < 0 || lightIndex >= len(cameraLights.PointLights) {
+		return false
+	}
+	cameraLights.PointLights[lightIndex].Brightness = brightness
+	return true
+}
+
+func (r *RenderDef) setLightUniforms() {
+	var positions [shader.MAX_POINT_LIGHTS][3]float32
+	var colors [shader.MAX_POINT_LIGHTS][3]float32
+	var brightness [shader.MAX_POINT_LIGHTS]float32
+	for i, pointLight := range r.CameraLights.PointLights {
+		positions[i] = pointLight.Position
+		colors[i] = pointLight.Color
+		brightness[i] = pointLight.Brightness
+	}
+
+	r.ShaderSystem.SetEnvironmentLight(r.CameraLights.Ambient)
+	r.ShaderSystem.SetPointLights(positions, colors, brightness)
+}
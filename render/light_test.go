@@ -0,0 +1,46 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestBuildCameraLights(t *testing.T) {
+	light := fileio.LITCameraLight{
+		AmbientColor: fileio.LITLightColor{R: 255, G: 0, B: 0},
+	}
+	light.Colors[1] = fileio.LITLightColor{R: 0, G: 255, B: 0}
+	light.Positions[1] = fileio.LITPosition{X: 100, Y: -200, Z: 300}
+	light.Brightness[1] = 4000
+
+	cameraLights := BuildCameraLights(light)
+	if cameraLights.Ambient != [3]float32{1, 0, 0} {
+		t.Errorf("Expected red ambient light, got %v", cameraLights.Ambient)
+	}
+
+	pointLight := cameraLights.PointLights[1]
+	if pointLight.Position != (mgl32.Vec3{100, -200, 300}) {
+		t.Errorf("Expected light position [100, -200, 300], got %v", pointLight.Position)
+	}
+	if pointLight.Color != [3]float32{0, 1, 0} {
+		t.Errorf("Expected green light, got %v", pointLight.Color)
+	}
+	if pointLight.Brightness != 4000 {
+		t.Errorf("Expected brightness 4000, got %f", pointLight.Brightness)
+	}
+}
+
+func TestCameraLights_SetInvalidLight(t *testing.T) {
+	cameraLights := CameraLights{}
+	if cameraLights.SetLightPosition(3, LIGHT_AXIS_X, 10) {
+		t.Error("Expected light 3 to not exist")
+	}
+	if cameraLights.SetLightPosition(0, 3, 10) {
+		t.Error("Expected axis 3 to not exist")
+	}
+	if cameraLights.SetLightBrightness(-1, 10) {
+		t.Error("Expected light -1 to not exist")
+	}
+}
@@ -14,9 +14,19 @@ type RenderDef struct {
 	ShaderSystem     *shader.ShaderSystem // Grouped shader management
 	ViewSystem       *ViewSystem          // Grouped camera/view components
 	SceneSystem      *SceneSystem         // Grouped scene entities
-	EnvironmentLight [3]float32
+	CameraLights     CameraLights
 	VideoBuffer      *Entity2D
 
+	// Fades and camera shake
+	ScreenEffects      *ScreenEffects
+	ScreenEffectEntity *Entity2D
+
+	// Blob shadows under characters
+	ShadowSystem *ShadowSystem
+
+	// Water surface in flooded rooms
+	WaterPlane WaterPlane
+
 	// Screen image management for menu rendering
 	ScreenImageManager *ScreenImageManager
 	
@@ -56,6 +66,9 @@ func InitRenderer(windowWidth int, windowHeight int) *RenderDef {
 		ViewSystem:         NewViewSystem(windowWidth, windowHeight),
 		SceneSystem:        NewSceneSystem(),
 		VideoBuffer:        NewBackgroundImageEntity(),
+		ScreenEffects:      NewScreenEffects(),
+		ScreenEffectEntity: NewScreenEffectEntity(),
+		ShadowSystem:       NewShadowSystem(),
 		ScreenImageManager: NewScreenImageManager(),
 		Renderer:           NewOpenGLRenderer(shaderSystem.GetUniformLocations()),
 	}
@@ -83,20 +96,28 @@ func (r *RenderDef) RenderFrame(playerEntity PlayerEntity,
 	r.ShaderSystem.SetViewMatrix(viewMatrix)
 	r.ShaderSystem.SetProjectionMatrix(projectionMatrix)
 
+	r.ScreenEffects.Update(timeElapsedSeconds)
+	r.ShaderSystem.SetScreenOffset(r.ScreenEffects.GetShakeOffset())
+
 	r.SceneSystem.RenderBackground(r)
+	r.RenderShadows()
 	r.SceneSystem.RenderItems(r)
+	r.SceneSystem.DoorGroupEntity.Update(timeElapsedSeconds)
+	r.SceneSystem.RenderDoors(r)
 
-	// Use cached uniform location for environment light
-	r.ShaderSystem.SetEnvironmentLight(r.EnvironmentLight)
+	r.setLightUniforms()
+	r.setWaterUniforms()
 	RenderAnimatedEntity(r, playerEntity, timeElapsedSeconds)
 
 	r.SceneSystem.RenderEnemies(r, timeElapsedSeconds)
 
-	// RenderSprites(r, r.SpriteGroupEntity, timeElapsedSeconds)
+	RenderSprites(r, r.SceneSystem.SpriteGroupEntity, timeElapsedSeconds)
 
 	// Only render for debugging
 	RenderCameraSwitches(r, debugEntities.CameraSwitchDebugEntity)
 	RenderDebugEntities(r, debugEntities.DebugEntities)
+
+	r.RenderScreenEffects()
 }
 
 // UpdateCameraMask updates the camera mask entity
@@ -23,13 +23,3 @@ func NewRenderRoom(rdtOutput *fileio.RDTOutput) RenderRoom {
 		SpriteData:      rdtOutput.SpriteOutput.SpriteData,
 	}
 }
-
-// BuildEnvironmentLight converts a LIT camera light to normalized RGB values
-func BuildEnvironmentLight(light fileio.LITCameraLight) [3]float32 {
-	lightColor := light.AmbientColor
-	// Normalize color rgb values to be between 0.0 and 1.0
-	red := float32(lightColor.R) / 255.0
-	green := float32(lightColor.G) / 255.0
-	blue := float32(lightColor.B) / 255.0
-	return [3]float32{red, green, blue}
-}
@@ -11,6 +11,7 @@ type SceneSystem struct {
 	CameraMaskEntity      *Entity2D
 	ItemGroupEntity       *ItemGroupEntity
 	EnemyGroupEntity      *EnemyGroupEntity
+	DoorGroupEntity       *DoorGroupEntity
 }
 
 // NewSceneSystem creates a new scene system with all entities
@@ -21,6 +22,7 @@ func NewSceneSystem() *SceneSystem {
 		CameraMaskEntity:      NewEntity2D(),
 		ItemGroupEntity:       NewItemGroupEntity(),
 		EnemyGroupEntity:      NewEnemyGroupEntity(),
+		DoorGroupEntity:       NewDoorGroupEntity(),
 	}
 }
 
@@ -32,6 +34,7 @@ func NewSceneSystemForTesting() *SceneSystem {
 		CameraMaskEntity:      nil,
 		ItemGroupEntity:       nil,
 		EnemyGroupEntity:      nil,
+		DoorGroupEntity:       NewDoorGroupEntity(),
 	}
 }
 
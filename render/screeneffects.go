@@ -0,0 +1,177 @@
+package render
+
+import (
+	"math"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/geometry"
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Screen effects are drawn on top of the scene after everything else
+
+const (
+	FADE_DIRECTION_IN  = 0 // From the fade color to the scene
+	FADE_DIRECTION_OUT = 1 // From the scene to the fade color
+
+	// Blend modes used by the PS1 for semi-transparent polygons
+	FADE_BLEND_ALPHA       = 0 // Mix the scene with the fade color
+	FADE_BLEND_ADDITIVE    = 1 // Add the fade color to the scene
+	FADE_BLEND_SUBTRACTIVE = 2 // Subtract the fade color from the scene
+
+	// Channels the fade color operand turns on, no bits is black and every bit is white
+	FADE_COLOR_RED   = 0x01
+	FADE_COLOR_GREEN = 0x02
+	FADE_COLOR_BLUE  = 0x04
+	FADE_COLOR_WHITE = FADE_COLOR_RED | FADE_COLOR_GREEN | FADE_COLOR_BLUE
+
+	// Number of times the screen moves back and forth per second
+	SHAKE_FREQUENCY = 15.0
+)
+
+type ScreenFade struct {
+	Direction int
+	Color     [3]float32
+	BlendMode int
+	Duration  float64 // Seconds
+	Elapsed   float64
+}
+
+type ScreenShake struct {
+	Amplitude float32 // Pixels on the 320x240 screen
+	Duration  float64 // Seconds
+	Elapsed   float64
+}
+
+type ScreenEffects struct {
+	Fade  ScreenFade
+	Shake ScreenShake
+}
+
+func NewScreenEffects() *ScreenEffects {
+	return &ScreenEffects{}
+}
+
+// Converts the color operand of SCE_FADE_SET to RGB
+func DecodeFadeColor(colorMask uint8) [3]float32 {
+	var color [3]float32
+	for i, channel := range []uint8{FADE_COLOR_RED, FADE_COLOR_GREEN, FADE_COLOR_BLUE} {
+		if colorMask&channel != 0 {
+			color[i] = 1.0
+		}
+	}
+	return color
+}
+
+func (effects *ScreenEffects) StartFade(direction int, color [3]float32, blendMode int, durationSeconds float64) {
+	effects.Fade = ScreenFade{
+		Direction: direction,
+		Color:     color,
+		BlendMode: blendMode,
+		Duration:  durationSeconds,
+		Elapsed:   0,
+	}
+}
+
+func (effects *ScreenEffects) StartShake(amplitude float32, durationSeconds float64) {
+	effects.Shake = ScreenShake{
+		Amplitude: amplitude,
+		Duration:  durationSeconds,
+		Elapsed:   0,
+	}
+}
+
+func (effects *ScreenEffects) StopShake() {
+	effects.Shake = ScreenShake{}
+}
+
+func (effects *ScreenEffects) Update(timeElapsedSeconds float64) {
+	if !effects.IsFadeComplete() {
+		effects.Fade.Elapsed = math.Min(effects.Fade.Elapsed+timeElapsedSeconds, effects.Fade.Duration)
+	}
+	if effects.IsShaking() {
+		effects.Shake.Elapsed = math.Min(effects.Shake.Elapsed+timeElapsedSeconds, effects.Shake.Duration)
+	}
+}
+
+func (effects *ScreenEffects) IsFadeComplete() bool {
+	return effects.Fade.Elapsed >= effects.Fade.Duration
+}
+
+// How much of the screen is covered by the fade color, from 0 to 1
+// The screen stays covered after a fade out until the next fade in
+func (effects *ScreenEffects) GetFadeIntensity() float32 {
+	progress := 1.0
+	if effects.Fade.Duration > 0 {
+		progress = effects.Fade.Elapsed / effects.Fade.Duration
+	}
+
+	if effects.Fade.Direction == FADE_DIRECTION_OUT {
+		return float32(progress)
+	}
+	return float32(1.0 - progress)
+}
+
+// Color output by the shader for the fade quad
+// Additive and subtractive modes scale the color because the blend function can't use alpha
+func (effects *ScreenEffects) GetFadeOverlayColor() [4]float32 {
+	intensity := effects.GetFadeIntensity()
+	color := effects.Fade.Color
+	if effects.Fade.BlendMode == FADE_BLEND_ALPHA {
+		return [4]float32{color[0], color[1], color[2], intensity}
+	}
+	return [4]float32{color[0] * intensity, color[1] * intensity, color[2] * intensity, 1.0}
+}
+
+func (effects *ScreenEffects) IsShaking() bool {
+	return effects.Shake.Elapsed < effects.Shake.Duration
+}
+
+// Offset in normalized device coordinates added to every vertex of the scene
+// The shake gets weaker until it stops
+func (effects *ScreenEffects) GetShakeOffset() mgl32.Vec2 {
+	if !effects.IsShaking() {
+		return mgl32.Vec2{0, 0}
+	}
+
+	remaining := float32(1.0 - effects.Shake.Elapsed/effects.Shake.Duration)
+	phase := 2.0 * math.Pi * SHAKE_FREQUENCY * effects.Shake.Elapsed
+	offsetX := effects.Shake.Amplitude * remaining * float32(math.Sin(phase)) * 0.5
+	offsetY := effects.Shake.Amplitude * remaining * float32(math.Cos(phase))
+	return mgl32.Vec2{
+		2.0 * offsetX / float32(geometry.BACKGROUND_IMAGE_WIDTH),
+		2.0 * offsetY / float32(geometry.BACKGROUND_IMAGE_HEIGHT),
+	}
+}
+
+func NewScreenEffectEntity() *Entity2D {
+	screenEffectEntity := NewEntity2D()
+	rect := geometry.NewFullScreenQuad(0.0)
+	screenEffectEntity.SetMesh(rect.VertexBuffer)
+	return screenEffectEntity
+}
+
+// Draws the fade quad over the whole screen
+func (r *RenderDef) RenderScreenEffects() {
+	if r.ScreenEffects.GetFadeIntensity() <= 0 {
+		return
+	}
+
+	gl.Clear(gl.DEPTH_BUFFER_BIT)
+	r.ShaderSystem.SetScreenOffset(mgl32.Vec2{0, 0})
+	r.ShaderSystem.SetDebugColor(r.ScreenEffects.GetFadeOverlayColor())
+
+	switch r.ScreenEffects.Fade.BlendMode {
+	case FADE_BLEND_ADDITIVE:
+		gl.BlendFunc(gl.ONE, gl.ONE)
+	case FADE_BLEND_SUBTRACTIVE:
+		gl.BlendFunc(gl.ONE, gl.ONE)
+		gl.BlendEquation(gl.FUNC_REVERSE_SUBTRACT)
+	}
+
+	r.RenderEntity2D(r.ScreenEffectEntity, RENDER_TYPE_SCREEN_EFFECT)
+
+	// Restore default blending
+	gl.BlendEquation(gl.FUNC_ADD)
+	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+}
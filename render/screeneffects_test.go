@@ -0,0 +1,106 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestScreenEffects_FadeOut(t *testing.T) {
+	effects := NewScreenEffects()
+	if effects.GetFadeIntensity() != 0 {
+		t.Errorf("Expected no fade by default, got %f", effects.GetFadeIntensity())
+	}
+
+	effects.StartFade(FADE_DIRECTION_OUT, [3]float32{1, 1, 1}, FADE_BLEND_ALPHA, 1.0)
+	if effects.IsFadeComplete() {
+		t.Error("Fade should not be complete when it starts")
+	}
+
+	effects.Update(0.5)
+	if effects.GetFadeIntensity() != 0.5 {
+		t.Errorf("Expected fade intensity 0.5, got %f", effects.GetFadeIntensity())
+	}
+
+	// The screen stays covered after the fade out
+	effects.Update(2.0)
+	if !effects.IsFadeComplete() {
+		t.Error("Fade should be complete")
+	}
+	if effects.GetFadeIntensity() != 1 {
+		t.Errorf("Expected fade intensity 1, got %f", effects.GetFadeIntensity())
+	}
+}
+
+func TestScreenEffects_FadeIn(t *testing.T) {
+	effects := NewScreenEffects()
+	effects.StartFade(FADE_DIRECTION_IN, [3]float32{0, 0, 0}, FADE_BLEND_ALPHA, 1.0)
+	if effects.GetFadeIntensity() != 1 {
+		t.Errorf("Expected fade intensity 1, got %f", effects.GetFadeIntensity())
+	}
+
+	effects.Update(1.0)
+	if effects.GetFadeIntensity() != 0 {
+		t.Errorf("Expected fade intensity 0, got %f", effects.GetFadeIntensity())
+	}
+}
+
+func TestScreenEffects_FadeOverlayColor(t *testing.T) {
+	tests := []struct {
+		name      string
+		blendMode int
+		expected  [4]float32
+	}{
+		{"alpha", FADE_BLEND_ALPHA, [4]float32{1, 0, 0, 0.5}},
+		{"additive", FADE_BLEND_ADDITIVE, [4]float32{0.5, 0, 0, 1}},
+		{"subtractive", FADE_BLEND_SUBTRACTIVE, [4]float32{0.5, 0, 0, 1}},
+	}
+
+	for _, test := range tests {
+		effects := NewScreenEffects()
+		effects.StartFade(FADE_DIRECTION_OUT, [3]float32{1, 0, 0}, test.blendMode, 1.0)
+		effects.Update(0.5)
+		if color := effects.GetFadeOverlayColor(); color != test.expected {
+			t.Errorf("%s: expected color %v, got %v", test.name, test.expected, color)
+		}
+	}
+}
+
+func TestScreenEffects_Shake(t *testing.T) {
+	effects := NewScreenEffects()
+	effects.StartShake(8, 1.0)
+	if !effects.IsShaking() {
+		t.Fatal("Screen should be shaking")
+	}
+
+	effects.Update(0.25)
+	if effects.GetShakeOffset() == (mgl32.Vec2{0, 0}) {
+		t.Error("Expected the screen to be offset while shaking")
+	}
+
+	effects.Update(1.0)
+	if effects.IsShaking() {
+		t.Error("Shake should stop after the duration")
+	}
+	if effects.GetShakeOffset() != (mgl32.Vec2{0, 0}) {
+		t.Errorf("Expected no offset after the shake, got %v", effects.GetShakeOffset())
+	}
+}
+
+func TestDecodeFadeColor(t *testing.T) {
+	tests := []struct {
+		colorMask uint8
+		expected  [3]float32
+	}{
+		{0, [3]float32{0, 0, 0}},
+		{FADE_COLOR_WHITE, [3]float32{1, 1, 1}},
+		{FADE_COLOR_RED, [3]float32{1, 0, 0}},
+		{FADE_COLOR_GREEN | FADE_COLOR_BLUE, [3]float32{0, 1, 1}},
+	}
+
+	for _, test := range tests {
+		if color := DecodeFadeColor(test.colorMask); color != test.expected {
+			t.Errorf("Color %d: expected %v, got %v", test.colorMask, test.expected, color)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package render
+
+import (
+	"github.com/OpenBiohazard2/OpenBiohazard2/geometry"
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Characters have a blob shadow on the floor under them
+// The shadow color is subtracted from the background like on the PS1
+
+const (
+	DEFAULT_SHADOW_HALF_SIZE = 400.0
+	// Lift the shadow above the floor so it isn't hidden by the floor depth
+	// Negative y is up
+	SHADOW_FLOOR_OFFSET = -4.0
+)
+
+var (
+	defaultShadowColor = [3]float32{0.3, 0.3, 0.3}
+)
+
+type ShadowSettings struct {
+	Color            [3]float32 // Amount subtracted from the background
+	HalfX, HalfZ     float32
+	OffsetX, OffsetZ float32
+}
+
+// Identifies the entity that casts the shadow
+type ShadowKey struct {
+	WorkComponent int
+	WorkIndex     int
+}
+
+type Shadow struct {
+	Position mgl32.Vec3 // Entity position on the floor
+	Settings ShadowSettings
+}
+
+type ShadowSystem struct {
+	Settings           map[ShadowKey]ShadowSettings // Set by the script
+	Shadows            []Shadow                     // Rebuilt every frame
+	VertexArrayObject  uint32
+	VertexBufferObject uint32
+}
+
+func NewDefaultShadowSettings() ShadowSettings {
+	return ShadowSettings{
+		Color: defaultShadowColor,
+		HalfX: DEFAULT_SHADOW_HALF_SIZE,
+		HalfZ: DEFAULT_SHADOW_HALF_SIZE,
+	}
+}
+
+func NewShadowSystem() *ShadowSystem {
+	var vao uint32
+	gl.GenVertexArrays(1, &vao)
+
+	var vbo uint32
+	gl.GenBuffers(1, &vbo)
+
+	return &ShadowSystem{
+		Settings:           make(map[ShadowKey]ShadowSettings),
+		Shadows:            make([]Shadow, 0),
+		VertexArrayObject:  vao,
+		VertexBufferObject: vbo,
+	}
+}
+
+func (shadowSystem *ShadowSystem) SetShadow(key ShadowKey, settings ShadowSettings) {
+	shadowSystem.Settings[key] = settings
+}
+
+func (shadowSystem *ShadowSystem) GetShadow(key ShadowKey) (ShadowSettings, bool) {
+	settings, exists := shadowSystem.Settings[key]
+	return settings, exists
+}
+
+// Shadows set by the previous room script don't carry over
+func (shadowSystem *ShadowSystem) Reset() {
+	shadowSystem.Settings = make(map[ShadowKey]ShadowSettings)
+	shadowSystem.Shadows = make([]Shadow, 0)
+}
+
+func (shadow Shadow) IsVisible() bool {
+	return shadow.Settings.HalfX > 0 && shadow.Settings.HalfZ > 0
+}
+
+// Flat quad on the floor, the fragment shader fades it out from the center
+func BuildShadowVertices(shadow Shadow) []float32 {
+	settings := shadow.Settings
+	centerX := shadow.Position.X() + settings.OffsetX
+	centerZ := shadow.Position.Z() + settings.OffsetZ
+	y := shadow.Position.Y() + SHADOW_FLOOR_OFFSET
+
+	vertices := [4][]float32{
+		{centerX - settings.HalfX, y, centerZ - settings.HalfZ},
+		{centerX + settings.HalfX, y, centerZ - settings.HalfZ},
+		{centerX + settings.HalfX, y, centerZ + settings.HalfZ},
+		{centerX - settings.HalfX, y, centerZ + settings.HalfZ},
+	}
+	uvs := [4][]float32{
+		{0.0, 0.0},
+		{1.0, 0.0},
+		{1.0, 1.0},
+		{0.0, 1.0},
+	}
+	return geometry.NewTexturedRectangle(vertices, uvs).VertexBuffer
+}
+
+// Shadows are depth tested against the background masks, so they are hidden behind foreground objects
+// They don't write depth because characters are drawn over them
+func (r *RenderDef) RenderShadows() {
+	shadowSystem := r.ShadowSystem
+	if len(shadowSystem.Shadows) == 0 {
+		return
+	}
+
+	gl.DepthMask(false)
+	gl.BlendEquation(gl.FUNC_REVERSE_SUBTRACT)
+	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE)
+
+	for _, shadow := range shadowSystem.Shadows {
+		if !shadow.IsVisible() {
+			continue
+		}
+		color := shadow.Settings.Color
+		r.ShaderSystem.SetDebugColor([4]float32{color[0], color[1], color[2], 1.0})
+
+		config := r.Renderer.Create2DEntityConfig(
+			shadowSystem.VertexArrayObject,
+			shadowSystem.VertexBufferObject,
+			BuildShadowVertices(shadow),
+			0, // No texture
+			nil,
+			RENDER_TYPE_SHADOW,
+		)
+		r.Renderer.RenderEntity(config)
+	}
+
+	// Restore default blending
+	gl.BlendEquation(gl.FUNC_ADD)
+	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+	gl.DepthMask(true)
+}
@@ -0,0 +1,34 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestBuildShadowVertices(t *testing.T) {
+	shadow := Shadow{
+		Position: mgl32.Vec3{1000, -1800, 2000},
+		Settings: ShadowSettings{HalfX: 100, HalfZ: 50, OffsetX: 10, OffsetZ: -20},
+	}
+
+	vertices := BuildShadowVertices(shadow)
+	if len(vertices) != 6*5 {
+		t.Fatalf("Expected 6 vertices with 5 floats, got %d floats", len(vertices))
+	}
+
+	// First vertex is the corner with the smallest x and z
+	if vertices[0] != 910 || vertices[1] != -1800+SHADOW_FLOOR_OFFSET || vertices[2] != 1930 {
+		t.Errorf("Expected first corner (910, %f, 1930), got (%f, %f, %f)",
+			-1800+SHADOW_FLOOR_OFFSET, vertices[0], vertices[1], vertices[2])
+	}
+}
+
+func TestShadowIsVisible(t *testing.T) {
+	if !(Shadow{Settings: NewDefaultShadowSettings()}).IsVisible() {
+		t.Error("Expected default shadow to be visible")
+	}
+	if (Shadow{Settings: ShadowSettings{HalfX: 0, HalfZ: 100}}).IsVisible() {
+		t.Error("Expected shadow without a size to be hidden")
+	}
+}
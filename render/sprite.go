@@ -9,18 +9,18 @@ import (
 
 const (
 	RENDER_TYPE_SPRITE = 4
-	SPRITE_FRAME_TIME  = 0.5 // in seconds
-)
 
-var (
-	totalSpriteRuntime = float64(0)
-	curSpriteFrame     = 0
+	// ESP timings and movement are in game frames
+	SPRITE_FRAMES_PER_SECOND = 30.0
+	// World units for each pixel of a sprite frame
+	SPRITE_PIXEL_SCALE = 32.0
 )
 
 type SpriteGroupEntity struct {
 	SpriteTextureIndexMap map[int]int
 	TextureIdPool         [][]uint32
-	VertexBuffer          []float32
+	SpriteData            []fileio.SpriteData
+	Instances             map[uint8]*EffectSprite // Key is the id set by the script
 	VertexArrayObject     uint32
 	VertexBufferObject    uint32
 }
@@ -32,11 +32,6 @@ func NewSpriteGroupEntity(spriteData []fileio.SpriteData) *SpriteGroupEntity {
 		spriteTextureIds = append(spriteTextureIds, spriteFrames)
 	}
 
-	spriteTextureIndexMap := make(map[int]int)
-	for i := 0; i < len(spriteData); i++ {
-		spriteTextureIndexMap[spriteData[i].Id] = i
-	}
-
 	var vao uint32
 	gl.GenVertexArrays(1, &vao)
 
@@ -44,29 +39,60 @@ func NewSpriteGroupEntity(spriteData []fileio.SpriteData) *SpriteGroupEntity {
 	gl.GenBuffers(1, &vbo)
 
 	return &SpriteGroupEntity{
-		SpriteTextureIndexMap: spriteTextureIndexMap,
+		SpriteTextureIndexMap: buildSpriteTextureIndexMap(spriteData),
 		TextureIdPool:         spriteTextureIds,
-		VertexBuffer:          make([]float32, 0),
+		SpriteData:            spriteData,
+		Instances:             make(map[uint8]*EffectSprite),
 		VertexArrayObject:     vao,
 		VertexBufferObject:    vbo,
 	}
 }
 
+// Frees the textures and buffers before the sprites of the next room are built
+func (spriteGroupEntity *SpriteGroupEntity) DeleteSpriteGroupEntity() {
+	for _, textureIds := range spriteGroupEntity.TextureIdPool {
+		for i := range textureIds {
+			// Frames without an image have texture id 0
+			if textureIds[i] != 0 {
+				gl.DeleteTextures(1, &textureIds[i])
+			}
+		}
+	}
+	if spriteGroupEntity.VertexArrayObject != 0 {
+		gl.DeleteVertexArrays(1, &spriteGroupEntity.VertexArrayObject)
+	}
+	if spriteGroupEntity.VertexBufferObject != 0 {
+		gl.DeleteBuffers(1, &spriteGroupEntity.VertexBufferObject)
+	}
+	spriteGroupEntity.TextureIdPool = nil
+	spriteGroupEntity.VertexArrayObject = 0
+	spriteGroupEntity.VertexBufferObject = 0
+}
+
+func buildSpriteTextureIndexMap(spriteData []fileio.SpriteData) map[int]int {
+	spriteTextureIndexMap := make(map[int]int)
+	for i := 0; i < len(spriteData); i++ {
+		spriteTextureIndexMap[spriteData[i].Id] = i
+	}
+	return spriteTextureIndexMap
+}
+
 // Each sprite id has its own texture
 // Build a texture for each frame
+// Frames without an image have texture id 0, so the frame index is also the texture index
 func BuildSpriteTexture(spriteData fileio.SpriteData) []uint32 {
 	allFrameTextures := make([]uint32, 0)
 
 	for _, frameData := range spriteData.FrameData {
-		spriteId := frameData.SpriteId
-		framePosition := spriteData.FramePositions[spriteId]
-
+		spriteId := int(frameData.SpriteId)
 		frameHeight := int(frameData.SquareSide)
 		frameWidth := int(frameData.SquareSide)
 
-		if frameHeight == 0 || frameWidth == 0 {
+		if spriteData.ImageData == nil || spriteId >= len(spriteData.FramePositions) || frameHeight == 0 || frameWidth == 0 {
+			allFrameTextures = append(allFrameTextures, 0)
 			continue
 		}
+		framePosition := spriteData.FramePositions[spriteId]
 
 		startX := int(framePosition.ImageX)
 		startY := int(framePosition.ImageY)
@@ -82,7 +108,7 @@ func BuildSpriteTexture(spriteData fileio.SpriteData) []uint32 {
 // buildTexturePixels extracts and processes pixel data for texture creation
 func buildTexturePixels(pixelData [][]uint16, startX, startY, width, height int) []uint16 {
 	texturePixels := make([]uint16, 0, width*height)
-	
+
 	for y := startY; y < startY+height; y++ {
 		for x := startX; x < startX+width; x++ {
 			curColor := pixelData[y][x]
@@ -97,53 +123,41 @@ func buildTexturePixels(pixelData [][]uint16, startX, startY, width, height int)
 			texturePixels = append(texturePixels, newTextureColor)
 		}
 	}
-	
-	return texturePixels
-}
-
-func (renderDef *RenderDef) AddSprite(sprite fileio.ScriptInstrSceEsprOn) {
-	spriteWidth := float32(1024 * 2)
-	spriteCenter := mgl32.Vec3{float32(sprite.X), float32(sprite.Y), float32(sprite.Z)}
-	viewMatrix := renderDef.ViewSystem.Camera.BuildViewMatrix()
 
-	// Generate billboard sprite using geometry package
-	rect := geometry.NewBillboardSprite(spriteCenter, spriteWidth, viewMatrix)
-	renderDef.SceneSystem.SpriteGroupEntity.VertexBuffer = append(renderDef.SceneSystem.SpriteGroupEntity.VertexBuffer, rect.VertexBuffer...)
+	return texturePixels
 }
 
 func RenderSprites(r *RenderDef, spriteGroupEntity *SpriteGroupEntity, timeElapsedSeconds float64) {
-	vertexBuffer := spriteGroupEntity.VertexBuffer
-	if len(vertexBuffer) == 0 {
-		return
-	}
-	textureIds := spriteGroupEntity.TextureIdPool
-	if len(textureIds) == 0 {
-		return
-	}
+	spriteGroupEntity.Update(timeElapsedSeconds)
 
-	// TODO: Calculate index based on id
-	spriteIndex := 0
+	viewMatrix := r.ViewSystem.Camera.BuildViewMatrix()
+	cameraRight := mgl32.Vec3{viewMatrix.At(0, 0), viewMatrix.At(1, 0), viewMatrix.At(2, 0)}
+	cameraUp := mgl32.Vec3{viewMatrix.At(0, 1), viewMatrix.At(1, 1), viewMatrix.At(2, 1)}
 
-	// Check when to move on to the next frame
-	totalSpriteRuntime += timeElapsedSeconds
-	if totalSpriteRuntime > SPRITE_FRAME_TIME {
-		totalSpriteRuntime = 0
-		curSpriteFrame++
-		if curSpriteFrame >= len(textureIds[spriteIndex]) {
-			curSpriteFrame = 0
+	for _, sprite := range spriteGroupEntity.Instances {
+		textureIds := spriteGroupEntity.TextureIdPool[sprite.SpriteIndex]
+		if sprite.FrameIndex >= len(textureIds) || textureIds[sprite.FrameIndex] == 0 {
+			continue
 		}
+		frame := spriteGroupEntity.SpriteData[sprite.SpriteIndex].FrameData[sprite.FrameIndex]
+
+		// The billboard starts at the bottom left corner, so move it to be centered on the sprite
+		spriteWidth := float32(frame.SquareSide) * SPRITE_PIXEL_SCALE
+		frameOffset := cameraRight.Mul(float32(frame.X) * SPRITE_PIXEL_SCALE).Sub(cameraUp.Mul(float32(frame.Y) * SPRITE_PIXEL_SCALE))
+		corner := sprite.GetPosition().Add(frameOffset).Sub(cameraRight.Add(cameraUp).Mul(spriteWidth / 2))
+		rect := geometry.NewBillboardSprite(corner, spriteWidth, viewMatrix)
+
+		// Create render config for 2D sprite (position + texture)
+		config := r.Renderer.Create2DEntityConfig(
+			spriteGroupEntity.VertexArrayObject,
+			spriteGroupEntity.VertexBufferObject,
+			rect.VertexBuffer,
+			textureIds[sprite.FrameIndex],
+			nil, // No model matrix for sprites
+			RENDER_TYPE_SPRITE,
+		)
+
+		// Render the sprite
+		r.Renderer.RenderEntity(config)
 	}
-
-	// Create render config for 2D sprite (position + texture)
-	config := r.Renderer.Create2DEntityConfig(
-		spriteGroupEntity.VertexArrayObject,
-		spriteGroupEntity.VertexBufferObject,
-		vertexBuffer,
-		textureIds[spriteIndex][curSpriteFrame],
-		nil, // No model matrix for sprites
-		RENDER_TYPE_SPRITE,
-	)
-
-	// Render the sprite
-	r.Renderer.RenderEntity(config)
 }
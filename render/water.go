@@ -0,0 +1,46 @@
+package render
+
+import (
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+)
+
+// Flooded rooms have a water surface that hides the characters below it
+// The part just under the surface stays visible through the water and is tinted
+
+const (
+	// Provisional: the scale of the level byte isn't known
+	// It is guessed that the whole range of the byte covers the height of one floor,
+	// measured up from y = 0 and not from the floor the characters stand on
+	WATER_LEVEL_UNIT = -fileio.FLOOR_HEIGHT_UNIT / 256.0
+
+	// How far under the surface the characters can be seen before they are clipped
+	WATER_VISIBLE_DEPTH = 200.0
+)
+
+var (
+	defaultWaterColor = [3]float32{0.15, 0.3, 0.35}
+)
+
+type WaterPlane struct {
+	Enabled      bool
+	Height       float32 // Y position of the surface in the room, negative y is up
+	VisibleDepth float32
+	Color        [3]float32
+}
+
+// Level 0 removes the water
+func NewWaterPlane(level int) WaterPlane {
+	if level == 0 {
+		return WaterPlane{}
+	}
+	return WaterPlane{
+		Enabled:      true,
+		Height:       -float32(level) * WATER_LEVEL_UNIT,
+		VisibleDepth: WATER_VISIBLE_DEPTH,
+		Color:        defaultWaterColor,
+	}
+}
+
+func (r *RenderDef) setWaterUniforms() {
+	r.ShaderSystem.SetWaterPlane(r.WaterPlane.Enabled, r.WaterPlane.Height, r.WaterPlane.VisibleDepth, r.WaterPlane.Color)
+}
@@ -0,0 +1,26 @@
+package render
+
+import (
+	"testing"
+)
+
+func TestNewWaterPlane(t *testing.T) {
+	// Half of the byte range is half a floor
+	waterPlane := NewWaterPlane(128)
+	if !waterPlane.Enabled {
+		t.Fatal("Expected water to be enabled")
+	}
+	if waterPlane.Height != -900 {
+		t.Errorf("Expected height -900, got %f", waterPlane.Height)
+	}
+	if waterPlane.VisibleDepth != WATER_VISIBLE_DEPTH {
+		t.Errorf("Expected visible depth %f, got %f", WATER_VISIBLE_DEPTH, waterPlane.VisibleDepth)
+	}
+	if waterPlane.Color != defaultWaterColor {
+		t.Errorf("Expected default water color, got %v", waterPlane.Color)
+	}
+
+	if NewWaterPlane(0).Enabled {
+		t.Error("Expected level 0 to remove the water")
+	}
+}
@@ -1,8 +1,12 @@
 package resource
 
 import (
+	"bytes"
+	"fmt"
 	"log"
+	"os"
 
+	"github.com/OpenBiohazard2/OpenBiohazard2/audio"
 	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
 )
 
@@ -30,3 +34,58 @@ func LoadADTImage(filename string) *Image16Bit {
 
 	return ConvertPixelsToImage16Bit(adtOutput.PixelData)
 }
+
+// LoadDoorModel loads the door model used by DOOR_MODEL_SET
+func LoadDoorModel(modelNumber int) (*fileio.DO2Output, error) {
+	filename := fmt.Sprintf(DOOR_FILE, modelNumber)
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return fileio.LoadDO2Stream(file, fileInfo.Size())
+}
+
+// LoadSoundBank loads a VAB sound bank stored as separate .vh and .vb files
+func LoadSoundBank(headerFilename string, dataFilename string) (*fileio.VABOutput, error) {
+	headerData, err := os.ReadFile(headerFilename)
+	if err != nil {
+		return nil, err
+	}
+	vabHeaderOutput, err := fileio.LoadVABHeaderStream(bytes.NewReader(headerData), int64(len(headerData)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sound bank header %s: %w", headerFilename, err)
+	}
+
+	data, err := os.ReadFile(dataFilename)
+	if err != nil {
+		return nil, err
+	}
+	vabDataOutput, err := fileio.LoadVABDataStream(bytes.NewReader(data), int64(len(data)), vabHeaderOutput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sound bank data %s: %w", dataFilename, err)
+	}
+
+	return &fileio.VABOutput{
+		VABHeaderOutput: vabHeaderOutput,
+		VABDataOutput:   vabDataOutput,
+	}, nil
+}
+
+// LoadMusicTrack decodes a BGM track from a SAP file
+func LoadMusicTrack(filename string) (*audio.Sound, error) {
+	sapOutput, err := fileio.LoadSAPFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	sound, err := audio.DecodeWAV(sapOutput.AudioData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode music %s: %w", filename, err)
+	}
+	return sound, nil
+}
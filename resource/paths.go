@@ -23,4 +23,12 @@ const (
 	ITEMALL_FILE        = COMMON_DATA_FOLDER + "itemall.bin"
 	SAVE_SCREEN_FILE    = COMMON_DATA_FOLDER + "type00.adt"
 	COMMON_SOUND_FOLDER = BASE_FOLDER + "Common/Sound/"
+	CORE_SOUND_HEADER   = COMMON_SOUND_FOLDER + "core/core00.vh"
+	CORE_SOUND_DATA     = COMMON_SOUND_FOLDER + "core/core00.vb"
+	BGM_FOLDER          = COMMON_SOUND_FOLDER + "BGM/"
+	BGM_MAIN_FILE       = BGM_FOLDER + "main%02x.sap"
+	BGM_SUB_FILE        = BGM_FOLDER + "sub_%02x.sap"
+	VOICE_FOLDER        = PL_FOLDER + "Voice/"
+	VOICE_FILE          = VOICE_FOLDER + "stage%d/v%03d.sap"
+	SUBTITLE_FILE       = VOICE_FOLDER + "stage%d/v%03d.srt"
 )
@@ -9,7 +9,6 @@ import (
 	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
 	"github.com/OpenBiohazard2/OpenBiohazard2/game"
 	"github.com/OpenBiohazard2/OpenBiohazard2/render"
-	"github.com/OpenBiohazard2/OpenBiohazard2/world"
 	"github.com/go-gl/mathgl/mgl32"
 )
 
@@ -34,6 +33,7 @@ type ScriptDef struct {
 	ScriptBitArray map[int]map[int]int
 	ScriptVariable map[int]int
 	DebugEnabled   bool
+	Coverage       *ScriptCoverage // nil unless coverage is enabled
 }
 
 func NewScriptDef() *ScriptDef {
@@ -47,6 +47,13 @@ func NewScriptDef() *ScriptDef {
 		ScriptBitArray: make(map[int]map[int]int),
 		ScriptVariable: make(map[int]int),
 		DebugEnabled:   false,
+		Coverage:       nil,
+	}
+}
+
+func (scriptDef *ScriptDef) EnableCoverage() {
+	if scriptDef.Coverage == nil {
+		scriptDef.Coverage = NewScriptCoverage()
 	}
 }
 
@@ -71,6 +78,8 @@ func (scriptDef *ScriptDef) RunScript(
 	timeElapsedSeconds float64,
 	gameDef *game.GameDef,
 	renderDef *render.RenderDef) {
+	scriptDef.updateScreenEffectBits(renderDef)
+	scriptDef.updateVoiceBits(gameDef)
 	for i := 0; i < len(scriptDef.ScriptThreads); i++ {
 		// Regulate frames per second
 		scriptDeltaTime += timeElapsedSeconds
@@ -125,6 +134,10 @@ func (scriptDef *ScriptDef) RunScriptUntilBreakControlFlow(
 
 		opcode := lineData[0]
 
+		if scriptDef.Coverage != nil {
+			scriptDef.Coverage.RecordInstruction(scriptData, curScriptThread.ProgramCounter)
+		}
+
 		// Override can be modified during execution
 		curScriptThread.OverrideProgramCounter = false
 
@@ -204,49 +217,128 @@ func (scriptDef *ScriptDef) ExecuteSingleInstruction(
 		returnValue = scriptDef.ScriptCalc(lineData)
 	case fileio.OP_CALC2: // 0x27
 		returnValue = scriptDef.ScriptCalc(lineData)
+	case fileio.OP_SCE_RND: // 0x28
+		returnValue = scriptDef.ScriptSceRnd(gameDef)
 	case fileio.OP_CUT_CHG:
 		returnValue = scriptDef.ScriptCameraChange(lineData, gameDef)
+	case fileio.OP_CUT_OLD: // 0x2a
+		returnValue = scriptDef.ScriptCutOld(gameDef)
 	case fileio.OP_AOT_SET:
 		returnValue = scriptDef.ScriptAotSet(lineData, gameDef)
 	case fileio.OP_OBJ_MODEL_SET:
 		returnValue = scriptDef.ScriptObjectModelSet(lineData, renderDef)
+	case fileio.OP_MESSAGE_ON: // 0x2b
+		returnValue = scriptDef.ScriptMessageOn(curScriptThread, lineData, gameDef)
 	case fileio.OP_WORK_SET:
 		returnValue = scriptDef.ScriptWorkSet(curScriptThread, lineData)
 	case fileio.OP_POS_SET:
-		returnValue = scriptDef.ScriptPositionSet(curScriptThread, lineData, gameDef)
+		returnValue = scriptDef.ScriptPositionSet(curScriptThread, lineData, gameDef, renderDef)
+	case fileio.OP_DIR_SET:
+		returnValue = scriptDef.ScriptDirectionSet(curScriptThread, lineData, gameDef, renderDef)
+	case fileio.OP_SPEED_SET:
+		returnValue = scriptDef.ScriptSpeedSet(curScriptThread, lineData, gameDef, renderDef)
+	case fileio.OP_ADD_SPEED:
+		returnValue = scriptDef.ScriptAddSpeed(curScriptThread, gameDef, renderDef)
+	case fileio.OP_ADD_ASPEED:
+		returnValue = scriptDef.ScriptAddAngularSpeed(curScriptThread, gameDef, renderDef)
 	case fileio.OP_MEMBER_SET:
 		returnValue = scriptDef.ScriptMemberSet(curScriptThread, lineData, gameDef, renderDef)
+	case fileio.OP_MEMBER_SET2:
+		returnValue = scriptDef.ScriptMemberSet2(curScriptThread, lineData, gameDef, renderDef)
+	case fileio.OP_SE_ON: // 0x36
+		returnValue = scriptDef.ScriptSeOn(lineData, gameDef)
 	case fileio.OP_SCA_ID_SET:
 		returnValue = scriptDef.ScriptScaIdSet(lineData, gameDef)
 	case fileio.OP_SCE_ESPR_ON:
 		returnValue = scriptDef.ScriptSceEsprOn(lineData, gameDef, renderDef)
 	case fileio.OP_DOOR_AOT_SET:
 		returnValue = scriptDef.ScriptDoorAotSet(lineData, gameDef)
+	case fileio.OP_MEMBER_COPY:
+		returnValue = scriptDef.ScriptMemberCopy(curScriptThread, lineData, gameDef, renderDef)
 	case fileio.OP_MEMBER_CMP:
-		returnValue = scriptDef.ScriptMemberCompare(lineData)
+		returnValue = scriptDef.ScriptMemberCompare(curScriptThread, lineData, gameDef, renderDef)
+	case fileio.OP_CUT_AUTO: // 0x3c
+		returnValue = scriptDef.ScriptCutAuto(lineData, gameDef)
 	case fileio.OP_PLC_MOTION: // 0x3f
-		returnValue = scriptDef.ScriptPlcMotion(lineData)
+		returnValue = scriptDef.ScriptPlcMotion(lineData, gameDef)
 	case fileio.OP_PLC_DEST: // 0x40
-		returnValue = scriptDef.ScriptPlcDest(lineData)
+		returnValue = scriptDef.ScriptPlcDest(curScriptThread, lineData, gameDef)
 	case fileio.OP_PLC_NECK: // 0x41
-		returnValue = scriptDef.ScriptPlcNeck(lineData)
+		returnValue = scriptDef.ScriptPlcNeck(lineData, gameDef)
+	case fileio.OP_PLC_RET: // 0x42
+		returnValue = scriptDef.ScriptPlcRet(gameDef)
+	case fileio.OP_PLC_FLAG: // 0x43
+		returnValue = scriptDef.ScriptPlcFlag(lineData, gameDef)
+	case fileio.OP_PLC_ROT: // 0x58
+		returnValue = scriptDef.ScriptPlcRot(lineData, gameDef)
+	case fileio.OP_PLC_STOP: // 0x66
+		returnValue = scriptDef.ScriptPlcStop(gameDef)
 	case fileio.OP_SCE_EM_SET: // 0x44
 		returnValue = scriptDef.ScriptSceEmSet(lineData, renderDef)
 	case fileio.OP_AOT_RESET: // 0x46
 		returnValue = scriptDef.ScriptAotReset(lineData, gameDef)
+	case fileio.OP_AOT_ON: // 0x47
+		returnValue = scriptDef.ScriptAotOn(lineData, gameDef)
+	case fileio.OP_SUPER_SET: // 0x48
+		returnValue = scriptDef.ScriptSuperSet(lineData, gameDef)
 	case fileio.OP_SCE_ESPR_KILL: // 0x4c
-		returnValue = scriptDef.ScriptSceEsprKill(lineData)
+		returnValue = scriptDef.ScriptSceEsprKill(lineData, renderDef)
+	case fileio.OP_CUT_REPLACE: // 0x4b
+		returnValue = scriptDef.ScriptCutReplace(lineData, gameDef)
+	case fileio.OP_DOOR_MODEL_SET: // 0x4d
+		returnValue = scriptDef.ScriptDoorModelSet(lineData, gameDef, renderDef)
 	case fileio.OP_ITEM_AOT_SET: // 0x4e
 		returnValue = scriptDef.ScriptItemAotSet(lineData, gameDef)
+	case fileio.OP_SCE_TRG_CK: // 0x50
+		returnValue = scriptDef.ScriptSceTrgCk(lineData, gameDef)
 	case fileio.OP_SCE_BGM_CONTROL: // 0x51
-		returnValue = scriptDef.ScriptSceBgmControl(lineData)
+		returnValue = scriptDef.ScriptSceBgmControl(lineData, gameDef)
+	case fileio.OP_SCE_ESPR_CONTROL: // 0x52
+		returnValue = scriptDef.ScriptSceEsprControl(lineData, renderDef)
+	case fileio.OP_SCE_FADE_SET: // 0x53
+		returnValue = scriptDef.ScriptSceFadeSet(lineData, renderDef)
+	case fileio.OP_SCE_ESPR3D_ON: // 0x54
+		returnValue = scriptDef.ScriptSceEspr3dOn(lineData, renderDef)
+	case fileio.OP_SCE_BGMTBL_SET: // 0x57
+		returnValue = scriptDef.ScriptSceBgmTblSet(lineData, gameDef)
+	case fileio.OP_XA_ON: // 0x59
+		returnValue = scriptDef.ScriptXaOn(lineData, gameDef)
+	case fileio.OP_WEAPON_CHG: // 0x5a
+		returnValue = scriptDef.ScriptWeaponChg(lineData, gameDef)
+	case fileio.OP_SCE_SHAKE_ON: // 0x5c
+		returnValue = scriptDef.ScriptSceShakeOn(lineData, renderDef)
+	case fileio.OP_MIZU_DIV_SET: // 0x5d
+		returnValue = scriptDef.ScriptMizuDivSet(lineData, renderDef)
+	case fileio.OP_KEEP_ITEM_CK: // 0x5e
+		returnValue = scriptDef.ScriptKeepItemCk(lineData, gameDef)
+	case fileio.OP_XA_VOL: // 0x5f
+		returnValue = scriptDef.ScriptXaVol(lineData, gameDef)
+	case fileio.OP_KAGE_SET: // 0x60
+		returnValue = scriptDef.ScriptKageSet(lineData, renderDef)
+	case fileio.OP_CUT_BE_SET: // 0x61
+		returnValue = scriptDef.ScriptCutBeSet(lineData, gameDef)
+	case fileio.OP_SCE_ITEM_LOST: // 0x62
+		returnValue = scriptDef.ScriptSceItemLost(lineData, gameDef)
+	case fileio.OP_SCE_ESPR_ON2: // 0x64
+		returnValue = scriptDef.ScriptSceEsprOn(lineData, gameDef, renderDef)
+	case fileio.OP_SCE_ESPR_KILL2: // 0x65
+		returnValue = scriptDef.ScriptSceEsprKill2(lineData, renderDef)
 	case fileio.OP_AOT_SET_4P:
 		returnValue = scriptDef.ScriptAotSet4p(lineData, gameDef)
 	case fileio.OP_DOOR_AOT_SET_4P:
 		returnValue = scriptDef.ScriptDoorAotSet4p(lineData, gameDef)
 	case fileio.OP_ITEM_AOT_SET_4P:
 		returnValue = scriptDef.ScriptItemAotSet4p(lineData, gameDef)
+	case fileio.OP_LIGHT_POS_SET: // 0x6a
+		returnValue = scriptDef.ScriptLightPosSet(lineData, renderDef)
+	case fileio.OP_LIGHT_KIDO_SET: // 0x6b
+		returnValue = scriptDef.ScriptLightKidoSet(lineData, renderDef)
+	case fileio.OP_SCE_ITEM_GET: // 0x76
+		returnValue = scriptDef.ScriptSceItemGet(lineData, gameDef)
 	default:
+		if scriptDef.Coverage != nil {
+			scriptDef.Coverage.RecordUnimplemented(opcode)
+		}
 		returnValue = 1
 	}
 
@@ -316,41 +408,18 @@ func (scriptDef *ScriptDef) ScriptWorkSet(thread *ScriptThread, lineData []byte)
 	return 1
 }
 
-func (scriptDef *ScriptDef) ScriptPositionSet(thread *ScriptThread, lineData []byte, gameDef *game.GameDef) int {
+func (scriptDef *ScriptDef) ScriptPositionSet(thread *ScriptThread, lineData []byte,
+	gameDef *game.GameDef, renderDef *render.RenderDef) int {
 	byteArr := bytes.NewBuffer(lineData)
 	instruction := fileio.ScriptInstrPosSet{}
 	binary.Read(byteArr, binary.LittleEndian, &instruction)
 
-	if thread.WorkSetComponent == WORKSET_PLAYER {
-		gameDef.Player.Position = mgl32.Vec3{float32(instruction.X), float32(instruction.Y), float32(instruction.Z)}
-	} else {
-		// TODO: set position of object
+	entity := GetWorkSetEntity(thread, gameDef, renderDef)
+	if entity == nil {
+		return 1
 	}
 
-	return 1
-}
-
-func (scriptDef *ScriptDef) ScriptMemberSet(thread *ScriptThread, lineData []byte, gameDef *game.GameDef, renderDef *render.RenderDef) int {
-	byteArr := bytes.NewBuffer(lineData)
-	instruction := fileio.ScriptInstrMemberSet{}
-	binary.Read(byteArr, binary.LittleEndian, &instruction)
-
-	if thread.WorkSetComponent == WORKSET_PLAYER {
-		switch int(instruction.MemberIndex) {
-		case 15:
-			// convert to angle in degrees
-			gameDef.Player.RotationAngle = (float32(instruction.Value) / 4096.0) * 360.0
-		}
-	} else if thread.WorkSetComponent == WORKSET_OBJECT {
-		modelObject := renderDef.SceneSystem.ItemGroupEntity.ModelObjectData[int(thread.WorkSetIndex)]
-		switch int(instruction.MemberIndex) {
-		case 15:
-			// convert to angle in degrees
-			modelObject.RotationAngle = (float32(instruction.Value) / 4096.0) * 360.0
-		}
-	} else {
-		// TODO: set attribute of object
-	}
+	entity.SetPosition(mgl32.Vec3{float32(instruction.X), float32(instruction.Y), float32(instruction.Z)})
 	return 1
 }
 
@@ -360,19 +429,11 @@ func (scriptDef *ScriptDef) ScriptScaIdSet(lineData []byte, gameDef *game.GameDe
 	binary.Read(byteArr, binary.LittleEndian, &instruction)
 
 	if instruction.Flag == 0 {
-		world.RemoveCollisionEntity(gameDef.GameWorld.GameRoom.CollisionEntities, int(instruction.Id))
+		gameDef.GameWorld.GameRoom.RemoveCollisionEntity(int(instruction.Id))
 	}
 	return 1
 }
 
-func (scriptDef *ScriptDef) ScriptMemberCompare(lineData []byte) int {
-	byteArr := bytes.NewBuffer(lineData)
-	instruction := fileio.ScriptInstrMemberCompare{}
-	binary.Read(byteArr, binary.LittleEndian, &instruction)
-
-	return 1
-}
-
 func (scriptDef *ScriptDef) ScriptSceEmSet(lineData []byte, renderDef *render.RenderDef) int {
 	byteArr := bytes.NewBuffer(lineData)
 	instruction := fileio.ScriptInstrSceEmSet{}
@@ -382,19 +443,19 @@ func (scriptDef *ScriptDef) ScriptSceEmSet(lineData []byte, renderDef *render.Re
 	if instruction.Type != 0 && instruction.ModelType != 0 {
 		// Load the EMD file based on the enemy type (3-digit hexadecimal)
 		enemyEMDPath := fmt.Sprintf("data/PL0/EMD0/EM%03X.EMD", instruction.Type)
-		
+
 		// Load the enemy model data
 		emdOutput := fileio.LoadEMDFile(enemyEMDPath)
 		if emdOutput != nil {
 			// Create enemy entity
 			enemyEntity := render.NewEnemyEntity(emdOutput)
 			enemyEntity.SetEnemyData(instruction)
-			
+
 			// Add to scene system
 			renderDef.SceneSystem.EnemyGroupEntity.AddEnemy(enemyEntity)
-			
+
 			// Log enemy creation since there won't be too many enemies
-			fmt.Printf("Created enemy type 0x%03X at position (%d, %d, %d)\n", 
+			fmt.Printf("Created enemy type 0x%03X at position (%d, %d, %d)\n",
 				instruction.Type, instruction.X, instruction.Y, instruction.Z)
 		} else {
 			// Only log failures for debugging purposes
@@ -404,11 +465,3 @@ func (scriptDef *ScriptDef) ScriptSceEmSet(lineData []byte, renderDef *render.Re
 
 	return 1
 }
-
-func (scriptDef *ScriptDef) ScriptSceBgmControl(lineData []byte) int {
-	byteArr := bytes.NewBuffer(lineData)
-	instruction := fileio.ScriptInstrSceBgmControl{}
-	binary.Read(byteArr, binary.LittleEndian, &instruction)
-
-	return 1
-}
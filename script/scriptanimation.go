@@ -3,38 +3,103 @@ package script
 import (
 	"bytes"
 	"encoding/binary"
+	"log"
 
 	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+	"github.com/go-gl/mathgl/mgl32"
 )
 
 // PLC commands are used for 3D model animation
+// They take control of the player away from the user until PLC_RET
 
-func (scriptDef *ScriptDef) ScriptPlcMotion(lineData []byte) int {
+const (
+	PLC_FLAG_OR  = 0
+	PLC_FLAG_SET = 1
+	PLC_FLAG_XOR = 2
+
+	PLC_ROT_ABSOLUTE = 0
+	PLC_ROT_RELATIVE = 1
+)
+
+func (scriptDef *ScriptDef) ScriptPlcMotion(lineData []byte, gameDef *game.GameDef) int {
 	byteArr := bytes.NewBuffer(lineData)
 	instruction := fileio.ScriptInstrPlcMotion{}
 	binary.Read(byteArr, binary.LittleEndian, &instruction)
 
-	// TODO: implement
-
+	gameDef.Player.SetScriptMotion(int(instruction.MoveNumber))
 	return 1
 }
 
-func (scriptDef *ScriptDef) ScriptPlcDest(lineData []byte) int {
+// The player walks with the motion in the instruction and the thread waits here until the player arrives
+// The speed is always the walking speed
+func (scriptDef *ScriptDef) ScriptPlcDest(thread *ScriptThread, lineData []byte, gameDef *game.GameDef) int {
 	byteArr := bytes.NewBuffer(lineData)
 	instruction := fileio.ScriptInstrPlcDest{}
 	binary.Read(byteArr, binary.LittleEndian, &instruction)
 
-	// TODO: implement
+	if !thread.WaitingForPlayer {
+		gameDef.Player.SetScriptDestination(float32(instruction.DestX), float32(instruction.DestZ), int(instruction.Action))
+		thread.WaitingForPlayer = true
+	}
+
+	if !gameDef.Player.HasReachedDestination() {
+		thread.OverrideProgramCounter = true
+		return INSTRUCTION_THREAD_END
+	}
 
+	thread.WaitingForPlayer = false
 	return 1
 }
 
-func (scriptDef *ScriptDef) ScriptPlcNeck(lineData []byte) int {
+func (scriptDef *ScriptDef) ScriptPlcNeck(lineData []byte, gameDef *game.GameDef) int {
 	byteArr := bytes.NewBuffer(lineData)
 	instruction := fileio.ScriptInstrPlcNeck{}
 	binary.Read(byteArr, binary.LittleEndian, &instruction)
 
-	// TODO: implement
+	gameDef.Player.SetNeckTarget(mgl32.Vec3{float32(instruction.NeckX), float32(instruction.NeckY), float32(instruction.NeckZ)})
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptPlcRet(gameDef *game.GameDef) int {
+	gameDef.Player.EndScriptControl()
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptPlcFlag(lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrPlcFlag{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	work := gameDef.Player.GetScriptWork()
+	switch instruction.Operation {
+	case PLC_FLAG_OR:
+		work.Status |= int(instruction.Flag)
+	case PLC_FLAG_SET:
+		work.Status = int(instruction.Flag)
+	case PLC_FLAG_XOR:
+		work.Status ^= int(instruction.Flag)
+	default:
+		log.Printf("SCRIPT: Unknown PLC_FLAG operation %d", instruction.Operation)
+	}
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptPlcRot(lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrPlcRot{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	player := gameDef.Player
+	rotationAngle := game.ScriptAngleToDegrees(int(instruction.Value))
+	if instruction.Index == PLC_ROT_RELATIVE {
+		rotationAngle += player.RotationAngle
+	}
+	player.SetScriptRotation(rotationAngle)
+	return 1
+}
 
+func (scriptDef *ScriptDef) ScriptPlcStop(gameDef *game.GameDef) int {
+	gameDef.Player.StopScriptMovement()
 	return 1
 }
@@ -0,0 +1,105 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestScriptPlcDestWaitsForArrival(t *testing.T) {
+	scriptDef := NewScriptDef()
+	thread := scriptDef.ScriptThreads[0]
+	gameDef := &game.GameDef{Player: game.NewPlayer(mgl32.Vec3{0, 0, 0}, 0)}
+
+	// Walk to (1000, 0)
+	lineData := []byte{fileio.OP_PLC_DEST, 0, 0, 0, 0xe8, 0x03, 0, 0}
+	returnValue := scriptDef.ScriptPlcDest(thread, lineData, gameDef)
+	if returnValue != INSTRUCTION_THREAD_END || !thread.OverrideProgramCounter {
+		t.Fatalf("Expected thread to wait for the player, got return value %d", returnValue)
+	}
+	if !gameDef.Player.IsInputLocked() {
+		t.Error("Expected player input to be locked")
+	}
+
+	// Walking speed covers the distance in a quarter of a second
+	gameDef.Player.UpdateScriptControl(0.2)
+	thread.OverrideProgramCounter = false
+	if scriptDef.ScriptPlcDest(thread, lineData, gameDef) != INSTRUCTION_THREAD_END {
+		t.Error("Expected thread to keep waiting before the player arrives")
+	}
+
+	gameDef.Player.UpdateScriptControl(0.2)
+	thread.OverrideProgramCounter = false
+	if scriptDef.ScriptPlcDest(thread, lineData, gameDef) != INSTRUCTION_NORMAL {
+		t.Error("Expected thread to continue after the player arrives")
+	}
+	if gameDef.Player.Position != (mgl32.Vec3{1000, 0, 0}) {
+		t.Errorf("Expected player at (1000, 0, 0), got %v", gameDef.Player.Position)
+	}
+	if thread.WaitingForPlayer {
+		t.Error("Expected thread to stop waiting")
+	}
+}
+
+func TestScriptPlcDestMotion(t *testing.T) {
+	scriptDef := NewScriptDef()
+	thread := scriptDef.ScriptThreads[0]
+	gameDef := &game.GameDef{Player: game.NewPlayer(mgl32.Vec3{0, 0, 0}, 0)}
+
+	// Walk to (1000, 0) with motion 7
+	lineData := []byte{fileio.OP_PLC_DEST, 0, 7, 0, 0xe8, 0x03, 0, 0}
+	scriptDef.ScriptPlcDest(thread, lineData, gameDef)
+	gameDef.Player.UpdateScriptControl(0.1)
+	if gameDef.Player.PoseNumber != 7 {
+		t.Errorf("Expected motion 7 while walking, got %d", gameDef.Player.PoseNumber)
+	}
+}
+
+func TestScriptPlcRetUnlocksInput(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := &game.GameDef{Player: game.NewPlayer(mgl32.Vec3{0, 0, 0}, 0)}
+
+	scriptDef.ScriptPlcMotion([]byte{fileio.OP_PLC_MOTION, 0, 5, 0}, gameDef)
+	if gameDef.Player.PoseNumber != 5 || !gameDef.Player.IsInputLocked() {
+		t.Fatalf("Expected pose 5 with input locked, got pose %d", gameDef.Player.PoseNumber)
+	}
+
+	scriptDef.ScriptPlcRet(gameDef)
+	if gameDef.Player.IsInputLocked() {
+		t.Error("Expected player input to be unlocked")
+	}
+	if gameDef.Player.PoseNumber != game.PLAYER_IDLE_POSE {
+		t.Errorf("Expected idle pose, got %d", gameDef.Player.PoseNumber)
+	}
+}
+
+func TestScriptPlcRotRelative(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := &game.GameDef{Player: game.NewPlayer(mgl32.Vec3{0, 0, 0}, 300)}
+
+	// Turn a quarter turn from the current direction
+	scriptDef.ScriptPlcRot([]byte{fileio.OP_PLC_ROT, PLC_ROT_RELATIVE, 0x00, 0x04}, gameDef)
+	gameDef.Player.UpdateScriptControl(1.0)
+
+	if gameDef.Player.RotationAngle != 30 {
+		t.Errorf("Expected rotation angle 30, got %f", gameDef.Player.RotationAngle)
+	}
+	if gameDef.Player.ScriptControl.IsRotating {
+		t.Error("Expected rotation to be finished")
+	}
+}
+
+func TestScriptPlcFlag(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := &game.GameDef{Player: game.NewPlayer(mgl32.Vec3{0, 0, 0}, 0)}
+
+	scriptDef.ScriptPlcFlag([]byte{fileio.OP_PLC_FLAG, PLC_FLAG_SET, 0x05, 0}, gameDef)
+	scriptDef.ScriptPlcFlag([]byte{fileio.OP_PLC_FLAG, PLC_FLAG_OR, 0x08, 0}, gameDef)
+	scriptDef.ScriptPlcFlag([]byte{fileio.OP_PLC_FLAG, PLC_FLAG_XOR, 0x01, 0}, gameDef)
+
+	if gameDef.Player.ScriptWork.Status != 0x0c {
+		t.Errorf("Expected player status 0x0c, got 0x%x", gameDef.Player.ScriptWork.Status)
+	}
+}
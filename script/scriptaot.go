@@ -7,7 +7,9 @@ import (
 
 	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
 	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+	"github.com/OpenBiohazard2/OpenBiohazard2/render"
 	"github.com/OpenBiohazard2/OpenBiohazard2/world"
+	"github.com/go-gl/mathgl/mgl32"
 )
 
 func (scriptDef *ScriptDef) ScriptAotSet(lineData []byte, gameDef *game.GameDef) int {
@@ -95,3 +97,80 @@ func (scriptDef *ScriptDef) ScriptItemAotSet4p(lineData []byte, gameDef *game.Ga
 	gameDef.GameWorld.AotManager.AddItemAot4p(item)
 	return 1
 }
+
+// Fires the aot as if the player triggered it
+func (scriptDef *ScriptDef) ScriptAotOn(lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrAotOn{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	aotManager := gameDef.GameWorld.AotManager
+	if aot := aotManager.GetAotTrigger(instruction.Aot); aot != nil {
+		scriptDef.ExecuteAotEvent(aot, gameDef)
+		return 1
+	}
+	if door := aotManager.GetDoor(instruction.Aot); door != nil {
+		gameDef.EnterDoor(door)
+		return 1
+	}
+
+	log.Printf("SCRIPT: AOT[%d] doesn't exist", instruction.Aot)
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptSuperSet(lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrSuperSet{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	gameDef.GameWorld.AotManager.SetSuper(instruction.Super, world.AotSuper{
+		WorkComponent: int(instruction.WorkComponent),
+		WorkIndex:     int(instruction.WorkIndex),
+		Offset:        mgl32.Vec3{float32(instruction.Offset[0]), float32(instruction.Offset[1]), float32(instruction.Offset[2])},
+	})
+	return 1
+}
+
+// Condition is true if the aot is enabled or disabled as expected
+func (scriptDef *ScriptDef) ScriptSceTrgCk(lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrSceTrgCk{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	isEnabled := gameDef.GameWorld.AotManager.IsAotEnabled(instruction.Aot)
+	if isEnabled == (instruction.On != 0) {
+		return 1
+	}
+	return INSTRUCTION_BREAK_FLOW
+}
+
+// Runs the event attached to an event aot or shows the text of a message aot
+func (scriptDef *ScriptDef) ExecuteAotEvent(aot *world.AotObject, gameDef *game.GameDef) {
+	switch aot.Header.Id {
+	case world.AOT_EVENT:
+		threadNum := aot.Data[0]
+		eventNum := aot.Data[3]
+		lineData := []byte{fileio.OP_EVT_EXEC, threadNum, 0, eventNum}
+		scriptDef.ScriptEvtExec(lineData, gameDef.RoomScript.RoomScriptData)
+	case world.AOT_MESSAGE:
+		messages := gameDef.RoomScript.Messages
+		messageId := int(aot.Data[world.AOT_MESSAGE_DATA_ID])
+		if messageId >= len(messages) {
+			log.Printf("AOT[%d] message %d doesn't exist in room with %d messages", aot.Header.Aot, messageId, len(messages))
+			return
+		}
+		gameDef.MessageBox.Open(messages[messageId])
+	}
+}
+
+// Moves aots with their super entity
+func UpdateAotSupers(gameDef *game.GameDef, renderDef *render.RenderDef) {
+	aotManager := gameDef.GameWorld.AotManager
+	for super, aotSuper := range aotManager.Supers {
+		entity := GetScriptEntity(aotSuper.WorkComponent, aotSuper.WorkIndex, gameDef, renderDef)
+		if entity == nil {
+			continue
+		}
+		aotManager.SetSuperPosition(super, entity.GetPosition().Add(aotSuper.Offset))
+	}
+}
@@ -0,0 +1,79 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+	"github.com/OpenBiohazard2/OpenBiohazard2/world"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestScriptSceTrgCk(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := game.NewGame(1, 0, 0)
+	gameDef.GameWorld.AotManager.SetAotEnabled(3, false)
+
+	if scriptDef.ScriptSceTrgCk([]byte{fileio.OP_SCE_TRG_CK, 3, 0, 1}, gameDef) != INSTRUCTION_BREAK_FLOW {
+		t.Error("Expected check for enabled aot to fail")
+	}
+	if scriptDef.ScriptSceTrgCk([]byte{fileio.OP_SCE_TRG_CK, 3, 0, 0}, gameDef) != INSTRUCTION_NORMAL {
+		t.Error("Expected check for disabled aot to pass")
+	}
+}
+
+func TestScriptSuperSet(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := game.NewGame(1, 0, 0)
+	gameDef.Player = game.NewPlayer(mgl32.Vec3{100, 0, 200}, 0)
+
+	// Attach super 1 to the player with an x offset of 16
+	scriptDef.ScriptSuperSet([]byte{fileio.OP_SUPER_SET, 1, WORKSET_PLAYER, 0, 16, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, gameDef)
+	UpdateAotSupers(gameDef, nil)
+
+	expected := mgl32.Vec3{116, 0, 200}
+	if position := gameDef.GameWorld.AotManager.SuperPositions[1]; position != expected {
+		t.Errorf("Expected super position %v, got %v", expected, position)
+	}
+}
+
+func TestScriptAotOnDoor(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := game.NewGame(1, 0, 0)
+	gameDef.Player = game.NewPlayer(mgl32.Vec3{}, 0)
+	gameDef.StateStatus = game.GAME_LOOP
+	gameDef.GameWorld.AotManager.Doors = append(gameDef.GameWorld.AotManager.Doors, world.AotDoor{
+		Header: world.AotHeader{Aot: 4, Id: world.AOT_DOOR},
+		Stage:  1,
+		Room:   2,
+	})
+
+	scriptDef.ScriptAotOn([]byte{fileio.OP_AOT_ON, 4}, gameDef)
+	if gameDef.StateStatus != game.GAME_LOAD_ROOM || gameDef.StageId != 2 || gameDef.RoomId != 2 {
+		t.Errorf("Expected to load stage 2 room 2, got stage %d room %d", gameDef.StageId, gameDef.RoomId)
+	}
+}
+
+func TestExecuteAotEventMessage(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := game.NewGame(1, 0, 0)
+	gameDef.RoomScript.Messages = []fileio.MSGMessage{
+		{Pages: []string{"A typewriter"}},
+		{Pages: []string{"It's locked."}},
+	}
+
+	aot := &world.AotObject{Header: world.AotHeader{Aot: 2, Id: world.AOT_MESSAGE}}
+	aot.Data[world.AOT_MESSAGE_DATA_ID] = 1
+	scriptDef.ExecuteAotEvent(aot, gameDef)
+	if !gameDef.MessageBox.IsOpen || gameDef.MessageBox.GetCurrentPage() != "It's locked." {
+		t.Errorf("Expected message 1 to be open, got %q", gameDef.MessageBox.GetCurrentPage())
+	}
+
+	// Missing messages are ignored
+	gameDef.MessageBox.Close()
+	aot.Data[world.AOT_MESSAGE_DATA_ID] = 5
+	scriptDef.ExecuteAotEvent(aot, gameDef)
+	if gameDef.MessageBox.IsOpen {
+		t.Error("Expected message box to stay closed")
+	}
+}
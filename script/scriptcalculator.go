@@ -6,6 +6,14 @@ import (
 	"log"
 
 	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+)
+
+const (
+	// Script variable that SCE_RND writes to
+	SCRIPT_VARIABLE_RANDOM = 16
+	// Script variable that gets the answer to a yes/no message
+	SCRIPT_VARIABLE_MESSAGE_CHOICE = 17
 )
 
 func (scriptDef *ScriptDef) GetBitArray(bitArrayIndex int, bitNumber int) int {
@@ -81,57 +89,37 @@ func (scriptDef *ScriptDef) ScriptCompare(lineData []byte) int {
 	variableValue := scriptDef.GetScriptVariable(int(instruction.VarId))
 	otherValue := int(instruction.Value)
 
-	switch int(instruction.Operation) {
+	if compareScriptValues(int(instruction.Operation), variableValue, otherValue) {
+		return 1
+	}
+	return INSTRUCTION_BREAK_FLOW
+}
+
+// Comparison operations shared by COMPARE and MEMBER_CMP
+func compareScriptValues(operation int, leftValue int, rightValue int) bool {
+	switch operation {
 	case 0:
-		if variableValue == otherValue {
-			return 1
-		} else {
-			return INSTRUCTION_BREAK_FLOW
-		}
+		return leftValue == rightValue
 	case 1:
 		// greater than
-		if variableValue > otherValue {
-			return 1
-		} else {
-			return INSTRUCTION_BREAK_FLOW
-		}
+		return leftValue > rightValue
 	case 2:
 		// greater than or equals to
-		if variableValue >= otherValue {
-			return 1
-		} else {
-			return INSTRUCTION_BREAK_FLOW
-		}
+		return leftValue >= rightValue
 	case 3:
 		// less than
-		if variableValue < otherValue {
-			return 1
-		} else {
-			return INSTRUCTION_BREAK_FLOW
-		}
+		return leftValue < rightValue
 	case 4:
 		// less than or equals to
-		if variableValue <= otherValue {
-			return 1
-		} else {
-			return INSTRUCTION_BREAK_FLOW
-		}
+		return leftValue <= rightValue
 	case 5:
 		// not equals
-		if variableValue != otherValue {
-			return 1
-		} else {
-			return INSTRUCTION_BREAK_FLOW
-		}
+		return leftValue != rightValue
 	case 6:
-		if variableValue&otherValue != 0 {
-			return 1
-		} else {
-			return INSTRUCTION_BREAK_FLOW
-		}
+		return leftValue&rightValue != 0
 	}
 
-	return 1
+	return true
 }
 
 func (scriptDef *ScriptDef) ScriptSave(lineData []byte) int {
@@ -209,3 +197,8 @@ func (scriptDef *ScriptDef) ScriptVariableCalculator(operation int, leftValue in
 
 	return INSTRUCTION_BREAK_FLOW
 }
+
+func (scriptDef *ScriptDef) ScriptSceRnd(gameDef *game.GameDef) int {
+	scriptDef.SetScriptVariable(SCRIPT_VARIABLE_RANDOM, gameDef.Random.Next())
+	return 1
+}
@@ -0,0 +1,29 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+)
+
+func TestScriptSceRndIsReproducible(t *testing.T) {
+	scriptDef1 := NewScriptDef()
+	scriptDef2 := NewScriptDef()
+	gameDef1 := game.NewGame(1, 0, 0)
+	gameDef2 := game.NewGame(1, 0, 0)
+	gameDef1.SetRandomSeed(1234)
+	gameDef2.SetRandomSeed(1234)
+
+	lineData := []byte{fileio.OP_SCE_RND}
+	for i := 0; i < 10; i++ {
+		scriptDef1.ExecuteSingleInstruction(0, scriptDef1.ScriptThreads[0], lineData, fileio.ScriptFunction{}, gameDef1, nil)
+		scriptDef2.ExecuteSingleInstruction(0, scriptDef2.ScriptThreads[0], lineData, fileio.ScriptFunction{}, gameDef2, nil)
+
+		value1 := scriptDef1.GetScriptVariable(SCRIPT_VARIABLE_RANDOM)
+		value2 := scriptDef2.GetScriptVariable(SCRIPT_VARIABLE_RANDOM)
+		if value1 != value2 {
+			t.Fatalf("Expected same random value at index %d, got %d and %d", i, value1, value2)
+		}
+	}
+}
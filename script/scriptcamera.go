@@ -0,0 +1,46 @@
+package script
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+)
+
+// Events turn off automatic camera switching to keep the same shot
+// while the player walks through camera switch zones
+
+func (scriptDef *ScriptDef) ScriptCutOld(gameDef *game.GameDef) int {
+	gameDef.RestorePreviousCamera()
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptCutAuto(lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrCutAuto{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	gameDef.AutoCameraSwitch = instruction.FlagOn != 0
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptCutReplace(lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrCutReplace{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	cameraSwitchHandler := gameDef.GameWorld.GameRoom.CameraSwitchHandler
+	cameraSwitchHandler.ReplaceCamera(int(instruction.OldCameraId), int(instruction.NewCameraId))
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptCutBeSet(lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrCutBeSet{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	cameraSwitchHandler := gameDef.GameWorld.GameRoom.CameraSwitchHandler
+	cameraSwitchHandler.SetCameraEnabled(int(instruction.CameraId), instruction.On != 0)
+	return 1
+}
@@ -0,0 +1,67 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+	"github.com/OpenBiohazard2/OpenBiohazard2/world"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func createCameraTestGame() *game.GameDef {
+	cameraSwitches := []fileio.RVDHeader{
+		{Cam0: 0, Cam1: 1, X1: 0, Z1: 0, X2: 10, Z2: 0, X3: 10, Z3: 10, X4: 0, Z4: 10, Floor: 0},
+	}
+	gameDef := game.NewGame(1, 0, 0)
+	gameDef.GameWorld.GameRoom = &world.Room{
+		CameraSwitchHandler: world.NewCameraSwitchHandler(cameraSwitches, 3),
+		MaxCamerasInRoom:    3,
+	}
+	gameDef.Player = game.NewPlayer(mgl32.Vec3{5, 0, 5}, 0)
+	return gameDef
+}
+
+func TestScriptCutAuto(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := createCameraTestGame()
+
+	scriptDef.ScriptCutAuto([]byte{fileio.OP_CUT_AUTO, 0}, gameDef)
+	gameDef.HandleCameraSwitch(gameDef.Player.Position)
+	if gameDef.CameraId != 0 {
+		t.Errorf("Expected camera to stay at 0, got %d", gameDef.CameraId)
+	}
+
+	scriptDef.ScriptCutAuto([]byte{fileio.OP_CUT_AUTO, 1}, gameDef)
+	gameDef.HandleCameraSwitch(gameDef.Player.Position)
+	if gameDef.CameraId != 1 {
+		t.Errorf("Expected camera 1, got %d", gameDef.CameraId)
+	}
+
+	scriptDef.ScriptCutOld(gameDef)
+	if gameDef.CameraId != 0 {
+		t.Errorf("Expected previous camera 0, got %d", gameDef.CameraId)
+	}
+}
+
+func TestScriptCutReplace(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := createCameraTestGame()
+
+	scriptDef.ScriptCutReplace([]byte{fileio.OP_CUT_REPLACE, 1, 2}, gameDef)
+	gameDef.HandleCameraSwitch(gameDef.Player.Position)
+	if gameDef.CameraId != 2 {
+		t.Errorf("Expected replaced camera 2, got %d", gameDef.CameraId)
+	}
+}
+
+func TestScriptCutBeSet(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := createCameraTestGame()
+
+	scriptDef.ScriptCutBeSet([]byte{fileio.OP_CUT_BE_SET, 1, 0, 0}, gameDef)
+	gameDef.HandleCameraSwitch(gameDef.Player.Position)
+	if gameDef.CameraId != 0 {
+		t.Errorf("Expected camera to stay at 0, got %d", gameDef.CameraId)
+	}
+}
@@ -0,0 +1,233 @@
+package script
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+)
+
+// Script coverage records which instructions of each room script were executed
+// Only used for development to find logic that playtests never reach
+
+const (
+	COVERAGE_INIT_SCRIPT = "init"
+	COVERAGE_ROOM_SCRIPT = "room"
+)
+
+type ScriptCoverage struct {
+	Rooms       map[game.RoomMapKey]*RoomCoverage
+	currentRoom *RoomCoverage
+}
+
+type RoomCoverage struct {
+	StageId       int
+	RoomId        int
+	Scripts       []*ScriptFunctionCoverage
+	Unimplemented map[byte]int // key is opcode, value is number of times it was executed
+}
+
+type ScriptFunctionCoverage struct {
+	Name       string
+	ScriptData fileio.ScriptFunction
+	Counts     map[int]int // key is program counter, value is number of times it was executed
+}
+
+type UnimplementedOpcodeCount struct {
+	Opcode byte
+	Count  int
+	Rooms  int
+}
+
+func NewScriptCoverage() *ScriptCoverage {
+	return &ScriptCoverage{
+		Rooms:       make(map[game.RoomMapKey]*RoomCoverage),
+		currentRoom: nil,
+	}
+}
+
+// Coverage is kept across room loads so that a whole playtest can be reported at once
+func (coverage *ScriptCoverage) BeginRoom(stageId int, roomId int, roomScript game.RoomScript) {
+	roomKey := game.RoomMapKey{StageId: stageId, RoomId: roomId}
+	roomCoverage, exists := coverage.Rooms[roomKey]
+	if !exists {
+		roomCoverage = &RoomCoverage{
+			StageId:       stageId,
+			RoomId:        roomId,
+			Scripts:       make([]*ScriptFunctionCoverage, 0),
+			Unimplemented: make(map[byte]int),
+		}
+		coverage.Rooms[roomKey] = roomCoverage
+	}
+
+	// Script data is reloaded from the RDT file every time the room is entered
+	roomCoverage.Scripts = []*ScriptFunctionCoverage{
+		roomCoverage.mergeScript(COVERAGE_INIT_SCRIPT, roomScript.InitScriptData),
+		roomCoverage.mergeScript(COVERAGE_ROOM_SCRIPT, roomScript.RoomScriptData),
+	}
+	coverage.currentRoom = roomCoverage
+}
+
+func (roomCoverage *RoomCoverage) mergeScript(name string, scriptData fileio.ScriptFunction) *ScriptFunctionCoverage {
+	counts := make(map[int]int)
+	for _, scriptCoverage := range roomCoverage.Scripts {
+		if scriptCoverage.Name == name {
+			counts = scriptCoverage.Counts
+		}
+	}
+
+	return &ScriptFunctionCoverage{
+		Name:       name,
+		ScriptData: scriptData,
+		Counts:     counts,
+	}
+}
+
+func (coverage *ScriptCoverage) RecordInstruction(scriptData fileio.ScriptFunction, programCounter int) {
+	if coverage.currentRoom == nil {
+		return
+	}
+
+	for _, scriptCoverage := range coverage.currentRoom.Scripts {
+		if isSameScriptFunction(scriptCoverage.ScriptData, scriptData) {
+			scriptCoverage.Counts[programCounter]++
+			return
+		}
+	}
+}
+
+func (coverage *ScriptCoverage) RecordUnimplemented(opcode byte) {
+	if coverage.currentRoom == nil {
+		return
+	}
+
+	coverage.currentRoom.Unimplemented[opcode]++
+}
+
+// Both scripts are loaded into separate maps, so the map identity tells them apart
+func isSameScriptFunction(scriptData1 fileio.ScriptFunction, scriptData2 fileio.ScriptFunction) bool {
+	if scriptData1.Instructions == nil || scriptData2.Instructions == nil {
+		return false
+	}
+	return reflect.ValueOf(scriptData1.Instructions).Pointer() == reflect.ValueOf(scriptData2.Instructions).Pointer()
+}
+
+// Sorted by the number of times the opcode was executed in all rooms
+func (coverage *ScriptCoverage) GetUnimplementedOpcodes() []UnimplementedOpcodeCount {
+	opcodeCounts := make(map[byte]*UnimplementedOpcodeCount)
+	for _, roomCoverage := range coverage.Rooms {
+		for opcode, count := range roomCoverage.Unimplemented {
+			opcodeCount, exists := opcodeCounts[opcode]
+			if !exists {
+				opcodeCount = &UnimplementedOpcodeCount{Opcode: opcode}
+				opcodeCounts[opcode] = opcodeCount
+			}
+			opcodeCount.Count += count
+			opcodeCount.Rooms++
+		}
+	}
+
+	result := make([]UnimplementedOpcodeCount, 0, len(opcodeCounts))
+	for _, opcodeCount := range opcodeCounts {
+		result = append(result, *opcodeCount)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Opcode < result[j].Opcode
+	})
+	return result
+}
+
+func (scriptCoverage *ScriptFunctionCoverage) GetExecutedCount() (int, int) {
+	executed := 0
+	for programCounter := range scriptCoverage.ScriptData.Instructions {
+		if scriptCoverage.Counts[programCounter] > 0 {
+			executed++
+		}
+	}
+	return executed, len(scriptCoverage.ScriptData.Instructions)
+}
+
+// Writes the disassembly of every visited room with the execution count of each instruction
+func (coverage *ScriptCoverage) WriteReport(writer io.Writer) error {
+	roomKeys := make([]game.RoomMapKey, 0, len(coverage.Rooms))
+	for roomKey := range coverage.Rooms {
+		roomKeys = append(roomKeys, roomKey)
+	}
+	sort.Slice(roomKeys, func(i, j int) bool {
+		if roomKeys[i].StageId != roomKeys[j].StageId {
+			return roomKeys[i].StageId < roomKeys[j].StageId
+		}
+		return roomKeys[i].RoomId < roomKeys[j].RoomId
+	})
+
+	if _, err := fmt.Fprintln(writer, "Unimplemented opcodes"); err != nil {
+		return err
+	}
+	for _, opcodeCount := range coverage.GetUnimplementedOpcodes() {
+		_, err := fmt.Fprintf(writer, "%8d  %-16s (0x%02x) in %d rooms\n",
+			opcodeCount.Count, getFunctionNameFromOpcode(opcodeCount.Opcode), opcodeCount.Opcode, opcodeCount.Rooms)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, roomKey := range roomKeys {
+		roomCoverage := coverage.Rooms[roomKey]
+		for _, scriptCoverage := range roomCoverage.Scripts {
+			if err := scriptCoverage.writeDisassembly(writer, roomCoverage); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (scriptCoverage *ScriptFunctionCoverage) writeDisassembly(writer io.Writer, roomCoverage *RoomCoverage) error {
+	executed, total := scriptCoverage.GetExecutedCount()
+	_, err := fmt.Fprintf(writer, "\nRoom %d%02x %s script: %d/%d instructions executed\n",
+		roomCoverage.StageId, roomCoverage.RoomId, scriptCoverage.Name, executed, total)
+	if err != nil {
+		return err
+	}
+
+	programCounters := make([]int, 0, len(scriptCoverage.ScriptData.Instructions))
+	for programCounter := range scriptCoverage.ScriptData.Instructions {
+		programCounters = append(programCounters, programCounter)
+	}
+	sort.Ints(programCounters)
+
+	functionStarts := make(map[int]int)
+	for functionId, startProgramCounter := range scriptCoverage.ScriptData.StartProgramCounter {
+		functionStarts[startProgramCounter] = functionId
+	}
+
+	for _, programCounter := range programCounters {
+		if functionId, exists := functionStarts[programCounter]; exists {
+			if _, err := fmt.Fprintf(writer, "  Function %d:\n", functionId); err != nil {
+				return err
+			}
+		}
+
+		lineData := scriptCoverage.ScriptData.Instructions[programCounter]
+		if len(lineData) == 0 {
+			continue
+		}
+
+		countText := "-"
+		if count := scriptCoverage.Counts[programCounter]; count > 0 {
+			countText = fmt.Sprintf("%d", count)
+		}
+		_, err := fmt.Fprintf(writer, "%10s  %04x  %s%s\n",
+			countText, programCounter, getFunctionNameFromOpcode(lineData[0]), GetOpcodeSignature(lineData))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package script
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+)
+
+// Helper function to create a room script with one function
+func createCoverageScriptData() fileio.ScriptFunction {
+	return fileio.ScriptFunction{
+		Instructions: map[int][]byte{
+			0:  {fileio.OP_SAVE, 5, 1, 0},          // Save var 5 = 1
+			4:  {fileio.OP_PLC_GUN_EFF},            // Not implemented
+			5:  {fileio.OP_COMPARE, 0, 5, 0, 1, 0}, // Check var 5 == 1
+			11: {fileio.OP_EVT_END},
+		},
+		StartProgramCounter: []int{0},
+	}
+}
+
+func TestScriptCoverageRecordsProgramCounters(t *testing.T) {
+	scriptDef := NewScriptDef()
+	scriptDef.EnableCoverage()
+
+	initScriptData := createCoverageScriptData()
+	roomScriptData := createCoverageScriptData()
+	scriptDef.Coverage.BeginRoom(1, 0, game.RoomScript{InitScriptData: initScriptData, RoomScriptData: roomScriptData})
+
+	scriptDef.InitScript(roomScriptData, 0, 0)
+	scriptDef.RunScriptThread(0, scriptDef.ScriptThreads[0], roomScriptData, nil, nil)
+
+	roomCoverage := scriptDef.Coverage.Rooms[game.RoomMapKey{StageId: 1, RoomId: 0}]
+	if roomCoverage == nil {
+		t.Fatal("Expected room coverage to be created")
+	}
+
+	initCoverage := roomCoverage.Scripts[0]
+	if len(initCoverage.Counts) != 0 {
+		t.Errorf("Expected init script to have no executed instructions, got %v", initCoverage.Counts)
+	}
+
+	roomCoverageScript := roomCoverage.Scripts[1]
+	for _, programCounter := range []int{0, 4, 5, 11} {
+		if roomCoverageScript.Counts[programCounter] != 1 {
+			t.Errorf("Expected program counter %d to be executed once, got %d", programCounter, roomCoverageScript.Counts[programCounter])
+		}
+	}
+
+	executed, total := roomCoverageScript.GetExecutedCount()
+	if executed != 4 || total != 4 {
+		t.Errorf("Expected 4/4 instructions executed, got %d/%d", executed, total)
+	}
+
+	if roomCoverage.Unimplemented[fileio.OP_PLC_GUN_EFF] != 1 {
+		t.Errorf("Expected PlcGunEff to hit the unimplemented path once, got %d", roomCoverage.Unimplemented[fileio.OP_PLC_GUN_EFF])
+	}
+}
+
+func TestScriptCoverageRanksUnimplementedOpcodes(t *testing.T) {
+	coverage := NewScriptCoverage()
+	coverage.BeginRoom(1, 0, game.RoomScript{})
+	coverage.RecordUnimplemented(fileio.OP_MOVIE_ON)
+	coverage.RecordUnimplemented(fileio.OP_PARTS_SET)
+	coverage.RecordUnimplemented(fileio.OP_PARTS_SET)
+
+	coverage.BeginRoom(1, 1, game.RoomScript{})
+	coverage.RecordUnimplemented(fileio.OP_PARTS_SET)
+
+	opcodes := coverage.GetUnimplementedOpcodes()
+	if len(opcodes) != 2 {
+		t.Fatalf("Expected 2 unimplemented opcodes, got %d", len(opcodes))
+	}
+	if opcodes[0].Opcode != fileio.OP_PARTS_SET || opcodes[0].Count != 3 || opcodes[0].Rooms != 2 {
+		t.Errorf("Expected PartsSet to be hit 3 times in 2 rooms, got %+v", opcodes[0])
+	}
+	if opcodes[1].Opcode != fileio.OP_MOVIE_ON || opcodes[1].Count != 1 || opcodes[1].Rooms != 1 {
+		t.Errorf("Expected MovieOn to be hit once in 1 room, got %+v", opcodes[1])
+	}
+}
+
+func TestScriptCoverageKeepsCountsAcrossRoomLoads(t *testing.T) {
+	coverage := NewScriptCoverage()
+	roomScriptData := createCoverageScriptData()
+	coverage.BeginRoom(1, 0, game.RoomScript{RoomScriptData: roomScriptData})
+	coverage.RecordInstruction(roomScriptData, 0)
+
+	// Room is loaded again from file
+	reloadedScriptData := createCoverageScriptData()
+	coverage.BeginRoom(1, 0, game.RoomScript{RoomScriptData: reloadedScriptData})
+	coverage.RecordInstruction(reloadedScriptData, 0)
+
+	roomCoverage := coverage.Rooms[game.RoomMapKey{StageId: 1, RoomId: 0}]
+	if roomCoverage.Scripts[1].Counts[0] != 2 {
+		t.Errorf("Expected program counter 0 to be executed twice, got %d", roomCoverage.Scripts[1].Counts[0])
+	}
+}
+
+func TestScriptCoverageWriteReport(t *testing.T) {
+	coverage := NewScriptCoverage()
+	roomScriptData := createCoverageScriptData()
+	coverage.BeginRoom(1, 0x1a, game.RoomScript{RoomScriptData: roomScriptData})
+	coverage.RecordInstruction(roomScriptData, 0)
+	coverage.RecordUnimplemented(fileio.OP_MOVIE_ON)
+
+	var output bytes.Buffer
+	if err := coverage.WriteReport(&output); err != nil {
+		t.Fatalf("Unexpected error writing report: %v", err)
+	}
+
+	report := output.String()
+	expectedLines := []string{
+		"MovieOn",
+		"Room 11a room script: 1/4 instructions executed",
+		"Function 0:",
+		"         1  0000  Save",
+		"         -  0004  PlcGunEff",
+	}
+	for _, expectedLine := range expectedLines {
+		if !strings.Contains(report, expectedLine) {
+			t.Errorf("Expected report to contain %q, got:\n%s", expectedLine, report)
+		}
+	}
+}
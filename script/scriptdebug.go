@@ -110,6 +110,7 @@ var (
 		fileio.OP_SCE_SCR_MOVE:     "SceScrMove",
 		fileio.OP_PARTS_SET:        "PartsSet",
 		fileio.OP_MOVIE_ON:         "MovieOn",
+		fileio.OP_SCE_ITEM_GET:     "SceItemGet",
 		fileio.OP_SCE_PARTS_BOMB:   "ScePartsBomb",
 		fileio.OP_SCE_PARTS_DOWN:   "ScePartsDown",
 	}
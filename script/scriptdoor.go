@@ -0,0 +1,57 @@
+package script
+
+import (
+	"bytes"
+	"encoding/binary"
+	"log"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+	"github.com/OpenBiohazard2/OpenBiohazard2/render"
+	"github.com/OpenBiohazard2/OpenBiohazard2/resource"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Places a door model in the room
+// The model belongs to the door aot with the same aot number
+func (scriptDef *ScriptDef) ScriptDoorModelSet(lineData []byte, gameDef *game.GameDef, renderDef *render.RenderDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrDoorModelSet{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	do2Output, err := resource.LoadDoorModel(int(instruction.ModelNumber))
+	if err != nil {
+		log.Printf("SCRIPT: Failed to load door model %d: %v", instruction.ModelNumber, err)
+		return 1
+	}
+
+	position := mgl32.Vec3{float32(instruction.Position[0]), float32(instruction.Position[1]), float32(instruction.Position[2])}
+	rotationAngle := (float32(instruction.Direction[1]) / 4096.0) * 360.0
+	door := render.NewDoorEntity(do2Output, instruction.Id, position, rotationAngle)
+	renderDef.SceneSystem.DoorGroupEntity.SetDoor(instruction.Index, door)
+	gameDef.GameWorld.AotManager.SetDoorModel(instruction.Id)
+	return 1
+}
+
+// Opens the model of the door the player is going through
+// The player goes to the next room once it is open
+func UpdateDoorModels(gameDef *game.GameDef, renderDef *render.RenderDef) {
+	doorGroup := renderDef.SceneSystem.DoorGroupEntity
+	pendingDoor := gameDef.PendingDoor
+	for _, door := range doorGroup.Doors {
+		if pendingDoor == nil || door.Aot != pendingDoor.Header.Aot {
+			door.Close()
+		}
+	}
+
+	if pendingDoor == nil {
+		return
+	}
+
+	door := doorGroup.GetDoorByAot(pendingDoor.Header.Aot)
+	if door == nil || door.IsOpen() {
+		gameDef.EnterDoor(pendingDoor)
+		return
+	}
+	door.Open()
+}
@@ -0,0 +1,39 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+	"github.com/OpenBiohazard2/OpenBiohazard2/render"
+	"github.com/OpenBiohazard2/OpenBiohazard2/world"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestUpdateDoorModels(t *testing.T) {
+	gameDef := game.NewGame(1, 0, 0)
+	gameDef.Player = game.NewPlayer(mgl32.Vec3{0, 0, 0}, 0)
+	renderDef := &render.RenderDef{SceneSystem: render.NewSceneSystemForTesting()}
+	door := &render.DoorEntity{Aot: 2}
+	renderDef.SceneSystem.DoorGroupEntity.SetDoor(0, door)
+
+	pendingDoor := &world.AotDoor{Header: world.AotHeader{Aot: 2}, Room: 5}
+	gameDef.PendingDoor = pendingDoor
+	gameDef.StateStatus = game.GAME_LOOP
+
+	UpdateDoorModels(gameDef, renderDef)
+	if !door.IsOpening {
+		t.Fatal("Expected door model to start opening")
+	}
+	if gameDef.PendingDoor == nil {
+		t.Fatal("Expected player to wait for the door")
+	}
+
+	door.Update(render.DOOR_ANIMATION_SECONDS)
+	UpdateDoorModels(gameDef, renderDef)
+	if gameDef.PendingDoor != nil {
+		t.Error("Expected player to go through the open door")
+	}
+	if gameDef.RoomId != 5 {
+		t.Errorf("Expected room 5, got %d", gameDef.RoomId)
+	}
+}
@@ -0,0 +1,55 @@
+package script
+
+import (
+	"bytes"
+	"encoding/binary"
+	"log"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+)
+
+// Condition that passes if the player carries the item
+func (scriptDef *ScriptDef) ScriptKeepItemCk(lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrKeepItemCk{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	if gameDef.Inventory.HasItem(int(instruction.ItemId)) {
+		return 1
+	}
+	return INSTRUCTION_BREAK_FLOW
+}
+
+func (scriptDef *ScriptDef) ScriptSceItemLost(lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrSceItemLost{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	if !gameDef.Inventory.RemoveItem(int(instruction.ItemId)) {
+		log.Printf("SCRIPT: Item %d is not in the inventory", instruction.ItemId)
+	}
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptSceItemGet(lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrSceItemGet{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	if !gameDef.Inventory.AddItem(int(instruction.ItemId), int(instruction.Amount)) {
+		log.Printf("SCRIPT: No space in the inventory for item %d", instruction.ItemId)
+	}
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptWeaponChg(lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrWeaponChg{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	if !gameDef.Inventory.EquipItem(int(instruction.WeaponId)) {
+		log.Printf("SCRIPT: Weapon %d is not in the inventory", instruction.WeaponId)
+	}
+	return 1
+}
@@ -0,0 +1,53 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+)
+
+func TestScriptKeepItemCk(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := game.NewGame(1, 0, 0)
+
+	result := scriptDef.ScriptKeepItemCk([]byte{fileio.OP_KEEP_ITEM_CK, game.ITEM_KNIFE}, gameDef)
+	if result != 1 {
+		t.Errorf("Expected carried item to pass, got %d", result)
+	}
+
+	result = scriptDef.ScriptKeepItemCk([]byte{fileio.OP_KEEP_ITEM_CK, 30}, gameDef)
+	if result != INSTRUCTION_BREAK_FLOW {
+		t.Errorf("Expected missing item to break flow, got %d", result)
+	}
+}
+
+func TestScriptSceItemGetAndLost(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := game.NewGame(1, 0, 0)
+
+	scriptDef.ScriptSceItemGet([]byte{fileio.OP_SCE_ITEM_GET, 30, 1}, gameDef)
+	if gameDef.Inventory.CountItem(30) != 1 {
+		t.Errorf("Expected item 30 count 1, got %d", gameDef.Inventory.CountItem(30))
+	}
+
+	scriptDef.ScriptSceItemLost([]byte{fileio.OP_SCE_ITEM_LOST, 30}, gameDef)
+	if gameDef.Inventory.HasItem(30) {
+		t.Error("Expected item 30 to be taken away")
+	}
+}
+
+func TestScriptWeaponChg(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := game.NewGame(1, 0, 0)
+
+	scriptDef.ScriptWeaponChg([]byte{fileio.OP_WEAPON_CHG, game.ITEM_KNIFE}, gameDef)
+	if gameDef.Inventory.GetEquippedItemId() != game.ITEM_KNIFE {
+		t.Errorf("Expected knife equipped, got %d", gameDef.Inventory.GetEquippedItemId())
+	}
+
+	scriptDef.ScriptWeaponChg([]byte{fileio.OP_WEAPON_CHG, 0}, gameDef)
+	if gameDef.Inventory.GetEquippedItemId() != game.ITEM_NONE {
+		t.Errorf("Expected no weapon, got %d", gameDef.Inventory.GetEquippedItemId())
+	}
+}
@@ -0,0 +1,35 @@
+package script
+
+import (
+	"bytes"
+	"encoding/binary"
+	"log"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/render"
+)
+
+// Light changes last until the next camera change
+
+func (scriptDef *ScriptDef) ScriptLightPosSet(lineData []byte, renderDef *render.RenderDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrLightPosSet{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	if !renderDef.CameraLights.SetLightPosition(int(instruction.Index), int(instruction.Axis), float32(instruction.Position)) {
+		log.Printf("SCRIPT: Invalid light %d or axis %d", instruction.Index, instruction.Axis)
+	}
+	return 1
+}
+
+// Kido is the brightness of the light
+func (scriptDef *ScriptDef) ScriptLightKidoSet(lineData []byte, renderDef *render.RenderDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrLightKidoSet{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	if !renderDef.CameraLights.SetLightBrightness(int(instruction.Index), float32(instruction.Brightness)) {
+		log.Printf("SCRIPT: Invalid light %d", instruction.Index)
+	}
+	return 1
+}
@@ -0,0 +1,35 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/render"
+)
+
+func TestScriptLightPosSet(t *testing.T) {
+	scriptDef := NewScriptDef()
+	renderDef := &render.RenderDef{}
+
+	// Move light 1 to y = -1000
+	scriptDef.ScriptLightPosSet([]byte{fileio.OP_LIGHT_POS_SET, 0, 1, render.LIGHT_AXIS_Y, 0x18, 0xfc}, renderDef)
+
+	position := renderDef.CameraLights.PointLights[1].Position
+	if position.Y() != -1000 {
+		t.Errorf("Expected light y position -1000, got %f", position.Y())
+	}
+}
+
+func TestScriptLightKidoSet(t *testing.T) {
+	scriptDef := NewScriptDef()
+	renderDef := &render.RenderDef{}
+
+	scriptDef.ScriptLightKidoSet([]byte{fileio.OP_LIGHT_KIDO_SET, 2, 0xd0, 0x07}, renderDef)
+
+	if brightness := renderDef.CameraLights.PointLights[2].Brightness; brightness != 2000 {
+		t.Errorf("Expected light brightness 2000, got %f", brightness)
+	}
+
+	// Out of range lights are ignored
+	scriptDef.ScriptLightKidoSet([]byte{fileio.OP_LIGHT_KIDO_SET, 5, 0xd0, 0x07}, renderDef)
+}
@@ -0,0 +1,121 @@
+package script
+
+import (
+	"bytes"
+	"encoding/binary"
+	"log"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+	"github.com/OpenBiohazard2/OpenBiohazard2/render"
+)
+
+// Member commands read and write attributes of the entity selected by WORK_SET
+
+func (scriptDef *ScriptDef) ScriptMemberSet(thread *ScriptThread, lineData []byte, gameDef *game.GameDef, renderDef *render.RenderDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrMemberSet{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	entity := GetWorkSetEntity(thread, gameDef, renderDef)
+	if entity == nil {
+		return 1
+	}
+
+	if !game.SetEntityMember(entity, int(instruction.MemberIndex), int(int16(instruction.Value))) {
+		log.Printf("SCRIPT: Unknown member index %d", instruction.MemberIndex)
+	}
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptMemberSet2(thread *ScriptThread, lineData []byte, gameDef *game.GameDef, renderDef *render.RenderDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrMemberSet2{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	entity := GetWorkSetEntity(thread, gameDef, renderDef)
+	if entity == nil {
+		return 1
+	}
+
+	value := scriptDef.GetScriptVariable(int(instruction.VarId))
+	if !game.SetEntityMember(entity, int(instruction.MemberIndex), value) {
+		log.Printf("SCRIPT: Unknown member index %d", instruction.MemberIndex)
+	}
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptMemberCopy(thread *ScriptThread, lineData []byte, gameDef *game.GameDef, renderDef *render.RenderDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrMemberCopy{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	entity := GetWorkSetEntity(thread, gameDef, renderDef)
+	if entity == nil {
+		return 1
+	}
+
+	value, exists := game.GetEntityMember(entity, int(instruction.MemberIndex))
+	if !exists {
+		log.Printf("SCRIPT: Unknown member index %d", instruction.MemberIndex)
+		return 1
+	}
+	scriptDef.SetScriptVariable(int(instruction.VarId), value)
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptMemberCompare(thread *ScriptThread, lineData []byte, gameDef *game.GameDef, renderDef *render.RenderDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrMemberCompare{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	entity := GetWorkSetEntity(thread, gameDef, renderDef)
+	if entity == nil {
+		return INSTRUCTION_BREAK_FLOW
+	}
+
+	memberValue, exists := game.GetEntityMember(entity, int(instruction.MemberIndex))
+	if !exists {
+		log.Printf("SCRIPT: Unknown member index %d", instruction.MemberIndex)
+		return INSTRUCTION_BREAK_FLOW
+	}
+
+	if compareScriptValues(int(instruction.CompareOperation), memberValue, int(instruction.Value)) {
+		return 1
+	}
+	return INSTRUCTION_BREAK_FLOW
+}
+
+// Returns the entity that was selected by the last WORK_SET in this thread
+func GetWorkSetEntity(thread *ScriptThread, gameDef *game.GameDef, renderDef *render.RenderDef) game.ScriptableEntity {
+	return GetScriptEntity(thread.WorkSetComponent, thread.WorkSetIndex, gameDef, renderDef)
+}
+
+func GetScriptEntity(workSetComponent int, workSetIndex int, gameDef *game.GameDef, renderDef *render.RenderDef) game.ScriptableEntity {
+	switch workSetComponent {
+	case WORKSET_PLAYER:
+		if gameDef == nil || gameDef.Player == nil {
+			return nil
+		}
+		return gameDef.Player
+	case WORKSET_ENEMY:
+		if renderDef == nil || renderDef.SceneSystem == nil || renderDef.SceneSystem.EnemyGroupEntity == nil {
+			return nil
+		}
+		// Enemies are referenced by the id they were created with
+		for _, enemyEntity := range renderDef.SceneSystem.EnemyGroupEntity.EnemyEntities {
+			if enemyEntity.ScriptWork.Id == workSetIndex {
+				return enemyEntity
+			}
+		}
+	case WORKSET_OBJECT:
+		if renderDef == nil || renderDef.SceneSystem == nil || renderDef.SceneSystem.ItemGroupEntity == nil {
+			return nil
+		}
+		modelObjectData := renderDef.SceneSystem.ItemGroupEntity.ModelObjectData
+		if workSetIndex >= 0 && workSetIndex < len(modelObjectData) {
+			return modelObjectData[workSetIndex]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Helper function to create a game with only a player
+func createMemberTestGame() *game.GameDef {
+	return &game.GameDef{
+		Player: game.NewPlayer(mgl32.Vec3{100, 0, 200}, 90),
+	}
+}
+
+func TestScriptMemberSetPlayerRotation(t *testing.T) {
+	scriptDef := NewScriptDef()
+	thread := scriptDef.ScriptThreads[0]
+	thread.WorkSetComponent = WORKSET_PLAYER
+	gameDef := createMemberTestGame()
+
+	// 1024 is a quarter turn
+	lineData := []byte{fileio.OP_MEMBER_SET, game.MEMBER_DIRECTION_Y, 0x00, 0x04}
+	returnValue := scriptDef.ScriptMemberSet(thread, lineData, gameDef, nil)
+
+	if returnValue != INSTRUCTION_NORMAL {
+		t.Errorf("Expected return value %d, got %d", INSTRUCTION_NORMAL, returnValue)
+	}
+	if gameDef.Player.RotationAngle != 90 {
+		t.Errorf("Expected rotation angle 90, got %f", gameDef.Player.RotationAngle)
+	}
+}
+
+func TestScriptMemberSetNegativePosition(t *testing.T) {
+	scriptDef := NewScriptDef()
+	thread := scriptDef.ScriptThreads[0]
+	thread.WorkSetComponent = WORKSET_PLAYER
+	gameDef := createMemberTestGame()
+
+	// -3000 as little endian int16
+	lineData := []byte{fileio.OP_MEMBER_SET, game.MEMBER_POSITION_X, 0x48, 0xf4}
+	scriptDef.ScriptMemberSet(thread, lineData, gameDef, nil)
+
+	expectedPosition := mgl32.Vec3{-3000, 0, 200}
+	if gameDef.Player.Position != expectedPosition {
+		t.Errorf("Expected position %v, got %v", expectedPosition, gameDef.Player.Position)
+	}
+}
+
+func TestScriptMemberCopyAndSet2(t *testing.T) {
+	scriptDef := NewScriptDef()
+	thread := scriptDef.ScriptThreads[0]
+	thread.WorkSetComponent = WORKSET_PLAYER
+	gameDef := createMemberTestGame()
+
+	// Copy player health to variable 3
+	lineData := []byte{fileio.OP_MEMBER_COPY, 3, game.MEMBER_LIFE}
+	scriptDef.ScriptMemberCopy(thread, lineData, gameDef, nil)
+	if scriptDef.GetScriptVariable(3) != game.PLAYER_MAX_HEALTH {
+		t.Errorf("Expected variable 3 to be %d, got %d", game.PLAYER_MAX_HEALTH, scriptDef.GetScriptVariable(3))
+	}
+
+	// Set player status from variable 4
+	scriptDef.SetScriptVariable(4, 7)
+	lineData = []byte{fileio.OP_MEMBER_SET2, game.MEMBER_STATUS, 4}
+	scriptDef.ScriptMemberSet2(thread, lineData, gameDef, nil)
+	if gameDef.Player.ScriptWork.Status != 7 {
+		t.Errorf("Expected player status to be 7, got %d", gameDef.Player.ScriptWork.Status)
+	}
+}
+
+func TestScriptMemberCompare(t *testing.T) {
+	scriptDef := NewScriptDef()
+	thread := scriptDef.ScriptThreads[0]
+	thread.WorkSetComponent = WORKSET_PLAYER
+	gameDef := createMemberTestGame()
+
+	tests := []struct {
+		name          string
+		operation     byte
+		value         int16
+		expectedValue int
+	}{
+		{"Position x equals", 0, 100, INSTRUCTION_NORMAL},
+		{"Position x not equals", 0, 101, INSTRUCTION_BREAK_FLOW},
+		{"Position x greater than", 1, 50, INSTRUCTION_NORMAL},
+		{"Position x less than", 3, 50, INSTRUCTION_BREAK_FLOW},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lineData := []byte{fileio.OP_MEMBER_CMP, 0, game.MEMBER_POSITION_X, test.operation, byte(test.value), byte(test.value >> 8)}
+			returnValue := scriptDef.ScriptMemberCompare(thread, lineData, gameDef, nil)
+			if returnValue != test.expectedValue {
+				t.Errorf("Expected return value %d, got %d", test.expectedValue, returnValue)
+			}
+		})
+	}
+}
+
+func TestScriptMemberCompareMissingWorkSet(t *testing.T) {
+	scriptDef := NewScriptDef()
+	thread := scriptDef.ScriptThreads[0]
+	thread.WorkSetComponent = WORKSET_ENEMY
+
+	lineData := []byte{fileio.OP_MEMBER_CMP, 0, game.MEMBER_LIFE, 0, 0, 0}
+	returnValue := scriptDef.ScriptMemberCompare(thread, lineData, nil, nil)
+	if returnValue != INSTRUCTION_BREAK_FLOW {
+		t.Errorf("Expected return value %d, got %d", INSTRUCTION_BREAK_FLOW, returnValue)
+	}
+}
@@ -0,0 +1,41 @@
+package script
+
+import (
+	"bytes"
+	"encoding/binary"
+	"log"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+)
+
+// The thread waits here until the player closes the message
+func (scriptDef *ScriptDef) ScriptMessageOn(thread *ScriptThread, lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrMessageOn{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	messageBox := gameDef.MessageBox
+	if !thread.WaitingForMessage {
+		messages := gameDef.RoomScript.Messages
+		messageId := int(instruction.MessageId)
+		if messageId >= len(messages) {
+			log.Printf("SCRIPT: Message %d doesn't exist in room with %d messages", messageId, len(messages))
+			return 1
+		}
+
+		messageBox.Open(messages[messageId])
+		thread.WaitingForMessage = true
+	}
+
+	if messageBox.IsOpen {
+		thread.OverrideProgramCounter = true
+		return INSTRUCTION_THREAD_END
+	}
+
+	thread.WaitingForMessage = false
+	if messageBox.Message.HasChoice {
+		scriptDef.SetScriptVariable(SCRIPT_VARIABLE_MESSAGE_CHOICE, messageBox.Choice)
+	}
+	return 1
+}
@@ -0,0 +1,59 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+)
+
+func TestScriptMessageOnWaitsForMessage(t *testing.T) {
+	scriptDef := NewScriptDef()
+	thread := scriptDef.ScriptThreads[0]
+	gameDef := game.NewGame(1, 0, 0)
+	gameDef.RoomScript.Messages = []fileio.MSGMessage{
+		{Pages: []string{"First"}},
+		{Pages: []string{"Take it?"}, HasChoice: true},
+	}
+
+	lineData := []byte{fileio.OP_MESSAGE_ON, 0, 1, 0, 0, 0}
+	if scriptDef.ScriptMessageOn(thread, lineData, gameDef) != INSTRUCTION_THREAD_END {
+		t.Fatal("Expected thread to wait for the message")
+	}
+	if !gameDef.MessageBox.IsOpen || gameDef.MessageBox.GetCurrentPage() != "Take it?" {
+		t.Fatalf("Expected message 1 to be open, got %q", gameDef.MessageBox.GetCurrentPage())
+	}
+
+	// Thread keeps waiting while the message is open
+	thread.OverrideProgramCounter = false
+	if scriptDef.ScriptMessageOn(thread, lineData, gameDef) != INSTRUCTION_THREAD_END {
+		t.Error("Expected thread to keep waiting")
+	}
+
+	// Answer no
+	gameDef.MessageBox.Update(1.0)
+	gameDef.MessageBox.ToggleChoice()
+	gameDef.MessageBox.Advance()
+
+	thread.OverrideProgramCounter = false
+	if scriptDef.ScriptMessageOn(thread, lineData, gameDef) != INSTRUCTION_NORMAL {
+		t.Error("Expected thread to continue after the message is closed")
+	}
+	if scriptDef.GetScriptVariable(SCRIPT_VARIABLE_MESSAGE_CHOICE) != game.MESSAGE_CHOICE_NO {
+		t.Errorf("Expected choice variable to be %d, got %d", game.MESSAGE_CHOICE_NO, scriptDef.GetScriptVariable(SCRIPT_VARIABLE_MESSAGE_CHOICE))
+	}
+}
+
+func TestScriptMessageOnMissingMessage(t *testing.T) {
+	scriptDef := NewScriptDef()
+	thread := scriptDef.ScriptThreads[0]
+	gameDef := game.NewGame(1, 0, 0)
+
+	lineData := []byte{fileio.OP_MESSAGE_ON, 0, 3, 0, 0, 0}
+	if scriptDef.ScriptMessageOn(thread, lineData, gameDef) != INSTRUCTION_NORMAL {
+		t.Error("Expected thread to continue when the message doesn't exist")
+	}
+	if gameDef.MessageBox.IsOpen {
+		t.Error("Expected message box to stay closed")
+	}
+}
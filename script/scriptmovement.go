@@ -0,0 +1,117 @@
+package script
+
+import (
+	"bytes"
+	"encoding/binary"
+	"log"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+	"github.com/OpenBiohazard2/OpenBiohazard2/render"
+	"github.com/OpenBiohazard2/OpenBiohazard2/world"
+)
+
+const (
+	// SPEED_SET ids after the three speed axes are for angular speed
+	SPEED_ID_ANGULAR_START = 3
+	SPEED_ID_COUNT         = 6
+)
+
+// Movement commands change the entity selected by WORK_SET
+
+func (scriptDef *ScriptDef) ScriptDirectionSet(thread *ScriptThread, lineData []byte, gameDef *game.GameDef, renderDef *render.RenderDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrDirSet{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	entity := GetWorkSetEntity(thread, gameDef, renderDef)
+	if entity == nil {
+		return 1
+	}
+
+	game.SetEntityMember(entity, game.MEMBER_DIRECTION_X, int(instruction.X))
+	game.SetEntityMember(entity, game.MEMBER_DIRECTION_Y, int(instruction.Y))
+	game.SetEntityMember(entity, game.MEMBER_DIRECTION_Z, int(instruction.Z))
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptSpeedSet(thread *ScriptThread, lineData []byte, gameDef *game.GameDef, renderDef *render.RenderDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrSpeedSet{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	entity := GetWorkSetEntity(thread, gameDef, renderDef)
+	if entity == nil {
+		return 1
+	}
+
+	work := entity.GetScriptWork()
+	speedId := int(instruction.Id)
+	if speedId < SPEED_ID_ANGULAR_START {
+		work.Speed[speedId] = int(instruction.Value)
+	} else if speedId < SPEED_ID_COUNT {
+		work.AngularSpeed[speedId-SPEED_ID_ANGULAR_START] = int(instruction.Value)
+	} else {
+		log.Printf("SCRIPT: Unknown speed id %d", speedId)
+	}
+	return 1
+}
+
+// Scripts run ADD_SPEED once per frame while the entity should move
+// The movement is applied by UpdateScriptedMovement
+func (scriptDef *ScriptDef) ScriptAddSpeed(thread *ScriptThread, gameDef *game.GameDef, renderDef *render.RenderDef) int {
+	entity := GetWorkSetEntity(thread, gameDef, renderDef)
+	if entity == nil {
+		return 1
+	}
+
+	game.StartEntitySpeed(entity)
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptAddAngularSpeed(thread *ScriptThread, gameDef *game.GameDef, renderDef *render.RenderDef) int {
+	entity := GetWorkSetEntity(thread, gameDef, renderDef)
+	if entity == nil {
+		return 1
+	}
+
+	game.StartEntityAngularSpeed(entity)
+	return 1
+}
+
+// Applies the speeds of every entity once per frame
+// The player and enemies are pushed out of the walls they walk into
+func UpdateScriptedMovement(timeElapsedSeconds float64, gameDef *game.GameDef, renderDef *render.RenderDef) {
+	var collision *world.CollisionIndex
+	if gameDef != nil && gameDef.GameWorld != nil && gameDef.GameWorld.GameRoom != nil {
+		collision = gameDef.GameWorld.GameRoom.Collision
+	}
+
+	if gameDef != nil && gameDef.Player != nil {
+		if game.UpdateEntityMovement(gameDef.Player, timeElapsedSeconds) && collision != nil {
+			game.ResolveEntityCollision(gameDef.Player, game.PLAYER_COLLISION_RADIUS, collision)
+		}
+	}
+	if renderDef == nil || renderDef.SceneSystem == nil {
+		return
+	}
+
+	if renderDef.SceneSystem.EnemyGroupEntity != nil {
+		for _, enemyEntity := range renderDef.SceneSystem.EnemyGroupEntity.EnemyEntities {
+			if enemyEntity == nil {
+				continue
+			}
+			if game.UpdateEntityMovement(enemyEntity, timeElapsedSeconds) && collision != nil {
+				game.ResolveEntityCollision(enemyEntity, game.ENEMY_COLLISION_RADIUS, collision)
+			}
+		}
+	}
+
+	if renderDef.SceneSystem.ItemGroupEntity != nil {
+		for _, modelObject := range renderDef.SceneSystem.ItemGroupEntity.ModelObjectData {
+			if modelObject != nil {
+				game.UpdateEntityMovement(modelObject, timeElapsedSeconds)
+			}
+		}
+	}
+}
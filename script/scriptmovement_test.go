@@ -0,0 +1,153 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+	"github.com/OpenBiohazard2/OpenBiohazard2/render"
+	"github.com/OpenBiohazard2/OpenBiohazard2/world"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Helper function to create a scene with one enemy and one object
+func createMovementTestRender() *render.RenderDef {
+	enemyEntity := &render.EnemyEntity{ScriptWork: game.ScriptEntityWork{Id: 2}}
+	modelObjectData := make([]*render.SceneMD1Entity, 4)
+	modelObjectData[3] = &render.SceneMD1Entity{}
+	return &render.RenderDef{
+		SceneSystem: &render.SceneSystem{
+			EnemyGroupEntity: &render.EnemyGroupEntity{EnemyEntities: []*render.EnemyEntity{enemyEntity}},
+			ItemGroupEntity:  &render.ItemGroupEntity{ModelObjectData: modelObjectData},
+		},
+	}
+}
+
+func TestScriptPositionSetWorkSet(t *testing.T) {
+	scriptDef := NewScriptDef()
+	thread := scriptDef.ScriptThreads[0]
+	renderDef := createMovementTestRender()
+
+	scriptDef.ScriptWorkSet(thread, []byte{fileio.OP_WORK_SET, WORKSET_ENEMY, 2})
+	scriptDef.ScriptPositionSet(thread, []byte{fileio.OP_POS_SET, 0, 0x10, 0, 0x20, 0, 0x30, 0}, nil, renderDef)
+
+	expectedPosition := mgl32.Vec3{16, 32, 48}
+	enemyPosition := renderDef.SceneSystem.EnemyGroupEntity.EnemyEntities[0].Position
+	if enemyPosition != expectedPosition {
+		t.Errorf("Expected enemy position %v, got %v", expectedPosition, enemyPosition)
+	}
+
+	scriptDef.ScriptWorkSet(thread, []byte{fileio.OP_WORK_SET, WORKSET_OBJECT, 3})
+	scriptDef.ScriptPositionSet(thread, []byte{fileio.OP_POS_SET, 0, 0x10, 0, 0x20, 0, 0x30, 0}, nil, renderDef)
+
+	objectPosition := renderDef.SceneSystem.ItemGroupEntity.ModelObjectData[3].ModelPosition
+	if objectPosition != expectedPosition {
+		t.Errorf("Expected object position %v, got %v", expectedPosition, objectPosition)
+	}
+}
+
+func TestScriptDirectionSetWorkSet(t *testing.T) {
+	scriptDef := NewScriptDef()
+	thread := scriptDef.ScriptThreads[0]
+	thread.WorkSetComponent = WORKSET_ENEMY
+	thread.WorkSetIndex = 2
+	renderDef := createMovementTestRender()
+
+	// Y is 2048, which is a half turn
+	scriptDef.ScriptDirectionSet(thread, []byte{fileio.OP_DIR_SET, 0, 0x01, 0, 0x00, 0x08, 0x02, 0}, nil, renderDef)
+
+	enemyEntity := renderDef.SceneSystem.EnemyGroupEntity.EnemyEntities[0]
+	if enemyEntity.RotationY != 180 {
+		t.Errorf("Expected enemy rotation 180, got %f", enemyEntity.RotationY)
+	}
+	if enemyEntity.ScriptWork.DirectionX != 1 || enemyEntity.ScriptWork.DirectionZ != 2 {
+		t.Errorf("Expected direction x=1 z=2, got x=%d z=%d", enemyEntity.ScriptWork.DirectionX, enemyEntity.ScriptWork.DirectionZ)
+	}
+}
+
+func TestScriptSpeedIntegration(t *testing.T) {
+	scriptDef := NewScriptDef()
+	thread := scriptDef.ScriptThreads[0]
+	thread.WorkSetComponent = WORKSET_OBJECT
+	thread.WorkSetIndex = 3
+	renderDef := createMovementTestRender()
+	modelObject := renderDef.SceneSystem.ItemGroupEntity.ModelObjectData[3]
+	frameSeconds := 1.0 / game.SCRIPT_ENTITY_FRAMES_PER_SECOND
+
+	// Speed x = 10 units per frame, angular speed y = 1024 units per frame
+	scriptDef.ScriptSpeedSet(thread, []byte{fileio.OP_SPEED_SET, 0, 10, 0}, nil, renderDef)
+	scriptDef.ScriptSpeedSet(thread, []byte{fileio.OP_SPEED_SET, 4, 0x00, 0x04}, nil, renderDef)
+
+	// Speed isn't applied until ADD_SPEED
+	UpdateScriptedMovement(frameSeconds, nil, renderDef)
+	if modelObject.ModelPosition != (mgl32.Vec3{0, 0, 0}) {
+		t.Errorf("Expected object to stay in place, got %v", modelObject.ModelPosition)
+	}
+
+	// One frame of movement is split over the updates in that frame
+	scriptDef.ScriptAddSpeed(thread, nil, renderDef)
+	UpdateScriptedMovement(frameSeconds/2, nil, renderDef)
+	UpdateScriptedMovement(frameSeconds/2, nil, renderDef)
+	if !modelObject.ModelPosition.ApproxEqual(mgl32.Vec3{10, 0, 0}) {
+		t.Errorf("Expected object position (10, 0, 0), got %v", modelObject.ModelPosition)
+	}
+
+	// The object stops when the script stops calling ADD_SPEED
+	UpdateScriptedMovement(frameSeconds, nil, renderDef)
+	if !modelObject.ModelPosition.ApproxEqual(mgl32.Vec3{10, 0, 0}) {
+		t.Errorf("Expected object to stop at (10, 0, 0), got %v", modelObject.ModelPosition)
+	}
+
+	// Rotating by a quarter turn changes the direction of movement
+	scriptDef.ScriptAddAngularSpeed(thread, nil, renderDef)
+	scriptDef.ScriptAddSpeed(thread, nil, renderDef)
+	UpdateScriptedMovement(frameSeconds, nil, renderDef)
+	if modelObject.RotationAngle != 90 {
+		t.Errorf("Expected object rotation 90, got %f", modelObject.RotationAngle)
+	}
+	if !modelObject.ModelPosition.ApproxEqualThreshold(mgl32.Vec3{10, 0, -10}, 1e-3) {
+		t.Errorf("Expected object position (10, 0, -10), got %v", modelObject.ModelPosition)
+	}
+}
+
+// A slow update moves the entity by the time that passed, not by one frame
+func TestScriptSpeedFollowsElapsedTime(t *testing.T) {
+	scriptDef := NewScriptDef()
+	thread := scriptDef.ScriptThreads[0]
+	thread.WorkSetComponent = WORKSET_OBJECT
+	thread.WorkSetIndex = 3
+	renderDef := createMovementTestRender()
+	modelObject := renderDef.SceneSystem.ItemGroupEntity.ModelObjectData[3]
+
+	scriptDef.ScriptSpeedSet(thread, []byte{fileio.OP_SPEED_SET, 0, 10, 0}, nil, renderDef)
+	scriptDef.ScriptAddSpeed(thread, nil, renderDef)
+	UpdateScriptedMovement(2.0/game.SCRIPT_ENTITY_FRAMES_PER_SECOND, nil, renderDef)
+	if !modelObject.ModelPosition.ApproxEqual(mgl32.Vec3{20, 0, 0}) {
+		t.Errorf("Expected object position (20, 0, 0), got %v", modelObject.ModelPosition)
+	}
+}
+
+func TestScriptAddSpeedPlayerCollision(t *testing.T) {
+	scriptDef := NewScriptDef()
+	thread := scriptDef.ScriptThreads[0]
+	thread.WorkSetComponent = WORKSET_PLAYER
+	gameDef := game.NewGame(1, 0, 0)
+	gameDef.Player = game.NewPlayer(mgl32.Vec3{0, 0, 0}, 0)
+	collisionEntities := []fileio.CollisionEntity{
+		{ScaIndex: 1, Shape: 0, X: 500, Z: -1000, Width: 1000, Density: 2000, FloorCheck: []bool{true}},
+	}
+	gameDef.GameWorld.GameRoom = &world.Room{
+		CollisionEntities: collisionEntities,
+		Collision:         world.NewCollisionIndex(collisionEntities),
+	}
+
+	// Walk 200 units towards the wall at x = 500
+	scriptDef.ScriptSpeedSet(thread, []byte{fileio.OP_SPEED_SET, 0, 200, 0}, gameDef, nil)
+	scriptDef.ScriptAddSpeed(thread, gameDef, nil)
+	UpdateScriptedMovement(1.0/game.SCRIPT_ENTITY_FRAMES_PER_SECOND, gameDef, nil)
+
+	position := gameDef.Player.GetPosition()
+	if position.X() > 500-game.PLAYER_COLLISION_RADIUS+1e-3 {
+		t.Errorf("Expected player to be pushed out of the wall, got %v", position)
+	}
+}
@@ -296,8 +296,8 @@ func formatSceEsprControlParams(lineBytes []byte) string {
 
 func formatSceEspr3DOnParams(lineBytes []byte) string {
 	instruction := readInstruction[fileio.ScriptInstrSceEspr3DOn](lineBytes)
-	return fmt.Sprintf("Dummy=%d, Unknown0=%d, Work=%d, Unknown1=%d, Vector1=%s, Vector2=%s, DirY=%d",
-		instruction.Dummy, instruction.Unknown0, instruction.Work, instruction.Unknown1,
+	return fmt.Sprintf("Dummy=%d, Id=%d, Type=%d, Work=%d, Unknown1=%d, Vector1=%s, Vector2=%s, DirY=%d",
+		instruction.Dummy, instruction.Id, instruction.Type, instruction.Work, instruction.Unknown1,
 		formatCoords3D(instruction.Vector1[0], instruction.Vector1[1], instruction.Vector1[2]),
 		formatCoords3D(instruction.Vector2[0], instruction.Vector2[1], instruction.Vector2[2]),
 		instruction.DirY)
@@ -430,11 +430,14 @@ func formatCutOldParams(lineBytes []byte) string {
 }
 
 func formatMessageOnParams(lineBytes []byte) string {
-	return fmt.Sprintf("param1=%d, param2=%d, param3=%d, param4=%d, param5=%d", lineBytes[1], lineBytes[2], lineBytes[3], lineBytes[4], lineBytes[5])
+	instruction := readInstruction[fileio.ScriptInstrMessageOn](lineBytes)
+	return fmt.Sprintf("Dummy=%d, MessageId=%d, Unknown0=%d, Unknown1=%d",
+		instruction.Dummy, instruction.MessageId, instruction.Unknown0, instruction.Unknown1)
 }
 
 func formatSpeedSetParams(lineBytes []byte) string {
-	return fmt.Sprintf("param1=%d, param2=%d, param3=%d", lineBytes[1], lineBytes[2], lineBytes[3])
+	instruction := readInstruction[fileio.ScriptInstrSpeedSet](lineBytes)
+	return fmt.Sprintf("Id=%d, Value=%d", instruction.Id, instruction.Value)
 }
 
 func formatAddSpeedParams(lineBytes []byte) string {
@@ -446,15 +449,19 @@ func formatAddAspeedParams(lineBytes []byte) string {
 }
 
 func formatDirSetParams(lineBytes []byte) string {
-	return fmt.Sprintf("param1=%d, param2=%d, param3=%d, param4=%d, param5=%d, param6=%d, param7=%d", lineBytes[1], lineBytes[2], lineBytes[3], lineBytes[4], lineBytes[5], lineBytes[6], lineBytes[7])
+	instruction := readInstruction[fileio.ScriptInstrDirSet](lineBytes)
+	return fmt.Sprintf("Dummy=%d, X=%d, Y=%d, Z=%d",
+		instruction.Dummy, instruction.X, instruction.Y, instruction.Z)
 }
 
 func formatMemberSet2Params(lineBytes []byte) string {
-	return fmt.Sprintf("param1=%d, param2=%d", lineBytes[1], lineBytes[2])
+	instruction := readInstruction[fileio.ScriptInstrMemberSet2](lineBytes)
+	return fmt.Sprintf("MemberIndex=%d, VarId=%d", instruction.MemberIndex, instruction.VarId)
 }
 
 func formatSeOnParams(lineBytes []byte) string {
-	return fmt.Sprintf("param1=%d, param2=%d, param3=%d, param4=%d, param5=%d, param6=%d, param7=%d, param8=%d, param9=%d, param10=%d, param11=%d", lineBytes[1], lineBytes[2], lineBytes[3], lineBytes[4], lineBytes[5], lineBytes[6], lineBytes[7], lineBytes[8], lineBytes[9], lineBytes[10], lineBytes[11])
+	instruction := readInstruction[fileio.ScriptInstrSeOn](lineBytes)
+	return fmt.Sprintf("Vab=%d, Edt=%d, Data0=%d, X=%d, Y=%d, Z=%d", instruction.Vab, instruction.Edt, instruction.Data0, instruction.X, instruction.Y, instruction.Z)
 }
 
 func formatDirCkParams(lineBytes []byte) string {
@@ -462,7 +469,8 @@ func formatDirCkParams(lineBytes []byte) string {
 }
 
 func formatMemberCopyParams(lineBytes []byte) string {
-	return fmt.Sprintf("param1=%d, param2=%d", lineBytes[1], lineBytes[2])
+	instruction := readInstruction[fileio.ScriptInstrMemberCopy](lineBytes)
+	return fmt.Sprintf("VarId=%d, MemberIndex=%d", instruction.VarId, instruction.MemberIndex)
 }
 
 func formatPlcRetParams(lineBytes []byte) string {
@@ -470,35 +478,82 @@ func formatPlcRetParams(lineBytes []byte) string {
 }
 
 func formatAotOnParams(lineBytes []byte) string {
-	return fmt.Sprintf("param1=%d", lineBytes[1])
+	instruction := readInstruction[fileio.ScriptInstrAotOn](lineBytes)
+	return fmt.Sprintf("Aot=%d", instruction.Aot)
+}
+
+func formatSuperSetParams(lineBytes []byte) string {
+	instruction := readInstruction[fileio.ScriptInstrSuperSet](lineBytes)
+	return fmt.Sprintf("Super=%d, WorkComponent=%d, WorkIndex=%d, Offset=%s, Rotation=%s",
+		instruction.Super, instruction.WorkComponent, instruction.WorkIndex,
+		formatCoords3D(instruction.Offset[0], instruction.Offset[1], instruction.Offset[2]),
+		formatCoords3D(instruction.Rotation[0], instruction.Rotation[1], instruction.Rotation[2]))
+}
+
+func formatSceTrgCkParams(lineBytes []byte) string {
+	instruction := readInstruction[fileio.ScriptInstrSceTrgCk](lineBytes)
+	return fmt.Sprintf("Aot=%d, Dummy=%d, On=%d", instruction.Aot, instruction.Dummy, instruction.On)
 }
 
 func formatCutReplaceParams(lineBytes []byte) string {
-	return fmt.Sprintf("param1=%d, param2=%d", lineBytes[1], lineBytes[2])
+	instruction := readInstruction[fileio.ScriptInstrCutReplace](lineBytes)
+	return fmt.Sprintf("OldCameraId=%d, NewCameraId=%d", instruction.OldCameraId, instruction.NewCameraId)
 }
 
 func formatSceBgmtblSetParams(lineBytes []byte) string {
-	return fmt.Sprintf("param1=%d, param2=%d, param3=%d, param4=%d, param5=%d, param6=%d, param7=%d", lineBytes[1], lineBytes[2], lineBytes[3], lineBytes[4], lineBytes[5], lineBytes[6], lineBytes[7])
+	instruction := readInstruction[fileio.ScriptInstrSceBgmTblSet](lineBytes)
+	return fmt.Sprintf("RoomId=%d, StageId=%d, Data0=%d, Data1=%d", instruction.RoomId, instruction.StageId, instruction.Data0, instruction.Data1)
 }
 
 func formatPlcCntParams(lineBytes []byte) string {
-	return fmt.Sprintf("param1=%d", lineBytes[1])
+	instruction := readInstruction[fileio.ScriptInstrPlcCnt](lineBytes)
+	return fmt.Sprintf("Count=%d", instruction.Count)
 }
 
 func formatXaVolParams(lineBytes []byte) string {
-	return fmt.Sprintf("param1=%d", lineBytes[1])
+	instruction := readInstruction[fileio.ScriptInstrXaVol](lineBytes)
+	return fmt.Sprintf("Volume=%d", instruction.Volume)
 }
 
 func formatCutBeSetParams(lineBytes []byte) string {
-	return fmt.Sprintf("param1=%d, param2=%d, param3=%d", lineBytes[1], lineBytes[2], lineBytes[3])
+	instruction := readInstruction[fileio.ScriptInstrCutBeSet](lineBytes)
+	return fmt.Sprintf("CameraId=%d, Dummy=%d, On=%d", instruction.CameraId, instruction.Dummy, instruction.On)
 }
 
 func formatSceItemLostParams(lineBytes []byte) string {
-	return fmt.Sprintf("param1=%d", lineBytes[1])
+	instruction := readInstruction[fileio.ScriptInstrSceItemLost](lineBytes)
+	return fmt.Sprintf("ItemId=%d", instruction.ItemId)
 }
 
-func formatSceEsprOn2Params(lineBytes []byte) string {
-	return fmt.Sprintf("param1=%d, param2=%d, param3=%d, param4=%d, param5=%d, param6=%d, param7=%d, param8=%d, param9=%d, param10=%d, param11=%d, param12=%d, param13=%d, param14=%d, param15=%d", lineBytes[1], lineBytes[2], lineBytes[3], lineBytes[4], lineBytes[5], lineBytes[6], lineBytes[7], lineBytes[8], lineBytes[9], lineBytes[10], lineBytes[11], lineBytes[12], lineBytes[13], lineBytes[14], lineBytes[15])
+func formatKeepItemCkParams(lineBytes []byte) string {
+	instruction := readInstruction[fileio.ScriptInstrKeepItemCk](lineBytes)
+	return fmt.Sprintf("ItemId=%d", instruction.ItemId)
+}
+
+func formatWeaponChgParams(lineBytes []byte) string {
+	instruction := readInstruction[fileio.ScriptInstrWeaponChg](lineBytes)
+	return fmt.Sprintf("WeaponId=%d", instruction.WeaponId)
+}
+
+func formatSceItemGetParams(lineBytes []byte) string {
+	instruction := readInstruction[fileio.ScriptInstrSceItemGet](lineBytes)
+	return fmt.Sprintf("ItemId=%d, Amount=%d", instruction.ItemId, instruction.Amount)
+}
+
+func formatSceEsprKill2Params(lineBytes []byte) string {
+	instruction := readInstruction[fileio.ScriptInstrSceEsprKill2](lineBytes)
+	return fmt.Sprintf("Id=%d", instruction.Id)
+}
+
+func formatSceFadeSetParams(lineBytes []byte) string {
+	instruction := readInstruction[fileio.ScriptInstrSceFadeSet](lineBytes)
+	return fmt.Sprintf("Direction=%d, Color=%d, BlendMode=%d, Duration=%d",
+		instruction.Direction, instruction.Color, instruction.BlendMode, instruction.Duration)
+}
+
+func formatSceShakeOnParams(lineBytes []byte) string {
+	instruction := readInstruction[fileio.ScriptInstrSceShakeOn](lineBytes)
+	return fmt.Sprintf("Amplitude=%d, Duration=%d", instruction.Amplitude, instruction.Duration)
 }
 
 func formatPlcStopParams(lineBytes []byte) string {
@@ -506,11 +561,14 @@ func formatPlcStopParams(lineBytes []byte) string {
 }
 
 func formatLightPosSetParams(lineBytes []byte) string {
-	return fmt.Sprintf("param1=%d, param2=%d, param3=%d, param4=%d, param5=%d", lineBytes[1], lineBytes[2], lineBytes[3], lineBytes[4], lineBytes[5])
+	instruction := readInstruction[fileio.ScriptInstrLightPosSet](lineBytes)
+	return fmt.Sprintf("Dummy=%d, Index=%d, Axis=%d, Position=%d",
+		instruction.Dummy, instruction.Index, instruction.Axis, instruction.Position)
 }
 
 func formatLightKidoSetParams(lineBytes []byte) string {
-	return fmt.Sprintf("param1=%d, param2=%d, param3=%d", lineBytes[1], lineBytes[2], lineBytes[3])
+	instruction := readInstruction[fileio.ScriptInstrLightKidoSet](lineBytes)
+	return fmt.Sprintf("Index=%d, Brightness=%d", instruction.Index, instruction.Brightness)
 }
 
 func formatPartsSetParams(lineBytes []byte) string {
@@ -602,6 +660,10 @@ var OpcodeSignatures = map[byte]OpcodeSignature{
 	// Item opcodes
 	fileio.OP_ITEM_AOT_SET:    formatItemAotSetParams,
 	fileio.OP_ITEM_AOT_SET_4P: formatItemAotSet4pParams,
+	fileio.OP_KEEP_ITEM_CK:    formatKeepItemCkParams,
+	fileio.OP_SCE_ITEM_LOST:   formatSceItemLostParams,
+	fileio.OP_SCE_ITEM_GET:    formatSceItemGetParams,
+	fileio.OP_WEAPON_CHG:      formatWeaponChgParams,
 
 	// Audio opcodes
 	fileio.OP_SCE_BGM_CONTROL: formatSceBgmControlParams,
@@ -610,6 +672,8 @@ var OpcodeSignatures = map[byte]OpcodeSignature{
 	// Visual effects opcodes
 	fileio.OP_KAGE_SET:     formatKageSetParams,
 	fileio.OP_MIZU_DIV_SET: formatMizuDivSetParams,
+	fileio.OP_SCE_FADE_SET: formatSceFadeSetParams,
+	fileio.OP_SCE_SHAKE_ON: formatSceShakeOnParams,
 
 	// Additional placeholder formatters for complete coverage
 	fileio.OP_EVT_END:        formatEvtEndParams,
@@ -643,15 +707,15 @@ var OpcodeSignatures = map[byte]OpcodeSignature{
 	fileio.OP_MEMBER_COPY:    formatMemberCopyParams,
 	fileio.OP_PLC_RET:        formatPlcRetParams,
 	fileio.OP_AOT_ON:         formatAotOnParams,
+	fileio.OP_SUPER_SET:      formatSuperSetParams,
+	fileio.OP_SCE_TRG_CK:     formatSceTrgCkParams,
 	fileio.OP_CUT_REPLACE:    formatCutReplaceParams,
 	fileio.OP_SCE_BGMTBL_SET: formatSceBgmtblSetParams,
 	fileio.OP_PLC_CNT:        formatPlcCntParams,
 	fileio.OP_XA_VOL:         formatXaVolParams,
 	fileio.OP_CUT_BE_SET:     formatCutBeSetParams,
-	fileio.OP_KEEP_ITEM_CK:   formatSceItemLostParams,
-	fileio.OP_SCE_ITEM_LOST:  formatSceItemLostParams,
-	fileio.OP_SCE_ESPR_ON2:   formatSceEsprOn2Params,
-	fileio.OP_SCE_ESPR_KILL2: formatSceEsprOn2Params,
+	fileio.OP_SCE_ESPR_ON2:   formatSceEsprOnParams,
+	fileio.OP_SCE_ESPR_KILL2: formatSceEsprKill2Params,
 	fileio.OP_PLC_STOP:       formatPlcStopParams,
 	fileio.OP_LIGHT_POS_SET:  formatLightPosSetParams,
 	fileio.OP_LIGHT_KIDO_SET: formatLightKidoSetParams,
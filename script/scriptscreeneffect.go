@@ -0,0 +1,60 @@
+package script
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/render"
+)
+
+const (
+	// System flags share the bit array with the difficulty
+	SCRIPT_BIT_ARRAY_SYSTEM  = 0
+	SCRIPT_BIT_FADE_COMPLETE = 27
+)
+
+// The script doesn't wait for the fade
+// It checks the fade complete bit instead
+func (scriptDef *ScriptDef) ScriptSceFadeSet(lineData []byte, renderDef *render.RenderDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrSceFadeSet{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	color := render.DecodeFadeColor(instruction.Color)
+	durationSeconds := float64(instruction.Duration) / SCRIPT_FRAMES_PER_SECOND
+	renderDef.ScreenEffects.StartFade(int(instruction.Direction), color, int(instruction.BlendMode), durationSeconds)
+	scriptDef.updateScreenEffectBits(renderDef)
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptSceShakeOn(lineData []byte, renderDef *render.RenderDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrSceShakeOn{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	durationSeconds := float64(instruction.Duration) / SCRIPT_FRAMES_PER_SECOND
+	renderDef.ScreenEffects.StartShake(float32(instruction.Amplitude), durationSeconds)
+	return 1
+}
+
+func (scriptDef *ScriptDef) updateScreenEffectBits(renderDef *render.RenderDef) {
+	if renderDef == nil || renderDef.ScreenEffects == nil {
+		return
+	}
+
+	fadeComplete := 0
+	if renderDef.ScreenEffects.IsFadeComplete() {
+		fadeComplete = 1
+	}
+	scriptDef.SetBitArray(SCRIPT_BIT_ARRAY_SYSTEM, SCRIPT_BIT_FADE_COMPLETE, fadeComplete)
+}
+
+func (scriptDef *ScriptDef) ScriptMizuDivSet(lineData []byte, renderDef *render.RenderDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrMizuDivSet{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	renderDef.WaterPlane = render.NewWaterPlane(int(instruction.MizuDivMax))
+	return 1
+}
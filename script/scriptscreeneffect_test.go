@@ -0,0 +1,61 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/render"
+)
+
+func TestScriptSceFadeSet(t *testing.T) {
+	scriptDef := NewScriptDef()
+	renderDef := &render.RenderDef{ScreenEffects: render.NewScreenEffects()}
+
+	// Fade out to white for 30 frames
+	scriptDef.ScriptSceFadeSet([]byte{fileio.OP_SCE_FADE_SET, render.FADE_DIRECTION_OUT, render.FADE_COLOR_WHITE,
+		render.FADE_BLEND_ADDITIVE, 30, 0}, renderDef)
+
+	fade := renderDef.ScreenEffects.Fade
+	if fade.Direction != render.FADE_DIRECTION_OUT || fade.BlendMode != render.FADE_BLEND_ADDITIVE {
+		t.Errorf("Expected fade out with additive blending, got direction %d, blend mode %d", fade.Direction, fade.BlendMode)
+	}
+	if fade.Duration != 1.0 {
+		t.Errorf("Expected fade duration 1 second, got %f", fade.Duration)
+	}
+	if scriptDef.GetBitArray(SCRIPT_BIT_ARRAY_SYSTEM, SCRIPT_BIT_FADE_COMPLETE) != 0 {
+		t.Error("Fade complete bit should be cleared when the fade starts")
+	}
+
+	renderDef.ScreenEffects.Update(1.0)
+	scriptDef.updateScreenEffectBits(renderDef)
+	if scriptDef.GetBitArray(SCRIPT_BIT_ARRAY_SYSTEM, SCRIPT_BIT_FADE_COMPLETE) != 1 {
+		t.Error("Fade complete bit should be set when the fade ends")
+	}
+}
+
+func TestScriptSceShakeOn(t *testing.T) {
+	scriptDef := NewScriptDef()
+	renderDef := &render.RenderDef{ScreenEffects: render.NewScreenEffects()}
+
+	scriptDef.ScriptSceShakeOn([]byte{fileio.OP_SCE_SHAKE_ON, 4, 15}, renderDef)
+
+	shake := renderDef.ScreenEffects.Shake
+	if shake.Amplitude != 4 || shake.Duration != 0.5 {
+		t.Errorf("Expected amplitude 4 for 0.5 seconds, got %f for %f seconds", shake.Amplitude, shake.Duration)
+	}
+}
+
+func TestScriptMizuDivSet(t *testing.T) {
+	scriptDef := NewScriptDef()
+	renderDef := &render.RenderDef{}
+
+	scriptDef.ScriptMizuDivSet([]byte{fileio.OP_MIZU_DIV_SET, 80}, renderDef)
+	if renderDef.WaterPlane != render.NewWaterPlane(80) {
+		t.Errorf("Expected water level 80, got %+v", renderDef.WaterPlane)
+	}
+
+	scriptDef.ScriptMizuDivSet([]byte{fileio.OP_MIZU_DIV_SET, 0}, renderDef)
+	if renderDef.WaterPlane.Enabled {
+		t.Error("Expected water to be removed")
+	}
+}
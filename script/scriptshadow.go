@@ -0,0 +1,77 @@
+package script
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+	"github.com/OpenBiohazard2/OpenBiohazard2/render"
+	"github.com/OpenBiohazard2/OpenBiohazard2/world"
+)
+
+// A shadow with no size is hidden
+func (scriptDef *ScriptDef) ScriptKageSet(lineData []byte, renderDef *render.RenderDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrKageSet{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	key := render.ShadowKey{
+		WorkComponent: int(instruction.WorkSetComponent),
+		WorkIndex:     int(instruction.WorkSetIndex),
+	}
+	settings := render.ShadowSettings{
+		Color: [3]float32{
+			float32(instruction.Color[0]) / 255.0,
+			float32(instruction.Color[1]) / 255.0,
+			float32(instruction.Color[2]) / 255.0,
+		},
+		HalfX:   float32(instruction.HalfX),
+		HalfZ:   float32(instruction.HalfZ),
+		OffsetX: float32(instruction.OffsetX),
+		OffsetZ: float32(instruction.OffsetZ),
+	}
+	renderDef.ShadowSystem.SetShadow(key, settings)
+	return 1
+}
+
+// Places shadows on the floor under the player, enemies and objects
+// The player and enemies have a default shadow, objects only have one if the script sets it
+func UpdateShadows(gameDef *game.GameDef, renderDef *render.RenderDef) {
+	shadowSystem := renderDef.ShadowSystem
+	if shadowSystem == nil {
+		return
+	}
+
+	keys := []render.ShadowKey{{WorkComponent: WORKSET_PLAYER, WorkIndex: 0}}
+	if renderDef.SceneSystem != nil && renderDef.SceneSystem.EnemyGroupEntity != nil {
+		for _, enemyEntity := range renderDef.SceneSystem.EnemyGroupEntity.EnemyEntities {
+			keys = append(keys, render.ShadowKey{WorkComponent: WORKSET_ENEMY, WorkIndex: enemyEntity.ScriptWork.Id})
+		}
+	}
+	for key := range shadowSystem.Settings {
+		if key.WorkComponent == WORKSET_OBJECT {
+			keys = append(keys, key)
+		}
+	}
+
+	shadows := make([]render.Shadow, 0, len(keys))
+	for _, key := range keys {
+		entity := GetScriptEntity(key.WorkComponent, key.WorkIndex, gameDef, renderDef)
+		if entity == nil {
+			continue
+		}
+
+		settings, exists := shadowSystem.GetShadow(key)
+		if !exists {
+			settings = render.NewDefaultShadowSettings()
+		}
+
+		position := entity.GetPosition()
+		if gameDef.GameWorld.GameRoom != nil {
+			position[1] = world.GetFloorHeight(position, gameDef.GameWorld.GameRoom.CollisionEntities)
+		}
+		shadows = append(shadows, render.Shadow{Position: position, Settings: settings})
+	}
+	shadowSystem.Shadows = shadows
+}
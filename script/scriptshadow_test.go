@@ -0,0 +1,55 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+	"github.com/OpenBiohazard2/OpenBiohazard2/render"
+	"github.com/OpenBiohazard2/OpenBiohazard2/world"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func createShadowTestRender() *render.RenderDef {
+	return &render.RenderDef{
+		SceneSystem:  render.NewSceneSystemForTesting(),
+		ShadowSystem: &render.ShadowSystem{Settings: make(map[render.ShadowKey]render.ShadowSettings)},
+	}
+}
+
+func TestScriptKageSet(t *testing.T) {
+	scriptDef := NewScriptDef()
+	renderDef := createShadowTestRender()
+
+	// Player shadow, color (255, 0, 0), half size (200, 100), offset (10, -10)
+	lineData := []byte{fileio.OP_KAGE_SET, WORKSET_PLAYER, 0, 255, 0, 0, 200, 0, 100, 0, 10, 0, 0xf6, 0xff}
+	scriptDef.ScriptKageSet(lineData, renderDef)
+
+	settings, exists := renderDef.ShadowSystem.GetShadow(render.ShadowKey{WorkComponent: WORKSET_PLAYER, WorkIndex: 0})
+	if !exists {
+		t.Fatal("Expected player shadow to be set")
+	}
+	expected := render.ShadowSettings{Color: [3]float32{1, 0, 0}, HalfX: 200, HalfZ: 100, OffsetX: 10, OffsetZ: -10}
+	if settings != expected {
+		t.Errorf("Expected %+v, got %+v", expected, settings)
+	}
+}
+
+func TestUpdateShadows(t *testing.T) {
+	gameDef := game.NewGame(1, 0, 0)
+	gameDef.Player = game.NewPlayer(mgl32.Vec3{100, -1750, 200}, 0)
+	gameDef.GameWorld.GameRoom = &world.Room{}
+	renderDef := createShadowTestRender()
+
+	UpdateShadows(gameDef, renderDef)
+	shadows := renderDef.ShadowSystem.Shadows
+	if len(shadows) != 1 {
+		t.Fatalf("Expected only the player shadow, got %d", len(shadows))
+	}
+	if shadows[0].Position != (mgl32.Vec3{100, fileio.FLOOR_HEIGHT_UNIT, 200}) {
+		t.Errorf("Expected shadow on the floor, got %v", shadows[0].Position)
+	}
+	if shadows[0].Settings != render.NewDefaultShadowSettings() {
+		t.Errorf("Expected default shadow, got %+v", shadows[0].Settings)
+	}
+}
@@ -0,0 +1,99 @@
+package script
+
+import (
+	"bytes"
+	"encoding/binary"
+	"log"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	// Set when no voice line is playing
+	SCRIPT_BIT_XA_COMPLETE = 28
+)
+
+// Plays a sound effect at a position in the room
+// Edt selects the program in the low byte and the tone in the high byte
+func (scriptDef *ScriptDef) ScriptSeOn(lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrSeOn{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	bank := game.SOUND_BANK_ROOM
+	if instruction.Vab == game.SOUND_BANK_CORE {
+		bank = game.SOUND_BANK_CORE
+	}
+	program := int(instruction.Edt & 0xFF)
+	tone := int(instruction.Edt >> 8)
+	position := mgl32.Vec3{float32(instruction.X), float32(instruction.Y), float32(instruction.Z)}
+	if !gameDef.PlaySoundEffect(bank, program, tone, position) {
+		log.Printf("SCRIPT: Sound effect %d in bank %d is not available", instruction.Edt, instruction.Vab)
+	}
+	return 1
+}
+
+// Operation 0 only changes the volume
+func (scriptDef *ScriptDef) ScriptSceBgmControl(lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrSceBgmControl{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	if instruction.Operation == game.BGM_OPERATION_NOP {
+		gameDef.BGM.SetVolume(int(instruction.Type), int(instruction.LeftVolume), int(instruction.RightVolume))
+		return 1
+	}
+	gameDef.BGM.Control(int(instruction.Id), int(instruction.Operation))
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptSceBgmTblSet(lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrSceBgmTblSet{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	entry := game.BGMTableEntry{
+		Tracks: [game.BGM_CHANNEL_COUNT]int{
+			int(instruction.Data0 & 0xFF),
+			int(instruction.Data0 >> 8),
+			int(instruction.Data1 & 0xFF),
+		},
+	}
+	gameDef.BGM.SetRoomTracks(int(instruction.StageId)+1, int(instruction.RoomId), entry)
+	return 1
+}
+
+// The script doesn't wait for the voice line
+// It checks the XA complete bit instead
+func (scriptDef *ScriptDef) ScriptXaOn(lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrXaOn{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	gameDef.Voice.Play(gameDef.StageId, int(instruction.Id), gameDef.RoomScript.Messages)
+	scriptDef.updateVoiceBits(gameDef)
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptXaVol(lineData []byte, gameDef *game.GameDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrXaVol{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	gameDef.Voice.SetVolume(int(instruction.Volume))
+	return 1
+}
+
+func (scriptDef *ScriptDef) updateVoiceBits(gameDef *game.GameDef) {
+	if gameDef == nil || gameDef.Voice == nil {
+		return
+	}
+
+	xaComplete := 0
+	if gameDef.Voice.IsComplete() {
+		xaComplete = 1
+	}
+	scriptDef.SetBitArray(SCRIPT_BIT_ARRAY_SYSTEM, SCRIPT_BIT_XA_COMPLETE, xaComplete)
+}
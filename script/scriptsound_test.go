@@ -0,0 +1,70 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/audio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+)
+
+func TestScriptSeOnWithoutSoundBank(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := game.NewGame(1, 0, 0)
+
+	lineData := []byte{fileio.OP_SE_ON, 1, 2, 0, 0, 0, 0x10, 0, 0, 0, 0x20, 0}
+	if result := scriptDef.ScriptSeOn(lineData, gameDef); result != 1 {
+		t.Errorf("Expected script to continue, got %d", result)
+	}
+}
+
+func TestScriptSceBgmTblSet(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := game.NewGame(1, 0, 0)
+
+	lineData := []byte{fileio.OP_SCE_BGMTBL_SET, 0, 5, 0, 0x03, 0x04, 0xFF, 0}
+	scriptDef.ScriptSceBgmTblSet(lineData, gameDef)
+
+	room := game.RoomMapKey{StageId: 1, RoomId: 5}
+	if track := gameDef.BGM.GetRoomTrack(room, game.BGM_CHANNEL_MAIN); track != 3 {
+		t.Errorf("Expected main track 3, got %d", track)
+	}
+	if track := gameDef.BGM.GetRoomTrack(room, game.BGM_CHANNEL_SUB0); track != 4 {
+		t.Errorf("Expected sub track 4, got %d", track)
+	}
+	if track := gameDef.BGM.GetRoomTrack(room, game.BGM_CHANNEL_SUB1); track != game.BGM_NO_TRACK {
+		t.Errorf("Expected no sub track 1, got %d", track)
+	}
+}
+
+func TestScriptXaOnSetsCompleteBit(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := game.NewGame(1, 0, 0)
+	gameDef.Voice.LoadVoice = func(stageId int, xaId int) (*audio.Sound, error) {
+		return audio.NewSound(make([]int16, audio.SAMPLE_RATE*2), 2, audio.SAMPLE_RATE), nil
+	}
+
+	scriptDef.ScriptXaOn([]byte{fileio.OP_XA_ON, 0, 4, 0}, gameDef)
+	if gameDef.Voice.XaId != 4 || !gameDef.Voice.IsPlaying {
+		t.Fatalf("Expected voice 4 to play, got %+v", gameDef.Voice)
+	}
+	if scriptDef.GetBitArray(SCRIPT_BIT_ARRAY_SYSTEM, SCRIPT_BIT_XA_COMPLETE) != 0 {
+		t.Error("Expected XA complete bit to be cleared while the voice plays")
+	}
+
+	gameDef.Voice.Stop()
+	scriptDef.updateVoiceBits(gameDef)
+	if scriptDef.GetBitArray(SCRIPT_BIT_ARRAY_SYSTEM, SCRIPT_BIT_XA_COMPLETE) != 1 {
+		t.Error("Expected XA complete bit to be set after the voice stops")
+	}
+}
+
+func TestScriptXaVol(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := game.NewGame(1, 0, 0)
+
+	scriptDef.ScriptXaVol([]byte{fileio.OP_XA_VOL, 200}, gameDef)
+	if gameDef.Voice.Volume != 1.0 {
+		t.Errorf("Expected volume to be clamped to 1.0, got %f", gameDef.Voice.Volume)
+	}
+}
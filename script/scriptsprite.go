@@ -3,28 +3,121 @@ package script
 import (
 	"bytes"
 	"encoding/binary"
+	"log"
 
 	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
 	"github.com/OpenBiohazard2/OpenBiohazard2/game"
 	"github.com/OpenBiohazard2/OpenBiohazard2/render"
+	"github.com/go-gl/mathgl/mgl32"
 )
 
+const (
+	ESPR_CONTROL_PAUSE  = 0
+	ESPR_CONTROL_RESUME = 1
+	ESPR_CONTROL_KILL   = 2
+)
+
+// ESPR_ON2 has the same layout as ESPR_ON
 func (scriptDef *ScriptDef) ScriptSceEsprOn(lineData []byte, gameDef *game.GameDef, renderDef *render.RenderDef) int {
 	byteArr := bytes.NewBuffer(lineData)
 	scriptSprite := fileio.ScriptInstrSceEsprOn{}
 	binary.Read(byteArr, binary.LittleEndian, &scriptSprite)
 
 	gameDef.GameWorld.AotManager.AddScriptSprite(scriptSprite)
-	renderDef.AddSprite(scriptSprite)
+	position := mgl32.Vec3{float32(scriptSprite.X), float32(scriptSprite.Y), float32(scriptSprite.Z)}
+	spawnEffectSprite(renderDef, scriptSprite.Id, scriptSprite.Type, scriptSprite.Work, position)
 	return 1
 }
 
-func (scriptDef *ScriptDef) ScriptSceEsprKill(lineData []byte) int {
+// The second vector is the speed of the sprite in units per frame
+func (scriptDef *ScriptDef) ScriptSceEspr3dOn(lineData []byte, renderDef *render.RenderDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrSceEspr3DOn{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	position := mgl32.Vec3{float32(instruction.Vector1[0]), float32(instruction.Vector1[1]), float32(instruction.Vector1[2])}
+	sprite := spawnEffectSprite(renderDef, instruction.Id, instruction.Type, instruction.Work, position)
+	if sprite != nil {
+		speed := mgl32.Vec3{float32(instruction.Vector2[0]), float32(instruction.Vector2[1]), float32(instruction.Vector2[2])}
+		sprite.Velocity = sprite.Velocity.Add(speed.Mul(render.SPRITE_FRAMES_PER_SECOND))
+	}
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptSceEsprControl(lineData []byte, renderDef *render.RenderDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrSceEsprControl{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
+
+	spriteGroup := renderDef.SceneSystem.SpriteGroupEntity
+	sprite := spriteGroup.GetSprite(instruction.Id)
+	if sprite == nil || sprite.Type != instruction.Type {
+		log.Printf("SCRIPT: Effect sprite %d with type %d doesn't exist", instruction.Id, instruction.Type)
+		return 1
+	}
+
+	switch instruction.Action {
+	case ESPR_CONTROL_PAUSE:
+		sprite.IsPaused = true
+	case ESPR_CONTROL_RESUME:
+		sprite.IsPaused = false
+	case ESPR_CONTROL_KILL:
+		spriteGroup.KillSprite(instruction.Id)
+	default:
+		log.Printf("SCRIPT: Unknown effect sprite action %d", instruction.Action)
+	}
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptSceEsprKill(lineData []byte, renderDef *render.RenderDef) int {
 	byteArr := bytes.NewBuffer(lineData)
 	instruction := fileio.ScriptInstrSceEsprKill{}
 	binary.Read(byteArr, binary.LittleEndian, &instruction)
 
-	// TODO: implement
+	spriteGroup := renderDef.SceneSystem.SpriteGroupEntity
+	sprite := spriteGroup.GetSprite(instruction.Id)
+	if sprite != nil && sprite.Type == instruction.Type {
+		spriteGroup.KillSprite(instruction.Id)
+	}
+	return 1
+}
+
+func (scriptDef *ScriptDef) ScriptSceEsprKill2(lineData []byte, renderDef *render.RenderDef) int {
+	byteArr := bytes.NewBuffer(lineData)
+	instruction := fileio.ScriptInstrSceEsprKill2{}
+	binary.Read(byteArr, binary.LittleEndian, &instruction)
 
+	renderDef.SceneSystem.SpriteGroupEntity.KillSprite(instruction.Id)
 	return 1
 }
+
+// The low byte of work is the work set component and the high byte is the index
+func spawnEffectSprite(renderDef *render.RenderDef, id uint8, spriteType uint8, work uint16, position mgl32.Vec3) *render.EffectSprite {
+	sprite := renderDef.SceneSystem.SpriteGroupEntity.SpawnSprite(id, spriteType, position)
+	if sprite == nil {
+		log.Printf("SCRIPT: No effect sprite data for type %d", spriteType)
+		return nil
+	}
+	sprite.WorkComponent = int(work & 0xff)
+	sprite.WorkIndex = int(work >> 8)
+	return sprite
+}
+
+// Moves effect sprites with the entity they are attached to
+func UpdateEffectSprites(gameDef *game.GameDef, renderDef *render.RenderDef) {
+	spriteGroup := renderDef.SceneSystem.SpriteGroupEntity
+	if spriteGroup == nil {
+		return
+	}
+
+	for _, sprite := range spriteGroup.Instances {
+		if sprite.WorkComponent == 0 {
+			continue
+		}
+		entity := GetScriptEntity(sprite.WorkComponent, sprite.WorkIndex, gameDef, renderDef)
+		if entity == nil {
+			continue
+		}
+		sprite.AnchorPosition = entity.GetPosition()
+	}
+}
@@ -0,0 +1,77 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+	"github.com/OpenBiohazard2/OpenBiohazard2/render"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func createSpriteTestRender() *render.RenderDef {
+	renderDef := &render.RenderDef{SceneSystem: render.NewSceneSystemForTesting()}
+	renderDef.SceneSystem.SpriteGroupEntity = &render.SpriteGroupEntity{
+		SpriteTextureIndexMap: map[int]int{8: 0},
+		SpriteData:            []fileio.SpriteData{{Id: 8}},
+		Instances:             make(map[uint8]*render.EffectSprite),
+	}
+	return renderDef
+}
+
+func TestScriptSceEsprOnAndKill(t *testing.T) {
+	scriptDef := NewScriptDef()
+	gameDef := game.NewGame(1, 0, 0)
+	renderDef := createSpriteTestRender()
+	spriteGroup := renderDef.SceneSystem.SpriteGroupEntity
+
+	// Id 3, type 8, attached to the player, position (100, -200, 300)
+	lineData := []byte{fileio.OP_SCE_ESPR_ON, 0, 3, 8, WORKSET_PLAYER, 0, 0, 0, 100, 0, 0x38, 0xff, 0x2c, 0x01, 0, 0}
+	scriptDef.ScriptSceEsprOn(lineData, gameDef, renderDef)
+
+	sprite := spriteGroup.GetSprite(3)
+	if sprite == nil {
+		t.Fatal("Expected sprite 3 to be spawned")
+	}
+	if sprite.Origin != (mgl32.Vec3{100, -200, 300}) {
+		t.Errorf("Expected origin (100, -200, 300), got %v", sprite.Origin)
+	}
+
+	gameDef.Player = game.NewPlayer(mgl32.Vec3{10, 0, 20}, 0)
+	UpdateEffectSprites(gameDef, renderDef)
+	if sprite.GetPosition() != (mgl32.Vec3{110, -200, 320}) {
+		t.Errorf("Expected sprite to follow the player, got %v", sprite.GetPosition())
+	}
+
+	// Wrong type doesn't kill the sprite
+	scriptDef.ScriptSceEsprKill([]byte{fileio.OP_SCE_ESPR_KILL, 3, 9, 0, 0}, renderDef)
+	if spriteGroup.GetSprite(3) == nil {
+		t.Error("Expected sprite with a different type to stay")
+	}
+	scriptDef.ScriptSceEsprKill([]byte{fileio.OP_SCE_ESPR_KILL, 3, 8, 0, 0}, renderDef)
+	if spriteGroup.GetSprite(3) != nil {
+		t.Error("Expected sprite to be killed")
+	}
+}
+
+func TestScriptSceEsprControl(t *testing.T) {
+	scriptDef := NewScriptDef()
+	renderDef := createSpriteTestRender()
+	spriteGroup := renderDef.SceneSystem.SpriteGroupEntity
+	spriteGroup.SpawnSprite(1, 8, mgl32.Vec3{})
+
+	scriptDef.ScriptSceEsprControl([]byte{fileio.OP_SCE_ESPR_CONTROL, 1, 8, ESPR_CONTROL_PAUSE, 0, 0}, renderDef)
+	if !spriteGroup.GetSprite(1).IsPaused {
+		t.Error("Expected sprite to be paused")
+	}
+
+	scriptDef.ScriptSceEsprControl([]byte{fileio.OP_SCE_ESPR_CONTROL, 1, 8, ESPR_CONTROL_RESUME, 0, 0}, renderDef)
+	if spriteGroup.GetSprite(1).IsPaused {
+		t.Error("Expected sprite to be resumed")
+	}
+
+	scriptDef.ScriptSceEsprKill2([]byte{fileio.OP_SCE_ESPR_KILL2, 1}, renderDef)
+	if spriteGroup.GetSprite(1) != nil {
+		t.Error("Expected sprite to be killed by id")
+	}
+}
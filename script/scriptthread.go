@@ -16,6 +16,8 @@ type ScriptThread struct {
 	LevelState             []*LevelState
 	OverrideProgramCounter bool
 	FunctionIds            []int // Only used for debugging
+	WaitingForPlayer       bool  // Thread is blocked until the player reaches the PLC_DEST destination
+	WaitingForMessage      bool  // Thread is blocked until the MESSAGE_ON message is closed
 }
 
 type LevelState struct {
@@ -106,6 +108,8 @@ func (thread *ScriptThread) Reset() {
 
 	thread.OverrideProgramCounter = false
 	thread.FunctionIds = []int{-1}
+	thread.WaitingForPlayer = false
+	thread.WaitingForMessage = false
 }
 
 func (thread *ScriptThread) IncrementProgramCounter(opcode byte) {
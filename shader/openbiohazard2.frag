@@ -5,9 +5,19 @@ uniform int gameState;
 uniform sampler2D diffuse;
 uniform vec3 envLight;
 uniform vec4 debugColor;
+// point lights
+uniform vec3 lightPositions[3];
+uniform vec3 lightColors[3];
+uniform float lightBrightness[3];
+// water surface
+uniform int waterEnabled;
+uniform float waterHeight;
+uniform float waterVisibleDepth;
+uniform vec3 waterColor;
 
 in vec2 fragTexCoord;
 in vec3 fragNormal;
+in vec3 fragPosition;
 
 out vec4 fragColor;
 
@@ -29,10 +39,43 @@ void renderBackgroundTransparent() {
   }
 }
 
+// Brightness is the distance the light reaches
+vec3 calculatePointLights() {
+  vec3 normal = normalize(fragNormal);
+  vec3 lightColor = vec3(0.0);
+  for (int i = 0; i < 3; i++) {
+    if (lightBrightness[i] <= 0.0) {
+      continue;
+    }
+    vec3 lightDirection = lightPositions[i] - fragPosition;
+    float attenuation = clamp(1.0 - length(lightDirection) / lightBrightness[i], 0.0, 1.0);
+    float diffuseFactor = max(dot(normal, normalize(lightDirection)), 0.0);
+    lightColor += lightColors[i] * diffuseFactor * attenuation;
+  }
+  return lightColor;
+}
+
+// Tint the part of the model under the water surface
+// Positive y is down, so anything below the surface has a larger y
+vec3 applyWaterTint(vec3 color) {
+  if (waterEnabled == 0 || fragPosition.y <= waterHeight) {
+    return color;
+  }
+  return mix(color, waterColor, 0.5);
+}
+
+// The water hides the part of the model deeper than the visible depth
+bool isClippedByWater() {
+  return waterEnabled != 0 && fragPosition.y > waterHeight + waterVisibleDepth;
+}
+
 void renderEntity() {
+  if (isClippedByWater()) {
+    discard;
+  }
   vec4 diffuseColor = texture(diffuse, fragTexCoord.st);
-  vec3 lightColor = envLight;
-  fragColor = vec4(vec3(diffuseColor) * lightColor, 1.0);
+  vec3 lightColor = min(envLight + calculatePointLights(), vec3(1.0));
+  fragColor = vec4(applyWaterTint(vec3(diffuseColor) * lightColor), 1.0);
   gl_FragDepth = gl_FragCoord.z;
 }
 
@@ -48,6 +91,14 @@ void renderSolidColor() {
   gl_FragDepth = gl_FragCoord.z;
 }
 
+// Blob that fades out towards the edge
+void renderShadow() {
+  float distanceFromCenter = length(fragTexCoord * 2.0 - 1.0);
+  float alpha = 1.0 - smoothstep(0.5, 1.0, distanceFromCenter);
+  fragColor = vec4(debugColor.rgb, alpha);
+  gl_FragDepth = gl_FragCoord.z;
+}
+
 void renderMainGame() {
   switch (renderType) {
     case -1:
@@ -68,6 +119,12 @@ void renderMainGame() {
     case 5:
       renderItem();
       break;
+    case 6:
+      renderSolidColor();
+      break;
+    case 7:
+      renderShadow();
+      break;
   }
 }
 
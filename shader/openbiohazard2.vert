@@ -9,11 +9,14 @@ uniform int gameState;
 uniform mat4 projection;
 uniform mat4 view;
 uniform mat4 model;
+// screen shake
+uniform vec2 screenOffset;
 // animation
 uniform mat4 boneOffset;
 
 out vec2 fragTexCoord;
 out vec3 fragNormal;
+out vec3 fragPosition;
 
 void renderBackground2D() {
   gl_Position = vec4(position, 1.0);
@@ -21,11 +24,14 @@ void renderBackground2D() {
 }
 
 void renderEntity() {
-  vec4 modelPos = model * boneOffset * vec4(position, 1.0);
+  mat4 skinnedModel = model * boneOffset;
+  vec4 modelPos = skinnedModel * vec4(position, 1.0);
   gl_Position = projection * view * modelPos;
   fragTexCoord = vertTexCoord;
 
-  fragNormal = mat3(transpose(inverse(model))) * vertNormal;
+  // The normal turns with the bone like the position
+  fragNormal = mat3(transpose(inverse(skinnedModel))) * vertNormal;
+  fragPosition = modelPos.xyz;
 }
 
 void renderSprite() {
@@ -63,7 +69,14 @@ void renderMainGame() {
     case 5:
       renderItem();
       break;
+    case 6:
+      renderBackground2D();
+      break;
+    case 7:
+      renderSprite();
+      break;
   }
+  gl_Position.xy += screenOffset * gl_Position.w;
 }
 
 void main() {
@@ -7,13 +7,29 @@ import (
 	"github.com/go-gl/mathgl/mgl32"
 )
 
+const (
+	MAX_POINT_LIGHTS = 3
+)
+
 // UniformLocations caches OpenGL uniform locations for performance
 type UniformLocations struct {
 	// Main rendering uniforms
-	GameState  int32
-	View       int32
-	Projection int32
-	EnvLight   int32
+	GameState    int32
+	View         int32
+	Projection   int32
+	EnvLight     int32
+	ScreenOffset int32 // Screen shake
+
+	// Point light uniforms
+	LightPositions  int32
+	LightColors     int32
+	LightBrightness int32
+
+	// Water plane uniforms
+	WaterEnabled      int32
+	WaterHeight       int32
+	WaterVisibleDepth int32
+	WaterColor        int32
 
 	// Entity rendering uniforms
 	RenderType int32
@@ -80,6 +96,32 @@ func (ss *ShaderSystem) SetEnvironmentLight(envLight [3]float32) {
 	gl.Uniform3fv(ss.UniformLocations.EnvLight, 1, &envLight[0])
 }
 
+// SetScreenOffset sets the screen offset uniform
+func (ss *ShaderSystem) SetScreenOffset(offset mgl32.Vec2) {
+	gl.Uniform2f(ss.UniformLocations.ScreenOffset, offset[0], offset[1])
+}
+
+// SetPointLights sets the position, color and brightness of every point light
+func (ss *ShaderSystem) SetPointLights(positions [MAX_POINT_LIGHTS][3]float32, colors [MAX_POINT_LIGHTS][3]float32,
+	brightness [MAX_POINT_LIGHTS]float32) {
+	gl.Uniform3fv(ss.UniformLocations.LightPositions, MAX_POINT_LIGHTS, &positions[0][0])
+	gl.Uniform3fv(ss.UniformLocations.LightColors, MAX_POINT_LIGHTS, &colors[0][0])
+	gl.Uniform1fv(ss.UniformLocations.LightBrightness, MAX_POINT_LIGHTS, &brightness[0])
+}
+
+// SetWaterPlane sets the height and color of the water surface
+// Geometry deeper than the visible depth under the surface is clipped
+func (ss *ShaderSystem) SetWaterPlane(enabled bool, height float32, visibleDepth float32, color [3]float32) {
+	waterEnabled := int32(0)
+	if enabled {
+		waterEnabled = 1
+	}
+	gl.Uniform1i(ss.UniformLocations.WaterEnabled, waterEnabled)
+	gl.Uniform1f(ss.UniformLocations.WaterHeight, height)
+	gl.Uniform1f(ss.UniformLocations.WaterVisibleDepth, visibleDepth)
+	gl.Uniform3fv(ss.UniformLocations.WaterColor, 1, &color[0])
+}
+
 // SetRenderType sets the render type uniform
 func (ss *ShaderSystem) SetRenderType(renderType int32) {
 	gl.Uniform1i(ss.UniformLocations.RenderType, renderType)
@@ -119,6 +161,18 @@ func (ss *ShaderSystem) cacheUniformLocations() {
 	ss.UniformLocations.View = gl.GetUniformLocation(programShader, gl.Str("view\x00"))
 	ss.UniformLocations.Projection = gl.GetUniformLocation(programShader, gl.Str("projection\x00"))
 	ss.UniformLocations.EnvLight = gl.GetUniformLocation(programShader, gl.Str("envLight\x00"))
+	ss.UniformLocations.ScreenOffset = gl.GetUniformLocation(programShader, gl.Str("screenOffset\x00"))
+
+	// Point light uniforms
+	ss.UniformLocations.LightPositions = gl.GetUniformLocation(programShader, gl.Str("lightPositions\x00"))
+	ss.UniformLocations.LightColors = gl.GetUniformLocation(programShader, gl.Str("lightColors\x00"))
+	ss.UniformLocations.LightBrightness = gl.GetUniformLocation(programShader, gl.Str("lightBrightness\x00"))
+
+	// Water plane uniforms
+	ss.UniformLocations.WaterEnabled = gl.GetUniformLocation(programShader, gl.Str("waterEnabled\x00"))
+	ss.UniformLocations.WaterHeight = gl.GetUniformLocation(programShader, gl.Str("waterHeight\x00"))
+	ss.UniformLocations.WaterVisibleDepth = gl.GetUniformLocation(programShader, gl.Str("waterVisibleDepth\x00"))
+	ss.UniformLocations.WaterColor = gl.GetUniformLocation(programShader, gl.Str("waterColor\x00"))
 
 	// Entity rendering uniforms
 	ss.UniformLocations.RenderType = gl.GetUniformLocation(programShader, gl.Str("renderType\x00"))
@@ -98,6 +98,7 @@ func TestShaderSystem_Methods(t *testing.T) {
 	_ = ss.SetViewMatrix
 	_ = ss.SetProjectionMatrix
 	_ = ss.SetEnvironmentLight
+	_ = ss.SetPointLights
 	_ = ss.GetUniformLocations
 }
 
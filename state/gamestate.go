@@ -7,16 +7,21 @@ import (
 	"os"
 	"time"
 
+	"github.com/OpenBiohazard2/OpenBiohazard2/audio"
 	"github.com/OpenBiohazard2/OpenBiohazard2/client"
 	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
 	"github.com/OpenBiohazard2/OpenBiohazard2/game"
 	"github.com/OpenBiohazard2/OpenBiohazard2/render"
 	"github.com/OpenBiohazard2/OpenBiohazard2/resource"
 	"github.com/OpenBiohazard2/OpenBiohazard2/script"
+	"github.com/OpenBiohazard2/OpenBiohazard2/ui_render"
 	"github.com/OpenBiohazard2/OpenBiohazard2/world"
 )
 
-var enableDebugDump = false // only enabled for development
+const ROOM_FADE_IN_SECONDS = 0.5
+
+var enableDebugDump = false      // only enabled for development
+var enableScriptCoverage = false // only enabled for development
 
 type MainGameStateInput struct {
 	GameDef        *game.GameDef
@@ -31,6 +36,9 @@ type MainGameRender struct {
 	PlayerEntity            *render.PlayerEntity
 	DebugEntities           []*render.DebugEntity
 	CameraSwitchDebugEntity *render.DebugEntity
+	UIRenderer              *ui_render.UIRenderer
+	MenuTextImages          []*resource.Image16Bit // Includes the font for messages
+	CoreSpriteData          []fileio.SpriteData
 }
 
 type DebugDumpJson struct {
@@ -46,6 +54,17 @@ func NewMainGameStateInput(renderDef *render.RenderDef, gameDef *game.GameDef) *
 	scriptDef.SetBitArray(0, 25, game.DIFFICULTY_EASY)
 	// Set camera id
 	scriptDef.SetScriptVariable(26, 0)
+	if enableScriptCoverage {
+		scriptDef.EnableCoverage()
+	}
+
+	// Core sounds such as footsteps are shared by every room
+	coreSoundOutput, err := resource.LoadSoundBank(resource.CORE_SOUND_HEADER, resource.CORE_SOUND_DATA)
+	if err != nil {
+		log.Printf("Warning: failed to load core sounds: %v", err)
+	} else {
+		gameDef.CoreSoundBank = audio.NewSampler(coreSoundOutput.VABHeaderOutput, coreSoundOutput.VABDataOutput)
+	}
 
 	return &MainGameStateInput{
 		GameDef:        gameDef,
@@ -63,7 +82,7 @@ func NewMainGameRender(renderDef *render.RenderDef) *MainGameRender {
 
 	// Core sprite file has sprite ids 0-7
 	// All other sprites are loaded based on the room
-	_, err = fileio.LoadESPFile(resource.CORE_SPRITE_FILE)
+	coreSpriteOutput, err := fileio.LoadESPFile(resource.CORE_SPRITE_FILE)
 	if err != nil {
 		log.Fatal("Error loading core sprite file: ", err)
 	}
@@ -79,6 +98,9 @@ func NewMainGameRender(renderDef *render.RenderDef) *MainGameRender {
 		PlayerEntity:            render.NewPlayerEntity(pldOutput),
 		DebugEntities:           make([]*render.DebugEntity, 0),
 		CameraSwitchDebugEntity: nil,
+		CoreSpriteData:          coreSpriteOutput.SpriteData,
+		UIRenderer:              ui_render.NewUIRenderer(renderDef),
+		MenuTextImages:          resource.LoadTIMImages(resource.MENU_TEXT_FILE),
 	}
 }
 
@@ -113,15 +135,37 @@ func loadRoomState(mainGameStateInput *MainGameStateInput) {
 	gameDef.RoomScript = gameDef.NewRoomScript(rdtOutput)
 	gameDef.GameWorld.LoadNewRoom(rdtOutput)
 	mainGameRender.RenderRoom = render.NewRenderRoom(rdtOutput)
+	gameDef.RoomSoundBank = nil
+	if rdtOutput.RoomVABData != nil {
+		gameDef.RoomSoundBank = audio.NewSampler(rdtOutput.RoomVABData.VABHeaderOutput, rdtOutput.RoomVABData.VABDataOutput)
+	}
+	gameDef.SetRoomReverb(int(rdtOutput.Header.NumReverb))
 
 	// Initialize room model objects
 	renderDef.SceneSystem.ItemGroupEntity.ItemTextureData = mainGameRender.RenderRoom.ItemTextureData
 	renderDef.SceneSystem.ItemGroupEntity.ItemModelData = mainGameRender.RenderRoom.ItemModelData
 
+	renderDef.SceneSystem.DoorGroupEntity.ClearDoors()
+	renderDef.ShadowSystem.Reset()
+	renderDef.WaterPlane = render.WaterPlane{}
+
 	// Initialize sprite textures
-	renderDef.SceneSystem.SpriteGroupEntity = render.NewSpriteGroupEntity(mainGameRender.RenderRoom.SpriteData)
+	// Core sprites are shared by every room
+	spriteData := append(append([]fileio.SpriteData{}, mainGameRender.CoreSpriteData...), mainGameRender.RenderRoom.SpriteData...)
+	if renderDef.SceneSystem.SpriteGroupEntity != nil {
+		renderDef.SceneSystem.SpriteGroupEntity.DeleteSpriteGroupEntity()
+	}
+	renderDef.SceneSystem.SpriteGroupEntity = render.NewSpriteGroupEntity(spriteData)
+
+	// Fade in after going through a door
+	// The room script can replace this with its own fade
+	renderDef.ScreenEffects.StartFade(render.FADE_DIRECTION_IN, [3]float32{0, 0, 0}, render.FADE_BLEND_ALPHA, ROOM_FADE_IN_SECONDS)
+	renderDef.ScreenEffects.StopShake()
 
+	gameDef.LoadRoomAotStates()
 	initScriptOnRoomLoad(scriptDef, gameDef, renderDef)
+	// The init script can change the music table for the room
+	gameDef.BGM.EnterRoom(gameDef.StageId, gameDef.RoomId)
 
 	mainGameRender.DebugEntities = render.BuildAllDebugEntities(gameDef.GameWorld)
 }
@@ -129,8 +173,12 @@ func loadRoomState(mainGameStateInput *MainGameStateInput) {
 func initScriptOnRoomLoad(scriptDef *script.ScriptDef, gameDef *game.GameDef, renderDef *render.RenderDef) {
 	// Reset all state
 	scriptDef.Reset()
+	gameDef.MessageBox.Close()
 
 	gameRoom := gameDef.RoomScript
+	if scriptDef.Coverage != nil {
+		scriptDef.Coverage.BeginRoom(gameDef.StageId, gameDef.RoomId, gameRoom)
+	}
 
 	// Run initial script once when the room loads
 	threadNum := 0
@@ -167,7 +215,8 @@ func updateCameraView(mainGameRender *MainGameRender, gameDef *game.GameDef) {
 	renderDef.ViewSystem.ViewMatrix = renderDef.ViewSystem.Camera.BuildViewMatrix()
 
 	// Update lighting
-	renderDef.EnvironmentLight = render.BuildEnvironmentLight(mainGameRender.RenderRoom.LightData[gameDef.CameraId])
+	// This also undoes any changes made to the lights by the script
+	renderDef.CameraLights = render.BuildCameraLights(mainGameRender.RenderRoom.LightData[gameDef.CameraId])
 }
 
 func updateRoomBackroundImage(mainGameRender *MainGameRender, gameDef *game.GameDef) {
@@ -225,6 +274,12 @@ func runGameLoop(mainGameStateInput *MainGameStateInput, gameStateManager *GameS
 		}
 	}
 
+	if scriptDef.Coverage != nil {
+		if windowHandler.InputHandler.IsActive(client.DEBUG_DUMP) {
+			dumpScriptCoverage(scriptDef.Coverage)
+		}
+	}
+
 	// Update screen
 	playerEntity.UpdatePlayerEntity(gameDef.Player, gameDef.Player.PoseNumber)
 
@@ -236,24 +291,58 @@ func runGameLoop(mainGameStateInput *MainGameStateInput, gameStateManager *GameS
 	}
 	renderDef.RenderFrame(*playerEntity, debugEntitiesRender, timeElapsedSeconds)
 
+	// Footsteps follow the walk animation
+	animation := playerEntity.Animation
+	if gameDef.Footsteps.Update(animation.CurPose, animation.FrameIndex, playerEntity.GetPoseFrameCount(animation.CurPose)) {
+		gameDef.PlayFootstep()
+	}
+
+	gameDef.Voice.Update(timeElapsedSeconds)
+	gameDef.BGM.Update()
+	subtitle := gameDef.Voice.GetSubtitle()
+	if gameDef.MessageBox.IsOpen || subtitle != "" {
+		gameDef.MessageBox.Update(timeElapsedSeconds)
+		mainGameRender.UIRenderer.GenerateMessageImage(mainGameRender.MenuTextImages, gameDef.MessageBox, subtitle)
+		renderDef.RenderOverlayVideoBuffer()
+	}
+
 	inputHandler := NewInputHandler(windowHandler, gameStateManager)
 	inputHandler.HandleAllInput(gameDef, timeElapsedSeconds, gameDef.GameWorld)
 	gameDef.HandleCameraSwitch(gameDef.Player.Position)
 	gameDef.HandleRoomSwitch(gameDef.Player.Position)
+	script.UpdateAotSupers(gameDef, renderDef)
+	script.UpdateDoorModels(gameDef, renderDef)
+	script.UpdateEffectSprites(gameDef, renderDef)
+	script.UpdateShadows(gameDef, renderDef)
 	handleEventTrigger(scriptDef, gameDef)
 
+	gameDef.Player.UpdateScriptControl(timeElapsedSeconds)
+	script.UpdateScriptedMovement(timeElapsedSeconds, gameDef, renderDef)
 	scriptDef.RunScript(gameDef.RoomScript.RoomScriptData, timeElapsedSeconds, gameDef, renderDef)
 }
 
+func dumpScriptCoverage(coverage *script.ScriptCoverage) {
+	const layout = "01-02-2006"
+	t := time.Now()
+	coverageOutputFilename := fmt.Sprintf("scriptcoverage-" + t.Format(layout) + ".txt")
+
+	file, err := os.Create(coverageOutputFilename)
+	if err != nil {
+		log.Fatal("Error writing to ", coverageOutputFilename)
+	}
+	defer file.Close()
+
+	if err := coverage.WriteReport(file); err != nil {
+		log.Fatal("Failed to write script coverage: ", err)
+	}
+	fmt.Printf("Dumped script coverage to %v\n", coverageOutputFilename)
+}
+
 func handleEventTrigger(scriptDef *script.ScriptDef, gameDef *game.GameDef) {
-	// Handles events like cutscenes
-	aot := gameDef.GameWorld.AotManager.GetAotTriggerNearPlayer(gameDef.Player.Position)
+	// Handles events like cutscenes and examining things
+	aot := gameDef.GameWorld.AotManager.GetAotTriggerNearPlayer(gameDef.Player.Position, gameDef.ActionPressed)
+	gameDef.ActionPressed = false
 	if aot != nil {
-		if aot.Header.Id == world.AOT_EVENT {
-			threadNum := aot.Data[0]
-			eventNum := aot.Data[3]
-			lineData := []byte{fileio.OP_EVT_EXEC, threadNum, 0, eventNum}
-			scriptDef.ScriptEvtExec(lineData, gameDef.RoomScript.RoomScriptData)
-		}
+		scriptDef.ExecuteAotEvent(aot, gameDef)
 	}
 }
@@ -10,6 +10,7 @@ const (
 	GAME_STATE_INVENTORY    = 2
 	GAME_STATE_LOAD_SAVE    = 3
 	GAME_STATE_SPECIAL_MENU = 4
+	GAME_STATE_SOUND_TEST   = 5
 
 	STATE_CHANGE_DELAY = 0.2 // in seconds
 )
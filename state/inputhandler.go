@@ -19,13 +19,13 @@ func NewInputHandler(windowHandler *client.WindowHandler, gameStateManager *Game
 	}
 }
 
-func (h *InputHandler) HandleTankMovement(gameDef *game.GameDef, timeElapsedSeconds float64, collisionEntities []fileio.CollisionEntity) {
+func (h *InputHandler) HandleTankMovement(gameDef *game.GameDef, timeElapsedSeconds float64, collision *world.CollisionIndex) {
 	if h.windowHandler.InputHandler.IsActive(client.PLAYER_FORWARD) {
-		gameDef.Player.HandlePlayerInputForward(collisionEntities, timeElapsedSeconds)
+		gameDef.Player.HandlePlayerInputForward(collision, timeElapsedSeconds)
 	}
 
 	if h.windowHandler.InputHandler.IsActive(client.PLAYER_BACKWARD) {
-		gameDef.Player.HandlePlayerInputBackward(collisionEntities, timeElapsedSeconds)
+		gameDef.Player.HandlePlayerInputBackward(collision, timeElapsedSeconds)
 	}
 
 	if !h.windowHandler.InputHandler.IsActive(client.PLAYER_FORWARD) &&
@@ -62,10 +62,35 @@ func (h *InputHandler) HandleInventoryToggle() {
 	}
 }
 
+func (h *InputHandler) HandleMessageInput(messageBox *game.MessageBox) {
+	if !h.gameStateManager.CanUpdateGameState(h.windowHandler) {
+		return
+	}
+
+	if h.windowHandler.InputHandler.IsActive(client.ACTION_BUTTON) {
+		messageBox.Advance()
+		h.gameStateManager.UpdateLastTimeChangeState(h.windowHandler)
+	} else if h.windowHandler.InputHandler.IsActive(client.MENU_LEFT_BUTTON) ||
+		h.windowHandler.InputHandler.IsActive(client.MENU_RIGHT_BUTTON) {
+		messageBox.ToggleChoice()
+		h.gameStateManager.UpdateLastTimeChangeState(h.windowHandler)
+	}
+}
+
 func (h *InputHandler) HandleAllInput(gameDef *game.GameDef, timeElapsedSeconds float64, gameWorld *world.GameWorld) {
 	collisionEntities := gameWorld.GameRoom.CollisionEntities
 
-	h.HandleTankMovement(gameDef, timeElapsedSeconds, collisionEntities)
+	if gameDef.MessageBox.IsOpen {
+		h.HandleMessageInput(gameDef.MessageBox)
+		return
+	}
+
+	// The script is controlling the player
+	if gameDef.Player.IsInputLocked() {
+		return
+	}
+
+	h.HandleTankMovement(gameDef, timeElapsedSeconds, gameWorld.GameRoom.Collision)
 	h.HandleTankRotation(gameDef, timeElapsedSeconds)
 	h.HandleActionButton(gameDef, collisionEntities)
 	h.HandleInventoryToggle()
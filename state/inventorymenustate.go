@@ -2,6 +2,7 @@ package state
 
 import (
 	"github.com/OpenBiohazard2/OpenBiohazard2/client"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
 	"github.com/OpenBiohazard2/OpenBiohazard2/render"
 	"github.com/OpenBiohazard2/OpenBiohazard2/resource"
 	"github.com/OpenBiohazard2/OpenBiohazard2/ui"
@@ -18,7 +19,7 @@ type InventoryStateInput struct {
 	InventoryManager    *ui.InventoryManager
 }
 
-func NewInventoryStateInput(renderDef *render.RenderDef) *InventoryStateInput {
+func NewInventoryStateInput(renderDef *render.RenderDef, gameDef *game.GameDef) *InventoryStateInput {
 	inventoryMenuImages := resource.LoadTIMImages(resource.INVENTORY_FILE)
 	inventoryItemImages := resource.LoadTIMImages(resource.ITEMALL_FILE)
 	
@@ -29,7 +30,7 @@ func NewInventoryStateInput(renderDef *render.RenderDef) *InventoryStateInput {
 		InventoryItemImages: inventoryItemImages,
 		InventoryMenu:       ui.NewInventoryMenu(),
 		HealthDisplay:       ui.NewHealthDisplay(),
-		InventoryManager:    ui.NewInventoryManager(),
+		InventoryManager:    ui.NewInventoryManager(gameDef.Inventory),
 	}
 }
 
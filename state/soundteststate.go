@@ -0,0 +1,70 @@
+package state
+
+import (
+	"log"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/audio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/client"
+	"github.com/OpenBiohazard2/OpenBiohazard2/render"
+	"github.com/OpenBiohazard2/OpenBiohazard2/resource"
+	"github.com/OpenBiohazard2/OpenBiohazard2/ui"
+	"github.com/OpenBiohazard2/OpenBiohazard2/ui_render"
+)
+
+type SoundTestStateInput struct {
+	RenderDef           *render.RenderDef
+	UIRenderer          *ui_render.UIRenderer
+	MenuBackgroundImage *resource.Image16Bit
+	MenuTextImages      []*resource.Image16Bit
+	SoundTest           *ui.SoundTest
+}
+
+func NewSoundTestStateInput(renderDef *render.RenderDef, audioEngine *audio.Engine) *SoundTestStateInput {
+	return &SoundTestStateInput{
+		RenderDef:  renderDef,
+		UIRenderer: ui_render.NewUIRenderer(renderDef),
+		SoundTest:  ui.NewSoundTest(audioEngine),
+	}
+}
+
+func HandleSoundTest(soundTestStateInput *SoundTestStateInput, gameStateManager *GameStateManager, windowHandler *client.WindowHandler) {
+	renderDef := soundTestStateInput.RenderDef
+	soundTest := soundTestStateInput.SoundTest
+	if !gameStateManager.ImageResourcesLoaded {
+		soundTestStateInput.MenuBackgroundImage = resource.LoadADTImage(resource.MENU_IMAGE_FILE)
+		soundTestStateInput.MenuTextImages = resource.LoadTIMImages(resource.MENU_TEXT_FILE)
+		soundTest.LoadTrackLists()
+		soundTest.Row = ui.SOUND_TEST_ROW_CATEGORY
+		soundTestStateInput.UIRenderer.UpdateSoundTest(soundTestStateInput.MenuBackgroundImage, soundTestStateInput.MenuTextImages, soundTest)
+
+		gameStateManager.ImageResourcesLoaded = true
+		gameStateManager.UpdateLastTimeChangeState(windowHandler)
+	}
+
+	// The progress bar moves while a track plays
+	wasPlaying := soundTest.IsPlaying
+	soundTest.Update(windowHandler.GetTimeSinceLastFrame())
+	if wasPlaying {
+		soundTestStateInput.UIRenderer.UpdateSoundTest(soundTestStateInput.MenuBackgroundImage, soundTestStateInput.MenuTextImages, soundTest)
+	}
+
+	renderDef.RenderTransparentVideoBuffer()
+
+	if gameStateManager.CanUpdateGameState(windowHandler) {
+		if soundTest.IsExitSelected() && windowHandler.InputHandler.IsActive(client.ACTION_BUTTON) {
+			soundTest.Stop()
+			gameStateManager.UpdateGameState(GAME_STATE_SPECIAL_MENU)
+			gameStateManager.UpdateLastTimeChangeState(windowHandler)
+			return
+		}
+
+		if soundTest.HandleInput(windowHandler) {
+			if soundTest.LastError != nil {
+				log.Print("Sound test: ", soundTest.LastError)
+				soundTest.LastError = nil
+			}
+			soundTestStateInput.UIRenderer.UpdateSoundTest(soundTestStateInput.MenuBackgroundImage, soundTestStateInput.MenuTextImages, soundTest)
+			gameStateManager.UpdateLastTimeChangeState(windowHandler)
+		}
+	}
+}
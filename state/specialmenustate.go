@@ -39,6 +39,9 @@ func HandleSpecialMenu(specialMenuStateInput *SpecialMenuStateInput, gameStateMa
 			case 0:
 				// TODO: Load gallery
 			case 1:
+				gameStateManager.UpdateGameState(GAME_STATE_SOUND_TEST)
+				gameStateManager.UpdateLastTimeChangeState(windowHandler)
+			case 2:
 				// Exit
 				gameStateManager.UpdateGameState(GAME_STATE_MAIN_MENU)
 				gameStateManager.UpdateLastTimeChangeState(windowHandler)
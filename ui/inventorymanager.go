@@ -1,37 +1,28 @@
 package ui
 
+import (
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+)
+
 // InventoryItem represents an item in the player's inventory
-type InventoryItem struct {
-	Id   int
-	Num  int
-	Size int
-}
+type InventoryItem = game.InventoryItem
 
 // InventoryManager manages inventory state and timing
 type InventoryManager struct {
 	totalInventoryTime        float64
 	updateInventoryCursorTime float64 // milliseconds
-	playerInventoryItems      []InventoryItem
+	playerInventory           *game.Inventory
 }
 
-// NewInventoryManager creates a new inventory manager
-func NewInventoryManager() *InventoryManager {
+// NewInventoryManager creates a new inventory manager for the player's inventory
+func NewInventoryManager(playerInventory *game.Inventory) *InventoryManager {
 	return &InventoryManager{
 		totalInventoryTime:        0,
 		updateInventoryCursorTime: 30, // milliseconds
-		playerInventoryItems:      initializeInventoryItems(),
+		playerInventory:           playerInventory,
 	}
 }
 
-// initializeInventoryItems creates the initial inventory items
-func initializeInventoryItems() []InventoryItem {
-	items := make([]InventoryItem, 11)
-	items[0] = InventoryItem{Id: 2, Num: 18, Size: 1}                  // hand gun
-	items[1] = InventoryItem{Id: 1, Num: 1, Size: 0}                   // knife
-	items[RESERVED_ITEM_SLOT] = InventoryItem{Id: 47, Num: 1, Size: 0} // lighter
-	return items
-}
-
 // UpdateInventoryTime updates the total inventory time
 func (im *InventoryManager) UpdateInventoryTime(timeElapsedSeconds float64) {
 	im.totalInventoryTime += timeElapsedSeconds * 1000
@@ -50,7 +41,7 @@ func (im *InventoryManager) ResetInventoryTime() {
 // GetPlayerInventoryItems returns a copy of the player's inventory items
 func (im *InventoryManager) GetPlayerInventoryItems() []InventoryItem {
 	// Return a copy to prevent external modification
-	items := make([]InventoryItem, len(im.playerInventoryItems))
-	copy(items, im.playerInventoryItems)
+	items := make([]InventoryItem, len(im.playerInventory.Items))
+	copy(items, im.playerInventory.Items)
 	return items
 }
@@ -2,10 +2,12 @@ package ui
 
 import (
 	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
 )
 
 func TestNewInventoryManager(t *testing.T) {
-	manager := NewInventoryManager()
+	manager := NewInventoryManager(game.NewInventory())
 
 	if manager == nil {
 		t.Fatal("NewInventoryManager() returned nil")
@@ -32,7 +34,7 @@ func TestNewInventoryManager(t *testing.T) {
 }
 
 func TestInventoryManager_UpdateInventoryTime(t *testing.T) {
-	manager := NewInventoryManager()
+	manager := NewInventoryManager(game.NewInventory())
 
 	// Test initial state
 	if manager.totalInventoryTime != 0 {
@@ -55,7 +57,7 @@ func TestInventoryManager_UpdateInventoryTime(t *testing.T) {
 }
 
 func TestInventoryManager_ShouldUpdateCursor(t *testing.T) {
-	manager := NewInventoryManager()
+	manager := NewInventoryManager(game.NewInventory())
 
 	// Test initial state - should not update cursor
 	if manager.ShouldUpdateCursor() {
@@ -70,7 +72,7 @@ func TestInventoryManager_ShouldUpdateCursor(t *testing.T) {
 }
 
 func TestInventoryManager_ResetInventoryTime(t *testing.T) {
-	manager := NewInventoryManager()
+	manager := NewInventoryManager(game.NewInventory())
 
 	// Add some time
 	manager.UpdateInventoryTime(0.1)
@@ -86,7 +88,7 @@ func TestInventoryManager_ResetInventoryTime(t *testing.T) {
 }
 
 func TestInventoryManager_GetPlayerInventoryItems(t *testing.T) {
-	manager := NewInventoryManager()
+	manager := NewInventoryManager(game.NewInventory())
 
 	items := manager.GetPlayerInventoryItems()
 
@@ -105,8 +107,8 @@ func TestInventoryManager_GetPlayerInventoryItems(t *testing.T) {
 
 func TestInventoryManager_Isolation(t *testing.T) {
 	// Test that multiple instances don't interfere
-	manager1 := NewInventoryManager()
-	manager2 := NewInventoryManager()
+	manager1 := NewInventoryManager(game.NewInventory())
+	manager2 := NewInventoryManager(game.NewInventory())
 
 	// Update them independently
 	manager1.UpdateInventoryTime(0.1)
@@ -133,7 +135,7 @@ func TestInventoryManager_Isolation(t *testing.T) {
 
 // Benchmark tests
 func BenchmarkUpdateInventoryTime(b *testing.B) {
-	manager := NewInventoryManager()
+	manager := NewInventoryManager(game.NewInventory())
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -142,7 +144,7 @@ func BenchmarkUpdateInventoryTime(b *testing.B) {
 }
 
 func BenchmarkGetPlayerInventoryItems(b *testing.B) {
-	manager := NewInventoryManager()
+	manager := NewInventoryManager(game.NewInventory())
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
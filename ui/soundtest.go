@@ -0,0 +1,339 @@
+package ui
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/audio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/client"
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/resource"
+)
+
+const (
+	SOUND_TEST_ROW_CATEGORY = 0
+	SOUND_TEST_ROW_TRACK    = 1
+	SOUND_TEST_ROW_TONE     = 2 // Only used by sound effects
+	SOUND_TEST_ROW_VOLUME   = 3
+	SOUND_TEST_ROW_EXIT     = 4
+	SOUND_TEST_ROW_COUNT    = 5
+
+	SOUND_TEST_CATEGORY_BGM     = 0
+	SOUND_TEST_CATEGORY_EFFECTS = 1
+	SOUND_TEST_CATEGORY_VOICE   = 2
+	SOUND_TEST_CATEGORY_COUNT   = 3
+
+	SOUND_TEST_MAX_VOLUME  = 127
+	SOUND_TEST_VOLUME_STEP = 8
+)
+
+var SoundTestCategoryNames = [SOUND_TEST_CATEGORY_COUNT]string{"BGM", "SOUND EFFECTS", "VOICE"}
+
+// Music and voices are SAP files
+// Sound effects are the tones of a room's sound bank or the core bank
+type SoundTestTrack struct {
+	Name     string
+	Filename string
+}
+
+type soundTestTone struct {
+	Program int
+	Tone    int
+}
+
+// Plays every BGM track, sound bank tone and voice line to check them by ear
+type SoundTest struct {
+	Engine     *audio.Engine
+	Tracks     [SOUND_TEST_CATEGORY_COUNT][]SoundTestTrack
+	Row        int
+	Category   int
+	TrackIndex [SOUND_TEST_CATEGORY_COUNT]int
+	ToneIndex  int
+	Volume     int // 0-127
+
+	LastError       error // Set when the selected sound couldn't be played
+	IsPlaying       bool
+	PlayingName     string
+	ElapsedSeconds  float64
+	DurationSeconds float64
+	IsLooping       bool
+	baseVolume      float32 // Volume of the sound before the sound test volume
+	voiceId         audio.VoiceId
+	status          *audio.VoiceStatus
+
+	// The bank of the selected sound effect track
+	bank         *audio.Sampler
+	bankTones    []soundTestTone
+	bankFilename string
+
+	// Replaced by tests that don't have the game files
+	LoadSound func(filename string) (*audio.Sound, error)
+	LoadBank  func(filename string) (*audio.Sampler, error)
+}
+
+func NewSoundTest(engine *audio.Engine) *SoundTest {
+	return &SoundTest{
+		Engine:    engine,
+		Volume:    SOUND_TEST_MAX_VOLUME,
+		LoadSound: resource.LoadMusicTrack,
+		LoadBank:  loadSoundTestBank,
+	}
+}
+
+// Room files keep the bank inside, the core bank is a .vh and .vb pair
+func loadSoundTestBank(filename string) (*audio.Sampler, error) {
+	var vabOutput *fileio.VABOutput
+	if strings.EqualFold(filepath.Ext(filename), ".vh") {
+		var err error
+		vabOutput, err = resource.LoadSoundBank(filename, strings.TrimSuffix(filename, filepath.Ext(filename))+".vb")
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		rdtOutput, err := fileio.LoadRDTFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		if rdtOutput.RoomVABData == nil {
+			return nil, fmt.Errorf("room %s has no sound bank", filename)
+		}
+		vabOutput = rdtOutput.RoomVABData
+	}
+	return audio.NewSampler(vabOutput.VABHeaderOutput, vabOutput.VABDataOutput), nil
+}
+
+// Looks for the sound files in the game folders
+func (soundTest *SoundTest) LoadTrackLists() {
+	soundTest.Tracks[SOUND_TEST_CATEGORY_BGM] = findSoundTestTracks(resource.BGM_FOLDER, ".sap")
+	soundTest.Tracks[SOUND_TEST_CATEGORY_EFFECTS] = append(
+		[]SoundTestTrack{{Name: "CORE", Filename: resource.CORE_SOUND_HEADER}},
+		findSoundTestTracks(resource.RDT_FOLDER, ".rdt")...)
+	soundTest.Tracks[SOUND_TEST_CATEGORY_VOICE] = findSoundTestTracks(resource.VOICE_FOLDER, ".sap")
+}
+
+// Names include the subfolder so voices from different stages can be told apart
+func findSoundTestTracks(folder string, extension string) []SoundTestTrack {
+	tracks := make([]SoundTestTrack, 0)
+	filepath.WalkDir(folder, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !strings.EqualFold(filepath.Ext(path), extension) {
+			return nil
+		}
+		name, _ := filepath.Rel(folder, path)
+		name = strings.TrimSuffix(filepath.ToSlash(name), filepath.Ext(name))
+		tracks = append(tracks, SoundTestTrack{Name: strings.ToUpper(name), Filename: path})
+		return nil
+	})
+	sort.Slice(tracks, func(i, j int) bool { return tracks[i].Name < tracks[j].Name })
+	return tracks
+}
+
+// Returns nil if the category has no tracks
+func (soundTest *SoundTest) GetSelectedTrack() *SoundTestTrack {
+	tracks := soundTest.Tracks[soundTest.Category]
+	trackIndex := soundTest.TrackIndex[soundTest.Category]
+	if trackIndex < 0 || trackIndex >= len(tracks) {
+		return nil
+	}
+	return &tracks[trackIndex]
+}
+
+func (soundTest *SoundTest) GetToneCount() int {
+	return len(soundTest.bankTones)
+}
+
+// Returns true if the screen needs to be drawn again
+func (soundTest *SoundTest) HandleInput(windowHandler *client.WindowHandler) bool {
+	inputHandler := windowHandler.InputHandler
+	switch {
+	case inputHandler.IsActive(client.MENU_UP_BUTTON):
+		soundTest.MoveRow(-1)
+	case inputHandler.IsActive(client.MENU_DOWN_BUTTON):
+		soundTest.MoveRow(1)
+	case inputHandler.IsActive(client.MENU_LEFT_BUTTON):
+		soundTest.LastError = soundTest.ChangeValue(-1)
+	case inputHandler.IsActive(client.MENU_RIGHT_BUTTON):
+		soundTest.LastError = soundTest.ChangeValue(1)
+	case inputHandler.IsActive(client.ACTION_BUTTON):
+		if soundTest.Row != SOUND_TEST_ROW_EXIT {
+			soundTest.LastError = soundTest.TogglePlay()
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+func (soundTest *SoundTest) IsExitSelected() bool {
+	return soundTest.Row == SOUND_TEST_ROW_EXIT
+}
+
+// The tone row is skipped for music and voices
+func (soundTest *SoundTest) MoveRow(delta int) {
+	soundTest.Row = min(max(soundTest.Row+delta, 0), SOUND_TEST_ROW_COUNT-1)
+	if soundTest.Row == SOUND_TEST_ROW_TONE && soundTest.Category != SOUND_TEST_CATEGORY_EFFECTS {
+		soundTest.Row = min(max(soundTest.Row+delta, 0), SOUND_TEST_ROW_COUNT-1)
+	}
+}
+
+// Changes the value on the selected row, wrapping around the lists
+func (soundTest *SoundTest) ChangeValue(delta int) error {
+	switch soundTest.Row {
+	case SOUND_TEST_ROW_CATEGORY:
+		soundTest.Stop()
+		soundTest.Category = wrapIndex(soundTest.Category+delta, SOUND_TEST_CATEGORY_COUNT)
+	case SOUND_TEST_ROW_TRACK:
+		soundTest.Stop()
+		trackCount := len(soundTest.Tracks[soundTest.Category])
+		soundTest.TrackIndex[soundTest.Category] = wrapIndex(soundTest.TrackIndex[soundTest.Category]+delta, trackCount)
+		soundTest.ToneIndex = 0
+	case SOUND_TEST_ROW_TONE:
+		if err := soundTest.loadSelectedBank(); err != nil {
+			return err
+		}
+		soundTest.ToneIndex = wrapIndex(soundTest.ToneIndex+delta, len(soundTest.bankTones))
+	case SOUND_TEST_ROW_VOLUME:
+		soundTest.SetVolume(soundTest.Volume + delta*SOUND_TEST_VOLUME_STEP)
+	}
+	return nil
+}
+
+func wrapIndex(index int, count int) int {
+	if count <= 0 {
+		return 0
+	}
+	return ((index % count) + count) % count
+}
+
+func (soundTest *SoundTest) SetVolume(volume int) {
+	soundTest.Volume = min(max(volume, 0), SOUND_TEST_MAX_VOLUME)
+	if soundTest.IsPlaying {
+		soundTest.Engine.SetVolume(soundTest.voiceId, soundTest.baseVolume*soundTest.getVolumeGain())
+	}
+}
+
+func (soundTest *SoundTest) getVolumeGain() float32 {
+	return float32(soundTest.Volume) / SOUND_TEST_MAX_VOLUME
+}
+
+func (soundTest *SoundTest) TogglePlay() error {
+	if soundTest.IsPlaying {
+		soundTest.Stop()
+		return nil
+	}
+	return soundTest.Play()
+}
+
+// Plays the selected track, or the selected tone for sound effects
+func (soundTest *SoundTest) Play() error {
+	soundTest.Stop()
+	track := soundTest.GetSelectedTrack()
+	if track == nil {
+		return fmt.Errorf("no %s tracks", strings.ToLower(SoundTestCategoryNames[soundTest.Category]))
+	}
+
+	var sound *audio.Sound
+	params := audio.DefaultVoiceParams()
+	name := track.Name
+	if soundTest.Category == SOUND_TEST_CATEGORY_EFFECTS {
+		if err := soundTest.loadSelectedBank(); err != nil {
+			return err
+		}
+		if soundTest.ToneIndex >= len(soundTest.bankTones) {
+			return fmt.Errorf("%s has no tones", track.Name)
+		}
+		bankTone := soundTest.bankTones[soundTest.ToneIndex]
+		tone := soundTest.bank.GetTone(bankTone.Program, bankTone.Tone)
+		var ok bool
+		sound, params, ok = soundTest.bank.GetToneVoice(tone, int(tone.Center), audio.VAB_MAX_VOLUME)
+		if !ok {
+			return fmt.Errorf("%s program %d tone %d has no waveform", track.Name, bankTone.Program, bankTone.Tone)
+		}
+		// Looping tones play the waveform once so they end
+		params.Loop = false
+		name = fmt.Sprintf("%s %d-%d", track.Name, bankTone.Program, bankTone.Tone)
+	} else {
+		var err error
+		sound, err = soundTest.LoadSound(track.Filename)
+		if err != nil {
+			return err
+		}
+		params.Loop = sound.HasLoop
+	}
+
+	soundTest.baseVolume = params.Volume
+	params.Volume *= soundTest.getVolumeGain()
+	soundTest.voiceId, soundTest.status = soundTest.Engine.PlayWithStatus(sound, params)
+	soundTest.IsPlaying = true
+	soundTest.IsLooping = params.Loop
+	soundTest.PlayingName = name
+	soundTest.ElapsedSeconds = 0
+	soundTest.DurationSeconds = float64(sound.FrameCount()) / float64(sound.SampleRate) / float64(params.Pitch)
+	return nil
+}
+
+// The bank is only loaded again when the selected room changes
+func (soundTest *SoundTest) loadSelectedBank() error {
+	track := soundTest.GetSelectedTrack()
+	if soundTest.Category != SOUND_TEST_CATEGORY_EFFECTS || track == nil {
+		return nil
+	}
+	if soundTest.bankFilename == track.Filename {
+		return nil
+	}
+
+	soundTest.bank = nil
+	soundTest.bankTones = nil
+	soundTest.bankFilename = track.Filename
+	bank, err := soundTest.LoadBank(track.Filename)
+	if err != nil {
+		return err
+	}
+	soundTest.bank = bank
+	for program := range bank.Header.Programs {
+		for tone := range bank.Header.GetProgramTones(program) {
+			soundTest.bankTones = append(soundTest.bankTones, soundTestTone{Program: program, Tone: tone})
+		}
+	}
+	return nil
+}
+
+func (soundTest *SoundTest) Stop() {
+	if !soundTest.IsPlaying {
+		return
+	}
+	soundTest.Engine.Stop(soundTest.voiceId)
+	soundTest.IsPlaying = false
+}
+
+func (soundTest *SoundTest) Update(timeElapsedSeconds float64) {
+	if !soundTest.IsPlaying {
+		return
+	}
+	soundTest.ElapsedSeconds += timeElapsedSeconds
+	if soundTest.status != nil && soundTest.status.IsFinished() {
+		soundTest.IsPlaying = false
+		soundTest.ElapsedSeconds = soundTest.DurationSeconds
+	}
+}
+
+// Returns 0 to 1, looping tracks start again from 0
+func (soundTest *SoundTest) GetProgress() float64 {
+	if soundTest.DurationSeconds <= 0 {
+		return 0
+	}
+	if soundTest.IsLooping && soundTest.IsPlaying {
+		elapsed := soundTest.ElapsedSeconds
+		for elapsed >= soundTest.DurationSeconds {
+			elapsed -= soundTest.DurationSeconds
+		}
+		return elapsed / soundTest.DurationSeconds
+	}
+	return min(soundTest.ElapsedSeconds/soundTest.DurationSeconds, 1.0)
+}
+
+func (soundTest *SoundTest) GetVolumeLevel() float64 {
+	return float64(soundTest.Volume) / SOUND_TEST_MAX_VOLUME
+}
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/audio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+)
+
+func newTestSoundTest() *SoundTest {
+	soundTest := NewSoundTest(audio.NewEngine(audio.NewNullBackend()))
+	soundTest.Tracks[SOUND_TEST_CATEGORY_BGM] = []SoundTestTrack{{Name: "MAIN00", Filename: "main00.sap"}, {Name: "MAIN01", Filename: "main01.sap"}}
+	soundTest.Tracks[SOUND_TEST_CATEGORY_EFFECTS] = []SoundTestTrack{{Name: "ROOM1000", Filename: "ROOM1000.RDT"}}
+	soundTest.LoadSound = func(filename string) (*audio.Sound, error) {
+		return audio.NewSound(make([]int16, audio.SAMPLE_RATE*2), 2, audio.SAMPLE_RATE), nil
+	}
+	soundTest.LoadBank = func(filename string) (*audio.Sampler, error) {
+		tones := make([]fileio.VABTone, 16)
+		tones[0] = fileio.VABTone{Volume: 127, Pan: 64, Center: 60, NoteMax: 127, Vag: 1}
+		tones[1] = fileio.VABTone{Volume: 127, Pan: 64, Center: 60, NoteMax: 127, Vag: 1}
+		return &audio.Sampler{
+			Header: &fileio.VABHeaderOutput{
+				VABHeader: fileio.VABHeader{MasterVolume: 127},
+				Programs:  []fileio.VABProgram{{Tones: 2, Volume: 127, Pan: 64}},
+				Tones:     [][]fileio.VABTone{tones},
+			},
+			Waveforms: []*audio.Sound{nil, audio.NewSound(make([]int16, audio.SPU_SAMPLE_RATE), 1, audio.SPU_SAMPLE_RATE)},
+		}, nil
+	}
+	return soundTest
+}
+
+func TestSoundTestMoveRowSkipsTone(t *testing.T) {
+	soundTest := newTestSoundTest()
+	soundTest.MoveRow(1)
+	soundTest.MoveRow(1)
+	if soundTest.Row != SOUND_TEST_ROW_VOLUME {
+		t.Errorf("Expected tone row to be skipped for music, got row %d", soundTest.Row)
+	}
+
+	soundTest.Row = SOUND_TEST_ROW_CATEGORY
+	soundTest.Category = SOUND_TEST_CATEGORY_EFFECTS
+	soundTest.MoveRow(1)
+	soundTest.MoveRow(1)
+	if soundTest.Row != SOUND_TEST_ROW_TONE {
+		t.Errorf("Expected tone row for sound effects, got row %d", soundTest.Row)
+	}
+}
+
+func TestSoundTestChangeValueWraps(t *testing.T) {
+	soundTest := newTestSoundTest()
+	soundTest.ChangeValue(-1)
+	if soundTest.Category != SOUND_TEST_CATEGORY_VOICE {
+		t.Errorf("Expected category to wrap to voice, got %d", soundTest.Category)
+	}
+
+	soundTest.Category = SOUND_TEST_CATEGORY_BGM
+	soundTest.Row = SOUND_TEST_ROW_TRACK
+	soundTest.ChangeValue(1)
+	soundTest.ChangeValue(1)
+	if soundTest.TrackIndex[SOUND_TEST_CATEGORY_BGM] != 0 {
+		t.Errorf("Expected track to wrap to 0, got %d", soundTest.TrackIndex[SOUND_TEST_CATEGORY_BGM])
+	}
+
+	soundTest.Row = SOUND_TEST_ROW_VOLUME
+	soundTest.ChangeValue(1)
+	if soundTest.Volume != SOUND_TEST_MAX_VOLUME {
+		t.Errorf("Expected volume to stay at max, got %d", soundTest.Volume)
+	}
+	soundTest.ChangeValue(-1)
+	if soundTest.Volume != SOUND_TEST_MAX_VOLUME-SOUND_TEST_VOLUME_STEP {
+		t.Errorf("Expected volume to go down one step, got %d", soundTest.Volume)
+	}
+}
+
+func TestSoundTestPlayMusic(t *testing.T) {
+	soundTest := newTestSoundTest()
+	if err := soundTest.Play(); err != nil {
+		t.Fatal(err)
+	}
+	if !soundTest.IsPlaying || soundTest.PlayingName != "MAIN00" {
+		t.Errorf("Expected MAIN00 to play, got %q", soundTest.PlayingName)
+	}
+	if soundTest.DurationSeconds != 1.0 {
+		t.Errorf("Expected 1 second track, got %f", soundTest.DurationSeconds)
+	}
+
+	soundTest.Update(0.25)
+	if soundTest.GetProgress() != 0.25 {
+		t.Errorf("Expected progress 0.25, got %f", soundTest.GetProgress())
+	}
+
+	soundTest.TogglePlay()
+	if soundTest.IsPlaying {
+		t.Error("Expected track to stop")
+	}
+}
+
+func TestSoundTestPlayTone(t *testing.T) {
+	soundTest := newTestSoundTest()
+	soundTest.Category = SOUND_TEST_CATEGORY_EFFECTS
+	soundTest.Row = SOUND_TEST_ROW_TONE
+	soundTest.ChangeValue(1)
+	if soundTest.GetToneCount() != 2 || soundTest.ToneIndex != 1 {
+		t.Fatalf("Expected second of 2 tones, got tone %d of %d", soundTest.ToneIndex, soundTest.GetToneCount())
+	}
+
+	if err := soundTest.Play(); err != nil {
+		t.Fatal(err)
+	}
+	if soundTest.PlayingName != "ROOM1000 0-1" {
+		t.Errorf("Expected room tone name, got %q", soundTest.PlayingName)
+	}
+
+	// Enough blocks for the one second waveform
+	for i := 0; soundTest.IsPlaying && i < 2*audio.SAMPLE_RATE/audio.MIX_BLOCK_FRAMES; i++ {
+		soundTest.Engine.MixBlock()
+		soundTest.Update(0.01)
+	}
+	if soundTest.IsPlaying || soundTest.GetProgress() != 1.0 {
+		t.Errorf("Expected tone to finish, progress %f", soundTest.GetProgress())
+	}
+}
+
+func TestSoundTestToneBankError(t *testing.T) {
+	soundTest := newTestSoundTest()
+	soundTest.LoadBank = func(filename string) (*audio.Sampler, error) {
+		return nil, errors.New("no bank")
+	}
+	soundTest.Category = SOUND_TEST_CATEGORY_EFFECTS
+	soundTest.Row = SOUND_TEST_ROW_TONE
+	if err := soundTest.ChangeValue(1); err == nil {
+		t.Error("Expected error when the bank can't be loaded")
+	}
+	if soundTest.ToneIndex != 0 {
+		t.Errorf("Expected tone to stay at 0, got %d", soundTest.ToneIndex)
+	}
+}
+
+func TestSoundTestPlayWithoutTracks(t *testing.T) {
+	soundTest := newTestSoundTest()
+	soundTest.Category = SOUND_TEST_CATEGORY_VOICE
+	if err := soundTest.Play(); err == nil {
+		t.Error("Expected error without voice tracks")
+	}
+}
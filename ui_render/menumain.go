@@ -108,7 +108,7 @@ func buildSpecialMenuOptions(screenImage *resource.Image16Bit, menuTextImages []
 	selectedOption := 1.0
 	otherOption := 0.3
 
-	optionsBrightness := [2]float64{otherOption, otherOption}
+	optionsBrightness := [3]float64{otherOption, otherOption, otherOption}
 	optionsBrightness[specialMenuOption] = selectedOption
 
 	// Special title
@@ -119,7 +119,10 @@ func buildSpecialMenuOptions(screenImage *resource.Image16Bit, menuTextImages []
 	screenImage.WriteSubImageUniformBrightness(image.Point{120, 154}, menuTextImages[0], image.Rect(169, 96, 169+75, 96+14),
 		optionsBrightness[0])
 
+	// Sound Test isn't in the menu text image, so it uses the message font
+	buildText(screenImage, menuTextImages[MESSAGE_FONT_IMAGE_INDEX], image.Point{120, 176}, "Sound Test", optionsBrightness[1])
+
 	// Exit
-	screenImage.WriteSubImageUniformBrightness(image.Point{135, 174}, menuTextImages[0], image.Rect(105, 124, 105+45, 124+14),
-		optionsBrightness[1])
+	screenImage.WriteSubImageUniformBrightness(image.Point{135, 194}, menuTextImages[0], image.Rect(105, 124, 105+45, 124+14),
+		optionsBrightness[2])
 }
@@ -0,0 +1,88 @@
+package ui_render
+
+import (
+	"image"
+	"image/color"
+	"strings"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/game"
+	"github.com/OpenBiohazard2/OpenBiohazard2/resource"
+)
+
+const (
+	// Font glyphs are in a grid with 16 characters per row, in the same order as the message text
+	MESSAGE_FONT_IMAGE_INDEX = 3
+	MESSAGE_FONT_WIDTH       = 8
+	MESSAGE_FONT_HEIGHT      = 10
+	MESSAGE_FONT_COLUMNS     = 16
+
+	MESSAGE_BOX_POS_X   = 16
+	MESSAGE_BOX_POS_Y   = 170
+	MESSAGE_BOX_WIDTH   = 288
+	MESSAGE_BOX_HEIGHT  = 60
+	MESSAGE_TEXT_MARGIN = 8
+	MESSAGE_LINE_HEIGHT = 12
+
+	SUBTITLE_BOTTOM_MARGIN = 12
+)
+
+// GenerateMessageImage renders the message box on top of the game
+// Voice subtitles are only shown when the message box is closed
+func (r *UIRenderer) GenerateMessageImage(menuTextImages []*resource.Image16Bit, messageBox *game.MessageBox, subtitle string) {
+	r.ClearScreen()
+	screenImage := r.GetScreenImage()
+	if messageBox.IsOpen {
+		buildMessageBox(screenImage, menuTextImages[MESSAGE_FONT_IMAGE_INDEX], messageBox)
+	} else if subtitle != "" {
+		buildSubtitle(screenImage, menuTextImages[MESSAGE_FONT_IMAGE_INDEX], subtitle)
+	}
+	r.UpdateVideoBuffer(screenImage)
+}
+
+// Each line is centered at the bottom of the screen
+func buildSubtitle(screenImage *resource.Image16Bit, fontImage *resource.Image16Bit, subtitle string) {
+	lines := strings.Split(subtitle, "\n")
+	top := screenImage.GetHeight() - SUBTITLE_BOTTOM_MARGIN - len(lines)*MESSAGE_LINE_HEIGHT
+	for i, line := range lines {
+		left := max((screenImage.GetWidth()-len(line)*MESSAGE_FONT_WIDTH)/2, 0)
+		buildText(screenImage, fontImage, image.Point{left, top + i*MESSAGE_LINE_HEIGHT}, line, 1.0)
+	}
+}
+
+func buildMessageBox(screenImage *resource.Image16Bit, fontImage *resource.Image16Bit, messageBox *game.MessageBox) {
+	screenImage.FillPixels(image.Point{MESSAGE_BOX_POS_X, MESSAGE_BOX_POS_Y},
+		image.Rect(0, 0, MESSAGE_BOX_WIDTH, MESSAGE_BOX_HEIGHT), color.RGBA{16, 16, 16, 255})
+
+	textOrigin := image.Point{MESSAGE_BOX_POS_X + MESSAGE_TEXT_MARGIN, MESSAGE_BOX_POS_Y + MESSAGE_TEXT_MARGIN}
+	lines := strings.Split(messageBox.GetVisibleText(), "\n")
+	for i, line := range lines {
+		buildText(screenImage, fontImage, textOrigin.Add(image.Point{0, i * MESSAGE_LINE_HEIGHT}), line, 1.0)
+	}
+
+	if messageBox.IsChoiceVisible() {
+		selectedOption := 1.0
+		otherOption := 0.3
+		optionsBrightness := [2]float64{otherOption, otherOption}
+		optionsBrightness[messageBox.Choice] = selectedOption
+
+		choiceOrigin := textOrigin.Add(image.Point{0, len(lines) * MESSAGE_LINE_HEIGHT})
+		buildText(screenImage, fontImage, choiceOrigin, "Yes", optionsBrightness[game.MESSAGE_CHOICE_YES])
+		buildText(screenImage, fontImage, choiceOrigin.Add(image.Point{6 * MESSAGE_FONT_WIDTH, 0}), "No",
+			optionsBrightness[game.MESSAGE_CHOICE_NO])
+	}
+}
+
+func buildText(screenImage *resource.Image16Bit, fontImage *resource.Image16Bit, origin image.Point, text string, brightness float64) {
+	for i := 0; i < len(text); i++ {
+		characterIndex, exists := fileio.GetMSGCharacterIndex(text[i])
+		if !exists {
+			continue
+		}
+
+		glyphX := (characterIndex % MESSAGE_FONT_COLUMNS) * MESSAGE_FONT_WIDTH
+		glyphY := (characterIndex / MESSAGE_FONT_COLUMNS) * MESSAGE_FONT_HEIGHT
+		screenImage.WriteSubImageUniformBrightness(origin.Add(image.Point{i * MESSAGE_FONT_WIDTH, 0}), fontImage,
+			image.Rect(glyphX, glyphY, glyphX+MESSAGE_FONT_WIDTH, glyphY+MESSAGE_FONT_HEIGHT), brightness)
+	}
+}
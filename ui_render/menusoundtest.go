@@ -0,0 +1,120 @@
+package ui_render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/resource"
+	"github.com/OpenBiohazard2/OpenBiohazard2/ui"
+)
+
+const (
+	SOUND_TEST_TEXT_X     = 40
+	SOUND_TEST_TEXT_Y     = 40
+	SOUND_TEST_ROW_HEIGHT = 20
+	SOUND_TEST_VALUE_X    = 128
+	SOUND_TEST_MAX_CHARS  = 20 // Characters that fit between the value column and the edge of the screen
+
+	SOUND_TEST_BAR_WIDTH  = 150
+	SOUND_TEST_BAR_HEIGHT = 6
+)
+
+var (
+	soundTestBarBackgroundColor = color.RGBA{3, 3, 8, 255}
+	soundTestVolumeColor        = color.RGBA{8, 20, 8, 255}
+	soundTestProgressColor      = color.RGBA{20, 20, 28, 255}
+)
+
+// UpdateSoundTest renders the sound test screen
+func (r *UIRenderer) UpdateSoundTest(
+	menuBackgroundImage *resource.Image16Bit,
+	menuTextImages []*resource.Image16Bit,
+	soundTest *ui.SoundTest,
+) {
+	r.ClearScreen()
+	screenImage := r.GetScreenImage()
+	buildMainMenuBackground(screenImage, menuBackgroundImage)
+	buildSoundTest(screenImage, menuTextImages[MESSAGE_FONT_IMAGE_INDEX], soundTest)
+	r.UpdateVideoBuffer(screenImage)
+}
+
+func buildSoundTest(screenImage *resource.Image16Bit, fontImage *resource.Image16Bit, soundTest *ui.SoundTest) {
+	selectedOption := 1.0
+	otherOption := 0.3
+
+	rowsBrightness := [ui.SOUND_TEST_ROW_COUNT]float64{}
+	for i := range rowsBrightness {
+		rowsBrightness[i] = otherOption
+	}
+	rowsBrightness[soundTest.Row] = selectedOption
+
+	buildText(screenImage, fontImage, image.Point{SOUND_TEST_TEXT_X, SOUND_TEST_TEXT_Y - SOUND_TEST_ROW_HEIGHT}, "Sound Test", selectedOption)
+	buildSoundTestRow(screenImage, fontImage, ui.SOUND_TEST_ROW_CATEGORY, "Type",
+		ui.SoundTestCategoryNames[soundTest.Category], rowsBrightness)
+
+	trackName := "NONE"
+	trackCount := len(soundTest.Tracks[soundTest.Category])
+	if track := soundTest.GetSelectedTrack(); track != nil {
+		trackName = fmt.Sprintf("%d/%d %s", soundTest.TrackIndex[soundTest.Category]+1, trackCount, track.Name)
+	}
+	buildSoundTestRow(screenImage, fontImage, ui.SOUND_TEST_ROW_TRACK, "Track", trackName, rowsBrightness)
+
+	if soundTest.Category == ui.SOUND_TEST_CATEGORY_EFFECTS {
+		toneName := "-"
+		if soundTest.GetToneCount() > 0 {
+			toneName = fmt.Sprintf("%d/%d", soundTest.ToneIndex+1, soundTest.GetToneCount())
+		}
+		buildSoundTestRow(screenImage, fontImage, ui.SOUND_TEST_ROW_TONE, "Tone", toneName, rowsBrightness)
+	}
+
+	buildSoundTestRow(screenImage, fontImage, ui.SOUND_TEST_ROW_VOLUME, "Volume", "", rowsBrightness)
+	buildSoundTestBar(screenImage, getSoundTestRowPosition(ui.SOUND_TEST_ROW_VOLUME).Add(image.Point{SOUND_TEST_VALUE_X - SOUND_TEST_TEXT_X, 2}),
+		soundTest.GetVolumeLevel(), soundTestVolumeColor)
+
+	buildText(screenImage, fontImage, getSoundTestRowPosition(ui.SOUND_TEST_ROW_EXIT), "Exit", rowsBrightness[ui.SOUND_TEST_ROW_EXIT])
+
+	// Now playing
+	nowPlayingPosition := getSoundTestRowPosition(ui.SOUND_TEST_ROW_COUNT).Add(image.Point{0, SOUND_TEST_ROW_HEIGHT / 2})
+	playingName := "Stopped"
+	if soundTest.IsPlaying {
+		playingName = soundTest.PlayingName
+	}
+	buildText(screenImage, fontImage, nowPlayingPosition, truncateSoundTestText(playingName, SOUND_TEST_MAX_CHARS+10), selectedOption)
+	buildSoundTestBar(screenImage, nowPlayingPosition.Add(image.Point{0, MESSAGE_LINE_HEIGHT + 2}),
+		soundTest.GetProgress(), soundTestProgressColor)
+}
+
+func getSoundTestRowPosition(row int) image.Point {
+	return image.Point{SOUND_TEST_TEXT_X, SOUND_TEST_TEXT_Y + row*SOUND_TEST_ROW_HEIGHT}
+}
+
+// Label on the left and value on the right, with arrows to show the value can be changed
+func buildSoundTestRow(screenImage *resource.Image16Bit, fontImage *resource.Image16Bit, row int, label string, value string, rowsBrightness [ui.SOUND_TEST_ROW_COUNT]float64) {
+	position := getSoundTestRowPosition(row)
+	buildText(screenImage, fontImage, position, label, rowsBrightness[row])
+	if value == "" {
+		return
+	}
+	valueText := "[" + truncateSoundTestText(value, SOUND_TEST_MAX_CHARS-2) + "]"
+	buildText(screenImage, fontImage, image.Point{SOUND_TEST_VALUE_X, position.Y}, valueText, rowsBrightness[row])
+}
+
+// The font has no underscore, so names with one show a dash instead
+func truncateSoundTestText(text string, maxChars int) string {
+	text = strings.ReplaceAll(text, "_", "-")
+	if len(text) > maxChars {
+		return text[:maxChars]
+	}
+	return text
+}
+
+// Fraction is from 0 to 1
+func buildSoundTestBar(screenImage *resource.Image16Bit, origin image.Point, fraction float64, fillColor color.RGBA) {
+	screenImage.FillPixels(origin, image.Rect(0, 0, SOUND_TEST_BAR_WIDTH, SOUND_TEST_BAR_HEIGHT), soundTestBarBackgroundColor)
+	fillWidth := int(float64(SOUND_TEST_BAR_WIDTH) * min(max(fraction, 0), 1))
+	if fillWidth > 0 {
+		screenImage.FillPixels(origin, image.Rect(0, 0, fillWidth, SOUND_TEST_BAR_HEIGHT), fillColor)
+	}
+}
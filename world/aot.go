@@ -2,6 +2,8 @@ package world
 
 import (
 	"log"
+	"math"
+	"slices"
 
 	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
 	"github.com/OpenBiohazard2/OpenBiohazard2/geometry"
@@ -11,9 +13,21 @@ import (
 // Handle script doors, items, events
 
 const (
-	AOT_DOOR  = 1
-	AOT_ITEM  = 2
-	AOT_EVENT = 5
+	AOT_NONE    = 0
+	AOT_DOOR    = 1
+	AOT_ITEM    = 2
+	AOT_MESSAGE = 4
+	AOT_EVENT   = 5
+
+	// Index in the trigger data of the room message shown by a message aot
+	AOT_MESSAGE_DATA_ID = 2
+
+	// Flags in the header type
+	AOT_TYPE_PLAYER = 0x01 // Triggered by the player
+	AOT_TYPE_MANUAL = 0x10 // Player has to press the action button, otherwise it fires when the player enters
+
+	// The aot is on every floor
+	AOT_FLOOR_ANY = 255
 )
 
 type AotManager struct {
@@ -21,6 +35,25 @@ type AotManager struct {
 	Items       []AotItem
 	Sprites     []fileio.ScriptInstrSceEsprOn
 	AotTriggers []AotObject
+
+	// Shared with the save data so it stays the same when the player comes back to the room
+	DisabledAots map[uint8]bool
+	// Aots that move with an entity are relative to the super position
+	Supers         map[uint8]AotSuper
+	SuperPositions map[uint8]mgl32.Vec3
+	// Doors with a model in the room open before the player goes through
+	DoorModels map[uint8]bool
+	// Automatic aots only fire again after the player leaves them
+	playerInsideAots   map[uint8]bool
+	previousInsideAots map[uint8]bool
+
+	// Grids over the doors and triggers, the ids are indices in the lists
+	// Aots attached to a super move with it, so they are checked every time instead
+	doorGrid      *SpatialGrid
+	triggerGrid   *SpatialGrid
+	superDoors    []int
+	superTriggers []int
+	candidates    []int
 }
 
 type AotHeader struct {
@@ -31,6 +64,13 @@ type AotHeader struct {
 	Super uint8
 }
 
+// Entity that aots are attached to
+type AotSuper struct {
+	WorkComponent int
+	WorkIndex     int
+	Offset        mgl32.Vec3
+}
+
 type AotObject struct {
 	Header AotHeader
 	Bounds *geometry.Quad
@@ -67,7 +107,90 @@ func NewAotManager() *AotManager {
 		Items:       make([]AotItem, 0),
 		Sprites:     make([]fileio.ScriptInstrSceEsprOn, 0),
 		AotTriggers: make([]AotObject, 0),
+
+		DisabledAots:       make(map[uint8]bool),
+		Supers:             make(map[uint8]AotSuper),
+		SuperPositions:     make(map[uint8]mgl32.Vec3),
+		DoorModels:         make(map[uint8]bool),
+		playerInsideAots:   make(map[uint8]bool),
+		previousInsideAots: make(map[uint8]bool),
+
+		doorGrid:      NewSpatialGrid(SPATIAL_GRID_CELL_SIZE),
+		triggerGrid:   NewSpatialGrid(SPATIAL_GRID_CELL_SIZE),
+		superDoors:    make([]int, 0),
+		superTriggers: make([]int, 0),
+		candidates:    make([]int, 0),
+	}
+}
+
+func (aotManager *AotManager) IsAotEnabled(aot uint8) bool {
+	return !aotManager.DisabledAots[aot]
+}
+
+func (aotManager *AotManager) SetAotEnabled(aot uint8, enabled bool) {
+	if enabled {
+		delete(aotManager.DisabledAots, aot)
+		return
+	}
+	aotManager.DisabledAots[aot] = true
+}
+
+func (aotManager *AotManager) SetSuper(super uint8, aotSuper AotSuper) {
+	aotManager.Supers[super] = aotSuper
+}
+
+// Moves all aots with this super number
+func (aotManager *AotManager) SetSuperPosition(super uint8, position mgl32.Vec3) {
+	aotManager.SuperPositions[super] = position
+}
+
+func (aotManager *AotManager) SetDoorModel(aot uint8) {
+	aotManager.DoorModels[aot] = true
+}
+
+func (aotManager *AotManager) HasDoorModel(aot uint8) bool {
+	return aotManager.DoorModels[aot]
+}
+
+func (aotManager *AotManager) GetDoor(aot uint8) *AotDoor {
+	for i := range aotManager.Doors {
+		if aotManager.Doors[i].Header.Aot == aot {
+			return &aotManager.Doors[i]
+		}
+	}
+	return nil
+}
+
+func (aotManager *AotManager) GetAotTrigger(aot uint8) *AotObject {
+	for i := range aotManager.AotTriggers {
+		if aotManager.AotTriggers[i].Header.Aot == aot {
+			return &aotManager.AotTriggers[i]
+		}
+	}
+	return nil
+}
+
+// Checks if the position is inside the aot on the same floor
+func (aotManager *AotManager) isPositionInAot(position mgl32.Vec3, header AotHeader, bounds *geometry.Quad) bool {
+	if !aotManager.IsAotEnabled(header.Aot) {
+		return false
 	}
+
+	floorNum := int(math.Round(float64(position.Y()) / fileio.FLOOR_HEIGHT_UNIT))
+	if header.Floor != AOT_FLOOR_ANY && int(header.Floor) != floorNum {
+		return false
+	}
+
+	if header.Super != 0 {
+		superPosition, exists := aotManager.SuperPositions[header.Super]
+		if !exists {
+			return false
+		}
+		position = position.Sub(mgl32.Vec3{superPosition.X(), 0, superPosition.Z()})
+	}
+
+	vertices := bounds.Vertices
+	return isPointInRectangle(position, vertices[0], vertices[1], vertices[2], vertices[3])
 }
 
 func (aotManager *AotManager) AddScriptSprite(sprite fileio.ScriptInstrSceEsprOn) {
@@ -75,23 +198,110 @@ func (aotManager *AotManager) AddScriptSprite(sprite fileio.ScriptInstrSceEsprOn
 }
 
 func (aotManager *AotManager) GetDoorNearPlayer(position mgl32.Vec3) *AotDoor {
-	for _, door := range aotManager.Doors {
-		vertices := door.Bounds.Vertices
-		if isPointInRectangle(position, vertices[0], vertices[1], vertices[2], vertices[3]) {
-			return &door
+	aotManager.updateIndex()
+	for _, i := range aotManager.getCandidates(aotManager.doorGrid, aotManager.superDoors, position) {
+		door := &aotManager.Doors[i]
+		if aotManager.isPositionInAot(position, door.Header, door.Bounds) {
+			return door
 		}
 	}
 	return nil
 }
 
-func (aotManager *AotManager) GetAotTriggerNearPlayer(position mgl32.Vec3) *AotObject {
-	for _, aot := range aotManager.AotTriggers {
-		vertices := aot.Bounds.Vertices
-		if isPointInRectangle(position, vertices[0], vertices[1], vertices[2], vertices[3]) {
-			return &aot
+// Returns the trigger that should fire this frame
+// Only triggers with the player flag are checked
+// Manual triggers fire when the action button is pressed inside them
+// Other triggers fire once when the player enters them
+func (aotManager *AotManager) GetAotTriggerNearPlayer(position mgl32.Vec3, actionPressed bool) *AotObject {
+	aotManager.updateIndex()
+
+	// Only the aots the player is inside are kept, the others were left
+	wasInsideAots := aotManager.playerInsideAots
+	aotManager.playerInsideAots = aotManager.previousInsideAots
+	aotManager.previousInsideAots = wasInsideAots
+	clear(aotManager.playerInsideAots)
+
+	var triggeredAot *AotObject
+	for _, i := range aotManager.getCandidates(aotManager.triggerGrid, aotManager.superTriggers, position) {
+		aot := &aotManager.AotTriggers[i]
+		// Enemies and objects set off the other aots
+		if aot.Header.Type&AOT_TYPE_PLAYER == 0 || !aotManager.isPositionInAot(position, aot.Header, aot.Bounds) {
+			continue
+		}
+		wasInside := wasInsideAots[aot.Header.Aot]
+		aotManager.playerInsideAots[aot.Header.Aot] = true
+		if triggeredAot != nil {
+			continue
+		}
+
+		if aot.Header.Type&AOT_TYPE_MANUAL != 0 {
+			if actionPressed {
+				triggeredAot = aot
+			}
+		} else if !wasInside {
+			triggeredAot = aot
+		}
+	}
+	return triggeredAot
+}
+
+// Indices of the aots near the position in list order
+func (aotManager *AotManager) getCandidates(grid *SpatialGrid, superIds []int, position mgl32.Vec3) []int {
+	if len(superIds) == 0 {
+		return grid.QueryPoint(position)
+	}
+	aotManager.candidates = append(aotManager.candidates[:0], grid.QueryPoint(position)...)
+	aotManager.candidates = append(aotManager.candidates, superIds...)
+	slices.Sort(aotManager.candidates)
+	return aotManager.candidates
+}
+
+func getQuadBounds(bounds *geometry.Quad) SpatialBounds {
+	vertices := bounds.Vertices
+	spatialBounds := NewSpatialBounds(vertices[0].X(), vertices[0].Z(), vertices[0].X(), vertices[0].Z())
+	for _, vertex := range vertices[1:] {
+		spatialBounds.MinX = min(spatialBounds.MinX, vertex.X())
+		spatialBounds.MinZ = min(spatialBounds.MinZ, vertex.Z())
+		spatialBounds.MaxX = max(spatialBounds.MaxX, vertex.X())
+		spatialBounds.MaxZ = max(spatialBounds.MaxZ, vertex.Z())
+	}
+	return spatialBounds
+}
+
+func indexAot(grid *SpatialGrid, superIds []int, id int, header AotHeader, bounds *geometry.Quad) []int {
+	if header.Super != 0 {
+		return append(superIds, id)
+	}
+	grid.Insert(id, getQuadBounds(bounds))
+	return superIds
+}
+
+func (aotManager *AotManager) indexDoor(id int) {
+	door := &aotManager.Doors[id]
+	aotManager.superDoors = indexAot(aotManager.doorGrid, aotManager.superDoors, id, door.Header, door.Bounds)
+}
+
+func (aotManager *AotManager) indexAotTrigger(id int) {
+	aot := &aotManager.AotTriggers[id]
+	aotManager.superTriggers = indexAot(aotManager.triggerGrid, aotManager.superTriggers, id, aot.Header, aot.Bounds)
+}
+
+// Builds the grids again if the lists were changed without the add functions
+func (aotManager *AotManager) updateIndex() {
+	if aotManager.doorGrid.Len()+len(aotManager.superDoors) != len(aotManager.Doors) {
+		aotManager.doorGrid.Clear()
+		aotManager.superDoors = aotManager.superDoors[:0]
+		for i := range aotManager.Doors {
+			aotManager.indexDoor(i)
+		}
+	}
+	if aotManager.triggerGrid.Len()+len(aotManager.superTriggers) != len(aotManager.AotTriggers) {
+		aotManager.triggerGrid.Clear()
+		aotManager.superTriggers = aotManager.superTriggers[:0]
+		for i := range aotManager.AotTriggers {
+			aotManager.indexAotTrigger(i)
 		}
 	}
-	return nil
 }
 
 func (aotManager *AotManager) AddDoorAot(aotInstruction fileio.ScriptInstrDoorAotSet) {
@@ -127,6 +337,7 @@ func (aotManager *AotManager) AddDoorAot(aotInstruction fileio.ScriptInstrDoorAo
 	log.Printf("AOT[%d] door type=%d pos=(%d,%d) size=(%d,%d)",
 		aotInstruction.Aot, aotInstruction.Id, aotInstruction.X, aotInstruction.Z, aotInstruction.Width, aotInstruction.Depth)
 	aotManager.Doors = append(aotManager.Doors, doorAot)
+	aotManager.indexDoor(len(aotManager.Doors) - 1)
 }
 
 func (aotManager *AotManager) AddDoorAot4p(aotInstruction fileio.ScriptInstrDoorAotSet4p) {
@@ -164,6 +375,7 @@ func (aotManager *AotManager) AddDoorAot4p(aotInstruction fileio.ScriptInstrDoor
 
 	log.Printf("AOT[%d] door-4p type=%d", aotInstruction.Aot, aotInstruction.Id)
 	aotManager.Doors = append(aotManager.Doors, doorAot)
+	aotManager.indexDoor(len(aotManager.Doors) - 1)
 }
 
 func (aotManager *AotManager) AddItemAot(aotInstruction fileio.ScriptInstrItemAotSet) {
@@ -240,6 +452,7 @@ func (aotManager *AotManager) AddAotTrigger(aotInstruction fileio.ScriptInstrAot
 	log.Printf("AOT[%d] trigger type=%d pos=(%d,%d) size=(%d,%d)",
 		aotInstruction.Aot, aotInstruction.Id, aotInstruction.X, aotInstruction.Z, aotInstruction.Width, aotInstruction.Depth)
 	aotManager.AotTriggers = append(aotManager.AotTriggers, aotTrigger)
+	aotManager.indexAotTrigger(len(aotManager.AotTriggers) - 1)
 }
 
 func (aotManager *AotManager) AddAotTrigger4p(aotInstruction fileio.ScriptInstrAotSet4p) {
@@ -264,6 +477,7 @@ func (aotManager *AotManager) AddAotTrigger4p(aotInstruction fileio.ScriptInstrA
 
 	log.Printf("AOT[%d] trigger-4p type=%d", aotInstruction.Aot, aotInstruction.Id)
 	aotManager.AotTriggers = append(aotManager.AotTriggers, aotTrigger)
+	aotManager.indexAotTrigger(len(aotManager.AotTriggers) - 1)
 }
 
 func (aotManager *AotManager) ResetAotTrigger(aotInstruction fileio.ScriptInstrAotReset) {
@@ -275,6 +489,8 @@ func (aotManager *AotManager) ResetAotTrigger(aotInstruction fileio.ScriptInstrA
 			aot.Header.Type = aotInstruction.Type
 			aot.Data = aotInstruction.Data
 			aotManager.AotTriggers[i] = aot
+			// Scripts reset an aot to nothing after its event so it doesn't fire again
+			aotManager.SetAotEnabled(aotInstruction.Aot, aotInstruction.Id != AOT_NONE)
 			return
 		}
 	}
@@ -292,4 +508,5 @@ func (aotManager *AotManager) ResetAotTrigger(aotInstruction fileio.ScriptInstrA
 		Data:   aotInstruction.Data,
 	}
 	aotManager.AotTriggers = append(aotManager.AotTriggers, aotTrigger)
+	aotManager.indexAotTrigger(len(aotManager.AotTriggers) - 1)
 }
@@ -0,0 +1,105 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func newTestAotTrigger(aot uint8, aotType uint8, floor uint8, super uint8) fileio.ScriptInstrAotSet {
+	return fileio.ScriptInstrAotSet{
+		Aot: aot, Id: AOT_EVENT, Type: aotType, Floor: floor, Super: super,
+		X: 0, Z: 0, Width: 100, Depth: 100,
+	}
+}
+
+func TestGetAotTriggerNearPlayer_ActivationModes(t *testing.T) {
+	tests := []struct {
+		name          string
+		aotType       uint8
+		actionPressed [3]bool
+		expected      [3]bool
+	}{
+		{"fires once on enter", AOT_TYPE_PLAYER, [3]bool{false, false, true}, [3]bool{true, false, false}},
+		{"manual needs action button", AOT_TYPE_PLAYER | AOT_TYPE_MANUAL, [3]bool{false, true, true}, [3]bool{false, true, true}},
+	}
+
+	for _, test := range tests {
+		aotManager := NewAotManager()
+		aotManager.AddAotTrigger(newTestAotTrigger(1, test.aotType, 0, 0))
+		for frame := 0; frame < 3; frame++ {
+			aot := aotManager.GetAotTriggerNearPlayer(mgl32.Vec3{50, 0, 50}, test.actionPressed[frame])
+			if (aot != nil) != test.expected[frame] {
+				t.Errorf("%s: frame %d expected triggered=%v, got %v", test.name, frame, test.expected[frame], aot != nil)
+			}
+		}
+	}
+}
+
+func TestGetAotTriggerNearPlayer_ReentersAfterLeaving(t *testing.T) {
+	aotManager := NewAotManager()
+	aotManager.AddAotTrigger(newTestAotTrigger(1, AOT_TYPE_PLAYER, 0, 0))
+
+	inside := mgl32.Vec3{50, 0, 50}
+	outside := mgl32.Vec3{500, 0, 500}
+	aotManager.GetAotTriggerNearPlayer(inside, false)
+	aotManager.GetAotTriggerNearPlayer(outside, false)
+	if aotManager.GetAotTriggerNearPlayer(inside, false) == nil {
+		t.Error("Expected trigger to fire again after the player left")
+	}
+}
+
+func TestGetAotTriggerNearPlayer_NotPlayer(t *testing.T) {
+	aotManager := NewAotManager()
+	aotManager.AddAotTrigger(newTestAotTrigger(1, AOT_TYPE_MANUAL, AOT_FLOOR_ANY, 0))
+
+	if aotManager.GetAotTriggerNearPlayer(mgl32.Vec3{50, 0, 50}, true) != nil {
+		t.Error("Expected trigger without the player flag to be ignored")
+	}
+}
+
+func TestGetAotTriggerNearPlayer_Floor(t *testing.T) {
+	aotManager := NewAotManager()
+	aotManager.AddAotTrigger(newTestAotTrigger(1, AOT_TYPE_PLAYER, 1, 0))
+
+	if aotManager.GetAotTriggerNearPlayer(mgl32.Vec3{50, 0, 50}, false) != nil {
+		t.Error("Expected trigger on another floor to be ignored")
+	}
+	if aotManager.GetAotTriggerNearPlayer(mgl32.Vec3{50, fileio.FLOOR_HEIGHT_UNIT, 50}, false) == nil {
+		t.Error("Expected trigger on the same floor to fire")
+	}
+}
+
+func TestGetAotTriggerNearPlayer_Disabled(t *testing.T) {
+	aotManager := NewAotManager()
+	aotManager.AddAotTrigger(newTestAotTrigger(1, AOT_TYPE_PLAYER, AOT_FLOOR_ANY, 0))
+
+	// Reset to nothing after the event
+	aotManager.ResetAotTrigger(fileio.ScriptInstrAotReset{Aot: 1, Id: AOT_NONE})
+	if aotManager.IsAotEnabled(1) {
+		t.Fatal("Expected aot to be disabled")
+	}
+	if aotManager.GetAotTriggerNearPlayer(mgl32.Vec3{50, 0, 50}, false) != nil {
+		t.Error("Expected disabled trigger to be ignored")
+	}
+
+	aotManager.ResetAotTrigger(fileio.ScriptInstrAotReset{Aot: 1, Id: AOT_EVENT})
+	if !aotManager.IsAotEnabled(1) {
+		t.Error("Expected aot to be enabled")
+	}
+}
+
+func TestGetAotTriggerNearPlayer_Super(t *testing.T) {
+	aotManager := NewAotManager()
+	aotManager.AddAotTrigger(newTestAotTrigger(1, AOT_TYPE_PLAYER, AOT_FLOOR_ANY, 2))
+
+	if aotManager.GetAotTriggerNearPlayer(mgl32.Vec3{50, 0, 50}, false) != nil {
+		t.Error("Expected aot without a super position to be ignored")
+	}
+
+	aotManager.SetSuperPosition(2, mgl32.Vec3{1000, 0, 1000})
+	if aotManager.GetAotTriggerNearPlayer(mgl32.Vec3{1050, 0, 1050}, false) == nil {
+		t.Error("Expected aot to move with the super position")
+	}
+}
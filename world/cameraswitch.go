@@ -10,6 +10,8 @@ import (
 type CameraSwitchHandler struct {
 	CameraSwitches          []fileio.RVDHeader
 	CameraSwitchTransitions map[int][]int
+	CameraReplacements      map[int]int  // Switches to the key camera go to the value camera instead
+	DisabledCameras         map[int]bool // Switches to these cameras are ignored
 }
 
 func NewCameraSwitchHandler(cameraSwitches []fileio.RVDHeader, maxCamerasInRoom int) *CameraSwitchHandler {
@@ -44,6 +46,8 @@ func NewCameraSwitchHandler(cameraSwitches []fileio.RVDHeader, maxCamerasInRoom
 	return &CameraSwitchHandler{
 		CameraSwitches:          cameraSwitches,
 		CameraSwitchTransitions: cameraSwitchTransitions,
+		CameraReplacements:      make(map[int]int),
+		DisabledCameras:         make(map[int]bool),
 	}
 }
 
@@ -62,9 +66,40 @@ func (cameraSwitchHandler *CameraSwitchHandler) GetCameraSwitchNewRegion(positio
 			continue
 		}
 
+		// A replacement can point the switch back to the current camera, which would reload it every frame
+		targetCameraId := cameraSwitchHandler.GetTargetCamera(region)
+		if targetCameraId == curCameraId || cameraSwitchHandler.DisabledCameras[targetCameraId] {
+			continue
+		}
+
 		if isPointInRectangle(position, corner1, corner2, corner3, corner4) {
 			return &region
 		}
 	}
 	return nil
 }
+
+// Camera that the region switches to after replacements
+func (cameraSwitchHandler *CameraSwitchHandler) GetTargetCamera(region fileio.RVDHeader) int {
+	cameraId := int(region.Cam1)
+	if newCameraId, exists := cameraSwitchHandler.CameraReplacements[cameraId]; exists {
+		return newCameraId
+	}
+	return cameraId
+}
+
+func (cameraSwitchHandler *CameraSwitchHandler) ReplaceCamera(oldCameraId int, newCameraId int) {
+	if oldCameraId == newCameraId {
+		delete(cameraSwitchHandler.CameraReplacements, oldCameraId)
+		return
+	}
+	cameraSwitchHandler.CameraReplacements[oldCameraId] = newCameraId
+}
+
+func (cameraSwitchHandler *CameraSwitchHandler) SetCameraEnabled(cameraId int, enabled bool) {
+	if enabled {
+		delete(cameraSwitchHandler.DisabledCameras, cameraId)
+		return
+	}
+	cameraSwitchHandler.DisabledCameras[cameraId] = true
+}
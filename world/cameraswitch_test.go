@@ -311,6 +311,55 @@ func TestCameraSwitchHandler_Integration(t *testing.T) {
 	}
 }
 
+func TestCameraSwitchHandler_ReplaceAndDisable(t *testing.T) {
+	cameraSwitches := []fileio.RVDHeader{
+		{Cam0: 0, Cam1: 1, X1: 0, Z1: 0, X2: 10, Z2: 0, X3: 10, Z3: 10, X4: 0, Z4: 10, Floor: 0},
+	}
+	handler := NewCameraSwitchHandler(cameraSwitches, 3)
+	position := mgl32.Vec3{5, 0, 5}
+
+	handler.ReplaceCamera(1, 2)
+	region := handler.GetCameraSwitchNewRegion(position, 0)
+	if region == nil || handler.GetTargetCamera(*region) != 2 {
+		t.Fatalf("Expected switch to replaced camera 2, got %v", region)
+	}
+
+	// Replacing a camera with itself removes the replacement
+	handler.ReplaceCamera(1, 1)
+	if handler.GetTargetCamera(*region) != 1 {
+		t.Errorf("Expected switch to camera 1, got %d", handler.GetTargetCamera(*region))
+	}
+
+	handler.SetCameraEnabled(1, false)
+	if region := handler.GetCameraSwitchNewRegion(position, 0); region != nil {
+		t.Errorf("Expected no switch to disabled camera, got %v", region)
+	}
+
+	handler.SetCameraEnabled(1, true)
+	if region := handler.GetCameraSwitchNewRegion(position, 0); region == nil {
+		t.Error("Expected switch after enabling camera")
+	}
+}
+
+func TestCameraSwitchHandler_ReplaceWithCurrentCamera(t *testing.T) {
+	cameraSwitches := []fileio.RVDHeader{
+		{Cam0: 0, Cam1: 1, X1: 0, Z1: 0, X2: 10, Z2: 0, X3: 10, Z3: 10, X4: 0, Z4: 10, Floor: 0},
+		{Cam0: 0, Cam1: 2, X1: 0, Z1: 0, X2: 20, Z2: 0, X3: 20, Z3: 20, X4: 0, Z4: 20, Floor: 0},
+	}
+	handler := NewCameraSwitchHandler(cameraSwitches, 3)
+
+	// The switch from camera 0 now goes back to camera 0
+	handler.ReplaceCamera(1, 0)
+	if region := handler.GetCameraSwitchNewRegion(mgl32.Vec3{5, 0, 5}, 0); region == nil || region.Cam1 != 2 {
+		t.Errorf("Expected the switch to camera 0 to be skipped for the next region, got %v", region)
+	}
+
+	handler.ReplaceCamera(2, 0)
+	if region := handler.GetCameraSwitchNewRegion(mgl32.Vec3{5, 0, 5}, 0); region != nil {
+		t.Errorf("Expected no switch when every region goes to the current camera, got %v", region)
+	}
+}
+
 // Benchmark tests for performance
 func BenchmarkNewCameraSwitchHandler(b *testing.B) {
 	cameraSwitches := []fileio.RVDHeader{
@@ -19,113 +19,152 @@ func RemoveCollisionEntity(collisionEntities []fileio.CollisionEntity, entityId
 }
 
 func CheckCollision(newPosition mgl32.Vec3, collisionEntities []fileio.CollisionEntity) *fileio.CollisionEntity {
-	playerFloorNum := int(math.Round(float64(newPosition.Y()) / fileio.FLOOR_HEIGHT_UNIT))
-	for _, entity := range collisionEntities {
-		// The boundary is on a different floor than the player
-		if !entity.FloorCheck[playerFloorNum] {
-			continue
+	playerFloorNum := getFloorNum(newPosition)
+	for i := range collisionEntities {
+		if isPointInCollisionEntity(newPosition, playerFloorNum, &collisionEntities[i]) {
+			return &collisionEntities[i]
 		}
+	}
+	return nil
+}
 
-		switch entity.Shape {
-		case 0:
-			// Rectangle
-			corner1 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z)}
-			corner2 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z) + float32(entity.Density)}
-			corner3 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z) + float32(entity.Density)}
-			corner4 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z)}
-			if isPointInRectangle(newPosition, corner1, corner2, corner3, corner4) {
-				return &entity
-			}
-		case 1:
-			// Triangle \\|
-			vertex1 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z + entity.Density)}
-			vertex2 := mgl32.Vec3{float32(entity.X + entity.Width), 0, float32(entity.Z + entity.Density)}
-			vertex3 := mgl32.Vec3{float32(entity.X + entity.Width), 0, float32(entity.Z)}
-			if isPointInTriangle(newPosition, vertex1, vertex2, vertex3) {
-				return &entity
-			}
-		case 2:
-			// Triangle |/
-			vertex1 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z)}
-			vertex2 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z + entity.Density)}
-			vertex3 := mgl32.Vec3{float32(entity.X + entity.Width), 0, float32(entity.Z + entity.Density)}
-			if isPointInTriangle(newPosition, vertex1, vertex2, vertex3) {
-				return &entity
-			}
-		case 3:
-			// Triangle /|
-			vertex1 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z)}
-			vertex2 := mgl32.Vec3{float32(entity.X + entity.Width), 0, float32(entity.Z + entity.Density)}
-			vertex3 := mgl32.Vec3{float32(entity.X + entity.Width), 0, float32(entity.Z)}
-			if isPointInTriangle(newPosition, vertex1, vertex2, vertex3) {
-				return &entity
-			}
-		case 6:
-			// Circle
-			radius := float32(entity.Width) / 2.0
-			center := mgl32.Vec3{float32(entity.X) + radius, 0, float32(entity.Z) + radius}
-			if isPointInCircle(newPosition, center, radius) {
-				return &entity
-			}
-		case 7:
-			// Ellipse, rectangle with rounded corners on the x-axis
-			majorAxis := float32(entity.Width) / 2.0
-			minorAxis := float32(entity.Density) / 2.0
-			center := mgl32.Vec3{float32(entity.X) + majorAxis, 0, float32(entity.Z) + minorAxis}
-			if isPointInEllipseXAxisMajor(newPosition, center, majorAxis, minorAxis) {
-				return &entity
-			}
-		case 8:
-			// Ellipse, rectangle with rounded corners on the z-axis
-			majorAxis := float32(entity.Density) / 2.0
-			minorAxis := float32(entity.Width) / 2.0
-			center := mgl32.Vec3{float32(entity.X) + minorAxis, 0, float32(entity.Z) + majorAxis}
-			if isPointInEllipseZAxisMajor(newPosition, center, majorAxis, minorAxis) {
-				return &entity
-			}
-		case 9:
-			// Rectangle climb up
-			corner1 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z)}
-			corner2 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z) + float32(entity.Density)}
-			corner3 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z) + float32(entity.Density)}
-			corner4 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z)}
-			if isPointInRectangle(newPosition, corner1, corner2, corner3, corner4) {
-				return &entity
-			}
-		case 10:
-			// Rectangle jump down
-			corner1 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z)}
-			corner2 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z) + float32(entity.Density)}
-			corner3 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z) + float32(entity.Density)}
-			corner4 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z)}
-			if isPointInRectangle(newPosition, corner1, corner2, corner3, corner4) {
-				return &entity
-			}
-		case fileio.SCA_TYPE_SLOPE: // 11
-			corner1 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z)}
-			corner2 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z) + float32(entity.Density)}
-			corner3 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z) + float32(entity.Density)}
-			corner4 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z)}
-			if isPointInRectangle(newPosition, corner1, corner2, corner3, corner4) {
-				return &entity
-			}
-		case fileio.SCA_TYPE_STAIRS: // 12
-			corner1 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z)}
-			corner2 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z) + float32(entity.Density)}
-			corner3 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z) + float32(entity.Density)}
-			corner4 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z)}
-			if isPointInRectangle(newPosition, corner1, corner2, corner3, corner4) {
-				return &entity
-			}
+func getFloorNum(position mgl32.Vec3) int {
+	return int(math.Round(float64(position.Y()) / fileio.FLOOR_HEIGHT_UNIT))
+}
+
+func isPointInCollisionEntity(position mgl32.Vec3, floorNum int, entity *fileio.CollisionEntity) bool {
+	// The boundary is on a different floor than the player
+	if !isEntityOnFloor(entity, floorNum) {
+		return false
+	}
+
+	switch entity.Shape {
+	case 0:
+		// Rectangle
+		corner1 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z)}
+		corner2 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z) + float32(entity.Density)}
+		corner3 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z) + float32(entity.Density)}
+		corner4 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z)}
+		if isPointInRectangle(position, corner1, corner2, corner3, corner4) {
+			return true
+		}
+	case 1:
+		// Triangle \\|
+		vertex1 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z + entity.Density)}
+		vertex2 := mgl32.Vec3{float32(entity.X + entity.Width), 0, float32(entity.Z + entity.Density)}
+		vertex3 := mgl32.Vec3{float32(entity.X + entity.Width), 0, float32(entity.Z)}
+		if isPointInTriangle(position, vertex1, vertex2, vertex3) {
+			return true
+		}
+	case 2:
+		// Triangle |/
+		vertex1 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z)}
+		vertex2 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z + entity.Density)}
+		vertex3 := mgl32.Vec3{float32(entity.X + entity.Width), 0, float32(entity.Z + entity.Density)}
+		if isPointInTriangle(position, vertex1, vertex2, vertex3) {
+			return true
+		}
+	case 3:
+		// Triangle /|
+		vertex1 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z)}
+		vertex2 := mgl32.Vec3{float32(entity.X + entity.Width), 0, float32(entity.Z + entity.Density)}
+		vertex3 := mgl32.Vec3{float32(entity.X + entity.Width), 0, float32(entity.Z)}
+		if isPointInTriangle(position, vertex1, vertex2, vertex3) {
+			return true
+		}
+	case 6:
+		// Circle
+		radius := float32(entity.Width) / 2.0
+		center := mgl32.Vec3{float32(entity.X) + radius, 0, float32(entity.Z) + radius}
+		if isPointInCircle(position, center, radius) {
+			return true
+		}
+	case 7:
+		// Ellipse, rectangle with rounded corners on the x-axis
+		majorAxis := float32(entity.Width) / 2.0
+		minorAxis := float32(entity.Density) / 2.0
+		center := mgl32.Vec3{float32(entity.X) + majorAxis, 0, float32(entity.Z) + minorAxis}
+		if isPointInEllipseXAxisMajor(position, center, majorAxis, minorAxis) {
+			return true
+		}
+	case 8:
+		// Ellipse, rectangle with rounded corners on the z-axis
+		majorAxis := float32(entity.Density) / 2.0
+		minorAxis := float32(entity.Width) / 2.0
+		center := mgl32.Vec3{float32(entity.X) + minorAxis, 0, float32(entity.Z) + majorAxis}
+		if isPointInEllipseZAxisMajor(position, center, majorAxis, minorAxis) {
+			return true
+		}
+	case 9:
+		// Rectangle climb up
+		corner1 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z)}
+		corner2 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z) + float32(entity.Density)}
+		corner3 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z) + float32(entity.Density)}
+		corner4 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z)}
+		if isPointInRectangle(position, corner1, corner2, corner3, corner4) {
+			return true
+		}
+	case 10:
+		// Rectangle jump down
+		corner1 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z)}
+		corner2 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z) + float32(entity.Density)}
+		corner3 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z) + float32(entity.Density)}
+		corner4 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z)}
+		if isPointInRectangle(position, corner1, corner2, corner3, corner4) {
+			return true
+		}
+	case fileio.SCA_TYPE_SLOPE: // 11
+		corner1 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z)}
+		corner2 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z) + float32(entity.Density)}
+		corner3 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z) + float32(entity.Density)}
+		corner4 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z)}
+		if isPointInRectangle(position, corner1, corner2, corner3, corner4) {
+			return true
+		}
+	case fileio.SCA_TYPE_STAIRS: // 12
+		corner1 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z)}
+		corner2 := mgl32.Vec3{float32(entity.X), 0, float32(entity.Z) + float32(entity.Density)}
+		corner3 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z) + float32(entity.Density)}
+		corner4 := mgl32.Vec3{float32(entity.X) + float32(entity.Width), 0, float32(entity.Z)}
+		if isPointInRectangle(position, corner1, corner2, corner3, corner4) {
+			return true
 		}
 	}
-	return nil
+	return false
 }
 
 func CheckRamp(entity *fileio.CollisionEntity) bool {
 	return entity.Shape == fileio.SCA_TYPE_SLOPE || entity.Shape == fileio.SCA_TYPE_STAIRS
 }
 
+// Height of the ground under the position from the SCA data
+// Ramps and stairs rise by their slope height, elsewhere the ground is the level of the floor the position is on
+func GetFloorHeight(position mgl32.Vec3, collisionEntities []fileio.CollisionEntity) float32 {
+	floorNum := getFloorNum(position)
+	for i := range collisionEntities {
+		entity := &collisionEntities[i]
+		if CheckRamp(entity) && isPointInCollisionEntity(position, floorNum, entity) {
+			return GetRampHeight(position, entity)
+		}
+	}
+	return float32(floorNum) * fileio.FLOOR_HEIGHT_UNIT
+}
+
+// Height on the ramp or stairs at the position, which goes up from the bottom edge to the slope height
+func GetRampHeight(position mgl32.Vec3, entity *fileio.CollisionEntity) float32 {
+	distanceFromRampBottom := 0.0
+
+	// Check slope type orientation
+	if entity.SlopeType == 0 || entity.SlopeType == 1 {
+		// ramp bottom is on the x-axis
+		distanceFromRampBottom = math.Abs(float64(position.X()-entity.RampBottom)) / float64(entity.Width)
+	} else if entity.SlopeType == 2 || entity.SlopeType == 3 {
+		// ramp bottom is on the z-axis
+		distanceFromRampBottom = math.Abs(float64(position.Z()-entity.RampBottom)) / float64(entity.Density)
+	}
+	return float32(float64(entity.SlopeHeight) * distanceFromRampBottom)
+}
+
 func CheckNearbyBoxClimb(playerPosition mgl32.Vec3, collisionEntities []fileio.CollisionEntity) bool {
 	for _, entity := range collisionEntities {
 		switch entity.Shape {
@@ -153,6 +153,28 @@ func TestCheckRamp(t *testing.T) {
 	}
 }
 
+func TestGetFloorHeight(t *testing.T) {
+	collisionEntities := []fileio.CollisionEntity{
+		{X: 2000, Z: 0, Width: 1000, Density: 1000, Shape: 0, FloorCheck: []bool{true, true}},
+		{
+			X: 0, Z: 0, Width: 1000, Density: 1000, Shape: fileio.SCA_TYPE_STAIRS, FloorCheck: []bool{true, true},
+			SlopeHeight: fileio.FLOOR_HEIGHT_UNIT, SlopeType: 0, RampBottom: 0,
+		},
+	}
+
+	// Flat floor is at the floor level
+	height := GetFloorHeight(mgl32.Vec3{5000, -1700, 5000}, collisionEntities)
+	if height != fileio.FLOOR_HEIGHT_UNIT {
+		t.Errorf("Expected floor height %d, got %f", fileio.FLOOR_HEIGHT_UNIT, height)
+	}
+
+	// Stairs go up from the bottom edge, whatever the height of the position
+	height = GetFloorHeight(mgl32.Vec3{500, -600, 500}, collisionEntities)
+	if height != fileio.FLOOR_HEIGHT_UNIT/2 {
+		t.Errorf("Expected stairs height %d, got %f", fileio.FLOOR_HEIGHT_UNIT/2, height)
+	}
+}
+
 func TestCheckNearbyBoxClimb(t *testing.T) {
 	collisionEntities := []fileio.CollisionEntity{
 		{
@@ -0,0 +1,307 @@
+package world
+
+import (
+	"math"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	// Number of times overlapping walls are pushed apart before giving up
+	// Corners need more than one pass because pushing out of one wall can move into the other
+	COLLISION_RESOLVE_ITERATIONS = 4
+
+	// Extra distance added when pushing out so the circle doesn't touch the wall on the next check
+	COLLISION_SKIN = 0.5
+)
+
+// Where a collision circle overlaps a boundary
+// Normal points from the boundary towards the circle on the floor plane
+// Depth is how far the circle has to move along the normal to stop overlapping
+type CollisionContact struct {
+	Entity *fileio.CollisionEntity
+	Normal mgl32.Vec3
+	Depth  float32
+}
+
+// Walls and furniture that block movement
+// Boxes to climb, slopes and stairs are handled by the point checks in CheckCollision
+func IsSolidCollisionShape(shape int) bool {
+	switch shape {
+	case 0, 1, 2, 3, 6, 7, 8:
+		return true
+	}
+	return false
+}
+
+// Returns the deepest contact, or nil if the circle doesn't overlap any solid boundary
+func CheckCircleCollision(center mgl32.Vec3, radius float32, collisionEntities []fileio.CollisionEntity) *CollisionContact {
+	floorNum := getFloorNum(center)
+	var deepestContact *CollisionContact
+	for i := range collisionEntities {
+		deepestContact = getDeeperContact(center, radius, floorNum, &collisionEntities[i], deepestContact)
+	}
+	return deepestContact
+}
+
+// Returns the contact with the entity if it is deeper than the current one
+func getDeeperContact(center mgl32.Vec3, radius float32, floorNum int, entity *fileio.CollisionEntity, deepestContact *CollisionContact) *CollisionContact {
+	if !IsSolidCollisionShape(entity.Shape) || !isEntityOnFloor(entity, floorNum) {
+		return deepestContact
+	}
+	normal, depth, ok := getCircleContact(center, radius, entity)
+	if !ok || (deepestContact != nil && depth <= deepestContact.Depth) {
+		return deepestContact
+	}
+	return &CollisionContact{Entity: entity, Normal: normal, Depth: depth}
+}
+
+// Moves the circle out of every solid boundary it overlaps
+// Movement into a wall is removed while movement along it is kept, so entities slide along walls
+// Returns the new position and the last contact, which is nil if nothing was hit
+func ResolveCircleCollision(center mgl32.Vec3, radius float32, collisionEntities []fileio.CollisionEntity) (mgl32.Vec3, *CollisionContact) {
+	return resolveCircleCollision(center, radius, func(center mgl32.Vec3) *CollisionContact {
+		return CheckCircleCollision(center, radius, collisionEntities)
+	})
+}
+
+func resolveCircleCollision(center mgl32.Vec3, radius float32, checkCollision func(center mgl32.Vec3) *CollisionContact) (mgl32.Vec3, *CollisionContact) {
+	var lastContact *CollisionContact
+	for i := 0; i < COLLISION_RESOLVE_ITERATIONS; i++ {
+		contact := checkCollision(center)
+		if contact == nil {
+			break
+		}
+		center = center.Add(contact.Normal.Mul(contact.Depth + COLLISION_SKIN))
+		lastContact = contact
+	}
+	return center, lastContact
+}
+
+func isEntityOnFloor(entity *fileio.CollisionEntity, floorNum int) bool {
+	return floorNum >= 0 && floorNum < len(entity.FloorCheck) && entity.FloorCheck[floorNum]
+}
+
+// The vertices are the same as the ones used by CheckCollision
+func getCircleContact(center mgl32.Vec3, radius float32, entity *fileio.CollisionEntity) (mgl32.Vec3, float32, bool) {
+	x := float32(entity.X)
+	z := float32(entity.Z)
+	width := float32(entity.Width)
+	density := float32(entity.Density)
+
+	switch entity.Shape {
+	case 0:
+		// Rectangle
+		return getPolygonCircleContact(center, radius, []mgl32.Vec3{
+			{x, 0, z}, {x, 0, z + density}, {x + width, 0, z + density}, {x + width, 0, z},
+		})
+	case 1:
+		// Triangle \\|
+		return getPolygonCircleContact(center, radius, []mgl32.Vec3{
+			{x, 0, z + density}, {x + width, 0, z + density}, {x + width, 0, z},
+		})
+	case 2:
+		// Triangle |/
+		return getPolygonCircleContact(center, radius, []mgl32.Vec3{
+			{x, 0, z}, {x, 0, z + density}, {x + width, 0, z + density},
+		})
+	case 3:
+		// Triangle /|
+		return getPolygonCircleContact(center, radius, []mgl32.Vec3{
+			{x, 0, z}, {x + width, 0, z + density}, {x + width, 0, z},
+		})
+	case 6:
+		// Circle
+		circleRadius := width / 2.0
+		return getCircleCircleContact(center, radius, mgl32.Vec3{x + circleRadius, 0, z + circleRadius}, circleRadius)
+	case 7, 8:
+		// Ellipse
+		radiusX := width / 2.0
+		radiusZ := density / 2.0
+		return getEllipseCircleContact(center, radius, mgl32.Vec3{x + radiusX, 0, z + radiusZ}, radiusX, radiusZ)
+	}
+	return mgl32.Vec3{}, 0, false
+}
+
+// Works for any convex polygon on the floor plane in either winding order
+func getPolygonCircleContact(center mgl32.Vec3, radius float32, vertices []mgl32.Vec3) (mgl32.Vec3, float32, bool) {
+	point := mgl32.Vec2{center.X(), center.Z()}
+
+	// Outward normals depend on the winding order
+	signedArea := float32(0)
+	for i := range vertices {
+		next := vertices[(i+1)%len(vertices)]
+		signedArea += vertices[i].X()*next.Z() - next.X()*vertices[i].Z()
+	}
+	if signedArea == 0 {
+		return mgl32.Vec3{}, 0, false
+	}
+
+	isInside := true
+	closestEdgeDistance := float32(-math.MaxFloat32)
+	var closestEdgeNormal mgl32.Vec2
+	closestPointDistance := float32(math.MaxFloat32)
+	var closestPoint mgl32.Vec2
+	for i := range vertices {
+		start := mgl32.Vec2{vertices[i].X(), vertices[i].Z()}
+		next := vertices[(i+1)%len(vertices)]
+		end := mgl32.Vec2{next.X(), next.Z()}
+		edge := end.Sub(start)
+		if edge.Len() == 0 {
+			continue
+		}
+
+		normal := mgl32.Vec2{edge.Y(), -edge.X()}.Normalize()
+		if signedArea < 0 {
+			normal = normal.Mul(-1)
+		}
+		edgeDistance := point.Sub(start).Dot(normal)
+		if edgeDistance > 0 {
+			isInside = false
+		}
+		if edgeDistance > closestEdgeDistance {
+			closestEdgeDistance = edgeDistance
+			closestEdgeNormal = normal
+		}
+
+		edgePoint := closestPointOnSegment(point, start, end)
+		if distance := point.Sub(edgePoint).Len(); distance < closestPointDistance {
+			closestPointDistance = distance
+			closestPoint = edgePoint
+		}
+	}
+
+	if isInside {
+		// Push out through the nearest edge
+		return mgl32.Vec3{closestEdgeNormal.X(), 0, closestEdgeNormal.Y()}, radius - closestEdgeDistance, true
+	}
+	if closestPointDistance >= radius {
+		return mgl32.Vec3{}, 0, false
+	}
+	normal := point.Sub(closestPoint).Mul(1 / closestPointDistance)
+	return mgl32.Vec3{normal.X(), 0, normal.Y()}, radius - closestPointDistance, true
+}
+
+func closestPointOnSegment(point mgl32.Vec2, start mgl32.Vec2, end mgl32.Vec2) mgl32.Vec2 {
+	edge := end.Sub(start)
+	t := point.Sub(start).Dot(edge) / edge.Dot(edge)
+	return start.Add(edge.Mul(mgl32.Clamp(t, 0, 1)))
+}
+
+func getCircleCircleContact(center mgl32.Vec3, radius float32, circleCenter mgl32.Vec3, circleRadius float32) (mgl32.Vec3, float32, bool) {
+	delta := mgl32.Vec3{center.X() - circleCenter.X(), 0, center.Z() - circleCenter.Z()}
+	distance := delta.Len()
+	if distance >= radius+circleRadius {
+		return mgl32.Vec3{}, 0, false
+	}
+	if distance == 0 {
+		// Any direction works from the center
+		return mgl32.Vec3{1, 0, 0}, radius + circleRadius, true
+	}
+	return delta.Mul(1 / distance), radius + circleRadius - distance, true
+}
+
+// Uses the closest point on the ellipse, so long thin ellipses are only touched where they are
+func getEllipseCircleContact(center mgl32.Vec3, radius float32, ellipseCenter mgl32.Vec3, radiusX float32, radiusZ float32) (mgl32.Vec3, float32, bool) {
+	if radiusX <= 0 || radiusZ <= 0 {
+		return mgl32.Vec3{}, 0, false
+	}
+	deltaX := center.X() - ellipseCenter.X()
+	deltaZ := center.Z() - ellipseCenter.Z()
+
+	// Nothing outside the box around the ellipse can touch it
+	if float32(math.Abs(float64(deltaX))) >= radiusX+radius || float32(math.Abs(float64(deltaZ))) >= radiusZ+radius {
+		return mgl32.Vec3{}, 0, false
+	}
+
+	// Solved in the first quadrant with the longer axis first, then mirrored back
+	signX := math.Copysign(1, float64(deltaX))
+	signZ := math.Copysign(1, float64(deltaZ))
+	pointX := math.Abs(float64(deltaX))
+	pointZ := math.Abs(float64(deltaZ))
+	isSwapped := radiusZ > radiusX
+	var closestX, closestZ float64
+	if isSwapped {
+		closestZ, closestX = getClosestPointOnEllipse(float64(radiusZ), float64(radiusX), pointZ, pointX)
+	} else {
+		closestX, closestZ = getClosestPointOnEllipse(float64(radiusX), float64(radiusZ), pointX, pointZ)
+	}
+
+	offsetX := (pointX - closestX) * signX
+	offsetZ := (pointZ - closestZ) * signZ
+	distance := math.Hypot(offsetX, offsetZ)
+	isInside := (pointX*pointX)/float64(radiusX*radiusX)+(pointZ*pointZ)/float64(radiusZ*radiusZ) < 1
+
+	var normal mgl32.Vec3
+	if distance == 0 {
+		// On the edge, so the normal of the ellipse is used
+		normal = mgl32.Vec3{float32(closestX*signX) / (radiusX * radiusX), 0, float32(closestZ*signZ) / (radiusZ * radiusZ)}.Normalize()
+	} else {
+		normal = mgl32.Vec3{float32(offsetX / distance), 0, float32(offsetZ / distance)}
+	}
+	if isInside {
+		// Leave through the closest point
+		return normal.Mul(-1), radius + float32(distance), true
+	}
+	if float32(distance) >= radius {
+		return mgl32.Vec3{}, 0, false
+	}
+	return normal, radius - float32(distance), true
+}
+
+// Number of bisection steps, enough to reach the precision of a float64
+const ellipseClosestPointIterations = 64
+
+// Closest point on the ellipse with radii major >= minor to a point in the first quadrant
+// The parameter of the closest point is found by bisection, which also works from inside the ellipse
+// See "Distance from a Point to an Ellipse" by David Eberly
+func getClosestPointOnEllipse(major float64, minor float64, pointX float64, pointZ float64) (float64, float64) {
+	if pointZ == 0 {
+		// On the major axis the closest point is the end, unless the point is close enough to the center to reach the sides
+		numerator := major * pointX
+		denominator := major*major - minor*minor
+		if numerator < denominator {
+			ratio := numerator / denominator
+			return major * ratio, minor * math.Sqrt(1-ratio*ratio)
+		}
+		return major, 0
+	}
+	if pointX == 0 {
+		return 0, minor
+	}
+
+	unitX := pointX / major
+	unitZ := pointZ / minor
+	value := unitX*unitX + unitZ*unitZ - 1
+	if value == 0 {
+		return pointX, pointZ
+	}
+
+	// Find the root of (ratio * unitX / (s + ratio))^2 + (unitZ / (s + 1))^2 - 1
+	ratio := (major / minor) * (major / minor)
+	scaledX := ratio * unitX
+	low := unitZ - 1
+	high := float64(0)
+	if value > 0 {
+		high = math.Hypot(scaledX, unitZ) - 1
+	}
+	s := low
+	for i := 0; i < ellipseClosestPointIterations; i++ {
+		s = (low + high) / 2
+		if s == low || s == high {
+			break
+		}
+		termX := scaledX / (s + ratio)
+		termZ := unitZ / (s + 1)
+		value = termX*termX + termZ*termZ - 1
+		if value > 0 {
+			low = s
+		} else if value < 0 {
+			high = s
+		} else {
+			break
+		}
+	}
+	return ratio * pointX / (s + ratio), pointZ / (s + 1)
+}
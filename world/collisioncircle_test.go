@@ -0,0 +1,181 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func newTestCollisionEntity(shape int, x int, z int, width int, density int) fileio.CollisionEntity {
+	return fileio.CollisionEntity{
+		Shape:      shape,
+		X:          x,
+		Z:          z,
+		Width:      width,
+		Density:    density,
+		FloorCheck: []bool{true, false, false},
+	}
+}
+
+func TestCheckCircleCollisionShapes(t *testing.T) {
+	tests := []struct {
+		name           string
+		entity         fileio.CollisionEntity
+		position       mgl32.Vec3
+		expectedNormal mgl32.Vec3
+		expectedDepth  float32
+	}{
+		{
+			name:           "Rectangle edge",
+			entity:         newTestCollisionEntity(0, 0, 0, 1000, 1000),
+			position:       mgl32.Vec3{1300, 0, 500},
+			expectedNormal: mgl32.Vec3{1, 0, 0},
+			expectedDepth:  100,
+		},
+		{
+			name:           "Center inside rectangle",
+			entity:         newTestCollisionEntity(0, 0, 0, 1000, 1000),
+			position:       mgl32.Vec3{500, 0, 900},
+			expectedNormal: mgl32.Vec3{0, 0, 1},
+			expectedDepth:  500,
+		},
+		{
+			name:           "Rectangle corner",
+			entity:         newTestCollisionEntity(0, 0, 0, 1000, 1000),
+			position:       mgl32.Vec3{1240, 0, 1180},
+			expectedNormal: mgl32.Vec3{0.8, 0, 0.6},
+			expectedDepth:  100,
+		},
+		{
+			name:           "Triangle slanted side",
+			entity:         newTestCollisionEntity(2, 0, 0, 1000, 1000), // |/ with the slanted side from (0,0) to (1000,1000)
+			position:       mgl32.Vec3{700, 0, 300},
+			expectedNormal: mgl32.Vec3{0.70710677, 0, -0.70710677},
+			expectedDepth:  400 - 282.84271,
+		},
+		{
+			name:           "Circle",
+			entity:         newTestCollisionEntity(6, 0, 0, 1000, 1000),
+			position:       mgl32.Vec3{500, 0, 1300},
+			expectedNormal: mgl32.Vec3{0, 0, 1},
+			expectedDepth:  100,
+		},
+		{
+			name:           "Ellipse on the major axis",
+			entity:         newTestCollisionEntity(7, 0, 0, 2000, 1000),
+			position:       mgl32.Vec3{2300, 0, 500},
+			expectedNormal: mgl32.Vec3{1, 0, 0},
+			expectedDepth:  100,
+		},
+		{
+			name:           "Ellipse on the minor axis",
+			entity:         newTestCollisionEntity(8, 0, 0, 1000, 2000),
+			position:       mgl32.Vec3{1200, 0, 1000},
+			expectedNormal: mgl32.Vec3{1, 0, 0},
+			expectedDepth:  200,
+		},
+		{
+			name:           "Beside a thin ellipse",
+			entity:         newTestCollisionEntity(7, -1000, -10, 2000, 20),
+			position:       mgl32.Vec3{500, 0, 300},
+			expectedNormal: mgl32.Vec3{0.0057477, 0, 0.9999835},
+			expectedDepth:  108.665,
+		},
+		{
+			name:           "End of a thin ellipse",
+			entity:         newTestCollisionEntity(7, -1000, -10, 2000, 20),
+			position:       mgl32.Vec3{1300, 0, 0},
+			expectedNormal: mgl32.Vec3{1, 0, 0},
+			expectedDepth:  100,
+		},
+		{
+			name:           "Inside a thin ellipse",
+			entity:         newTestCollisionEntity(8, -10, -1000, 20, 2000),
+			position:       mgl32.Vec3{5, 0, 0},
+			expectedNormal: mgl32.Vec3{1, 0, 0},
+			expectedDepth:  405,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contact := CheckCircleCollision(tt.position, 400, []fileio.CollisionEntity{tt.entity})
+			if contact == nil {
+				t.Fatal("Expected contact")
+			}
+			if !contact.Normal.ApproxEqualThreshold(tt.expectedNormal, 1e-4) {
+				t.Errorf("Expected normal %v, got %v", tt.expectedNormal, contact.Normal)
+			}
+			if !mgl32.FloatEqualThreshold(contact.Depth, tt.expectedDepth, 1e-2) {
+				t.Errorf("Expected depth %f, got %f", tt.expectedDepth, contact.Depth)
+			}
+		})
+	}
+}
+
+func TestCheckCircleCollisionMisses(t *testing.T) {
+	collisionEntities := []fileio.CollisionEntity{
+		newTestCollisionEntity(0, 0, 0, 1000, 1000),
+		newTestCollisionEntity(9, 2000, 0, 1000, 1000), // Box to climb isn't solid
+	}
+	collisionEntities[0].FloorCheck = []bool{false, true}
+
+	if contact := CheckCircleCollision(mgl32.Vec3{1300, 0, 500}, 400, collisionEntities); contact != nil {
+		t.Errorf("Expected no contact on a different floor, got %+v", contact)
+	}
+	if contact := CheckCircleCollision(mgl32.Vec3{2500, 0, 500}, 400, collisionEntities); contact != nil {
+		t.Errorf("Expected no contact with a box to climb, got %+v", contact)
+	}
+	if contact := CheckCircleCollision(mgl32.Vec3{1300, 0, 500}, 400, nil); contact != nil {
+		t.Errorf("Expected no contact without boundaries, got %+v", contact)
+	}
+
+	// Past the end of a thin ellipse, but inside its box
+	thinEllipse := []fileio.CollisionEntity{newTestCollisionEntity(7, -1000, -10, 2000, 20)}
+	if contact := CheckCircleCollision(mgl32.Vec3{1300, 0, 300}, 400, thinEllipse); contact != nil {
+		t.Errorf("Expected no contact past the end of a thin ellipse, got %+v", contact)
+	}
+	if contact := CheckCircleCollision(mgl32.Vec3{1200, 0, 380}, 400, thinEllipse); contact != nil {
+		t.Errorf("Expected no contact near the end of a thin ellipse, got %+v", contact)
+	}
+}
+
+func TestResolveCircleCollisionSlidesAlongWall(t *testing.T) {
+	collisionEntities := []fileio.CollisionEntity{newTestCollisionEntity(0, 0, 0, 1000, 4000)}
+
+	// Moved diagonally into the wall, only the part into the wall is removed
+	position, contact := ResolveCircleCollision(mgl32.Vec3{1200, 0, 2000}, 400, collisionEntities)
+	if contact == nil {
+		t.Fatal("Expected contact")
+	}
+	if position.Z() != 2000 || position.X() < 1400 || position.X() > 1401 {
+		t.Errorf("Expected position pushed out to x=1400, got %v", position)
+	}
+}
+
+func TestResolveCircleCollisionCorner(t *testing.T) {
+	// Two walls meeting at a right angle
+	collisionEntities := []fileio.CollisionEntity{
+		newTestCollisionEntity(0, 0, 0, 1000, 4000),
+		newTestCollisionEntity(0, 0, 0, 4000, 1000),
+	}
+
+	position, _ := ResolveCircleCollision(mgl32.Vec3{1200, 0, 1300}, 400, collisionEntities)
+	if CheckCircleCollision(position, 400, collisionEntities) != nil {
+		t.Errorf("Expected position out of both walls, got %v", position)
+	}
+}
+
+func BenchmarkResolveCircleCollision(b *testing.B) {
+	collisionEntities := make([]fileio.CollisionEntity, 0)
+	for i := 0; i < 50; i++ {
+		collisionEntities = append(collisionEntities, newTestCollisionEntity(i%10, i*1000, i*500, 800, 800))
+	}
+	position := mgl32.Vec3{25000, 0, 12500}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ResolveCircleCollision(position, 400, collisionEntities)
+	}
+}
@@ -0,0 +1,102 @@
+package world
+
+import (
+	"slices"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Collision boundaries of a room with a grid to only check the ones near a position
+// A boundary can only be hit inside its bounding box, so skipping the ones out of reach
+// gives the same results as CheckCollision and CheckCircleCollision on the whole list
+type CollisionIndex struct {
+	Entities []fileio.CollisionEntity
+	Grid     *SpatialGrid // Ids are indices in Entities
+}
+
+func NewCollisionIndex(collisionEntities []fileio.CollisionEntity) *CollisionIndex {
+	index := &CollisionIndex{
+		Entities: collisionEntities,
+		Grid:     NewSpatialGrid(SPATIAL_GRID_CELL_SIZE),
+	}
+	for i := range collisionEntities {
+		index.Grid.Insert(i, getCollisionEntityBounds(&collisionEntities[i]))
+	}
+	return index
+}
+
+// Every shape fits in the box given by its position, width and density
+// except circles, which only use the width
+func getCollisionEntityBounds(entity *fileio.CollisionEntity) SpatialBounds {
+	density := entity.Density
+	if entity.Shape == 6 {
+		density = entity.Width
+	}
+	return NewSpatialBounds(float32(entity.X), float32(entity.Z),
+		float32(entity.X+entity.Width), float32(entity.Z+density))
+}
+
+func (index *CollisionIndex) AddEntity(entity fileio.CollisionEntity) {
+	index.Entities = append(index.Entities, entity)
+	id := len(index.Entities) - 1
+	index.Grid.Insert(id, getCollisionEntityBounds(&index.Entities[id]))
+}
+
+// Returns false if there is no boundary with the SCA index
+// The entities after it move down, so the grid is built again
+func (index *CollisionIndex) RemoveEntity(scaIndex int) bool {
+	position := slices.IndexFunc(index.Entities, func(entity fileio.CollisionEntity) bool {
+		return entity.ScaIndex == scaIndex
+	})
+	if position < 0 {
+		return false
+	}
+
+	// Copied so the slice shared with the room data isn't changed
+	index.Entities = slices.Delete(slices.Clone(index.Entities), position, position+1)
+	index.Grid.Clear()
+	for i := range index.Entities {
+		index.Grid.Insert(i, getCollisionEntityBounds(&index.Entities[i]))
+	}
+	return true
+}
+
+// Same as CheckCollision, returns the first boundary in the list that contains the position
+func (index *CollisionIndex) CheckCollision(position mgl32.Vec3) *fileio.CollisionEntity {
+	floorNum := getFloorNum(position)
+	for _, id := range index.Grid.QueryPoint(position) {
+		if isPointInCollisionEntity(position, floorNum, &index.Entities[id]) {
+			return &index.Entities[id]
+		}
+	}
+	return nil
+}
+
+// Same as CheckCircleCollision, returns the deepest contact with a solid boundary
+func (index *CollisionIndex) CheckCircleCollision(center mgl32.Vec3, radius float32) *CollisionContact {
+	floorNum := getFloorNum(center)
+	var deepestContact *CollisionContact
+	for _, id := range index.Grid.QueryCircle(center, radius) {
+		deepestContact = getDeeperContact(center, radius, floorNum, &index.Entities[id], deepestContact)
+	}
+	return deepestContact
+}
+
+// Same as ResolveCircleCollision
+func (index *CollisionIndex) ResolveCircleCollision(center mgl32.Vec3, radius float32) (mgl32.Vec3, *CollisionContact) {
+	return resolveCircleCollision(center, radius, func(center mgl32.Vec3) *CollisionContact {
+		return index.CheckCircleCollision(center, radius)
+	})
+}
+
+// Returns the boundaries whose bounding boxes the segment crosses, such as walls blocking a line of sight
+// The exact shape and the floor aren't checked
+func (index *CollisionIndex) QuerySegment(start mgl32.Vec3, end mgl32.Vec3) []*fileio.CollisionEntity {
+	ids := index.Grid.QuerySegment(start, end)
+	entities := make([]*fileio.CollisionEntity, len(ids))
+	for i, id := range ids {
+		entities[i] = &index.Entities[id]
+	}
+	return entities
+}
@@ -0,0 +1,305 @@
+package world
+
+import (
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/OpenBiohazard2/OpenBiohazard2/geometry"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Boundaries spread over a room about the size of the largest rooms in the game
+func newTestRoomCollisionEntities(count int) []fileio.CollisionEntity {
+	random := rand.New(rand.NewSource(1))
+	shapes := []int{0, 1, 2, 3, 6, 7, 8, 9, 10}
+	collisionEntities := make([]fileio.CollisionEntity, count)
+	for i := range collisionEntities {
+		collisionEntities[i] = fileio.CollisionEntity{
+			ScaIndex:   i,
+			Shape:      shapes[i%len(shapes)],
+			X:          random.Intn(40000) - 20000,
+			Z:          random.Intn(40000) - 20000,
+			Width:      500 + random.Intn(3000),
+			Density:    500 + random.Intn(3000),
+			FloorCheck: []bool{true, false},
+		}
+	}
+	return collisionEntities
+}
+
+// Long thin boundaries, where the shape is far from filling its box
+func newTestThinCollisionEntities(count int) []fileio.CollisionEntity {
+	random := rand.New(rand.NewSource(4))
+	shapes := []int{0, 2, 7, 8}
+	collisionEntities := make([]fileio.CollisionEntity, count)
+	for i := range collisionEntities {
+		width, density := 1000+random.Intn(3000), 10+random.Intn(40)
+		if i%2 == 1 {
+			width, density = density, width
+		}
+		collisionEntities[i] = fileio.CollisionEntity{
+			ScaIndex:   i,
+			Shape:      shapes[i%len(shapes)],
+			X:          random.Intn(40000) - 20000,
+			Z:          random.Intn(40000) - 20000,
+			Width:      width,
+			Density:    density,
+			FloorCheck: []bool{true, false},
+		}
+	}
+	return collisionEntities
+}
+
+func newTestPositions(count int) []mgl32.Vec3 {
+	random := rand.New(rand.NewSource(2))
+	positions := make([]mgl32.Vec3, count)
+	for i := range positions {
+		positions[i] = mgl32.Vec3{float32(random.Intn(44000) - 22000), 0, float32(random.Intn(44000) - 22000)}
+	}
+	return positions
+}
+
+func TestCollisionIndexMatchesLinearScan(t *testing.T) {
+	testCollisionIndexMatchesLinearScan(t, newTestRoomCollisionEntities(60), newTestPositions(2000))
+}
+
+func TestCollisionIndexMatchesLinearScanThinShapes(t *testing.T) {
+	collisionEntities := newTestThinCollisionEntities(60)
+
+	// Positions around the ends and sides of each boundary, where the box is far from the shape
+	random := rand.New(rand.NewSource(5))
+	positions := make([]mgl32.Vec3, 0)
+	for _, entity := range collisionEntities {
+		for i := 0; i < 40; i++ {
+			positions = append(positions, mgl32.Vec3{
+				float32(entity.X - 500 + random.Intn(entity.Width+1000)), 0,
+				float32(entity.Z - 500 + random.Intn(entity.Density+1000)),
+			})
+		}
+	}
+	positions = append(positions, mgl32.Vec3{1200, 0, 380})
+
+	// The case from a thin ellipse centered at the origin
+	collisionEntities = append(collisionEntities, fileio.CollisionEntity{
+		ScaIndex: len(collisionEntities), Shape: 7, X: -1000, Z: -10, Width: 2000, Density: 20, FloorCheck: []bool{true, false},
+	})
+	testCollisionIndexMatchesLinearScan(t, collisionEntities, positions)
+}
+
+func testCollisionIndexMatchesLinearScan(t *testing.T, collisionEntities []fileio.CollisionEntity, positions []mgl32.Vec3) {
+	index := NewCollisionIndex(collisionEntities)
+	for _, position := range positions {
+		expected := CheckCollision(position, collisionEntities)
+		result := index.CheckCollision(position)
+		if (expected == nil) != (result == nil) || (expected != nil && expected.ScaIndex != result.ScaIndex) {
+			t.Fatalf("Point %v: expected %v, got %v", position, expected, result)
+		}
+
+		expectedContact := CheckCircleCollision(position, 400, collisionEntities)
+		contact := index.CheckCircleCollision(position, 400)
+		if (expectedContact == nil) != (contact == nil) ||
+			(expectedContact != nil && (expectedContact.Entity.ScaIndex != contact.Entity.ScaIndex || expectedContact.Depth != contact.Depth)) {
+			t.Fatalf("Circle %v: expected %+v, got %+v", position, expectedContact, contact)
+		}
+	}
+}
+
+func TestCollisionIndexQuerySegment(t *testing.T) {
+	index := NewCollisionIndex([]fileio.CollisionEntity{
+		{ScaIndex: 1, Shape: 0, X: 1000, Z: -500, Width: 500, Density: 1000},
+		{ScaIndex: 2, Shape: 6, X: 1000, Z: 2000, Width: 500, Density: 500},
+	})
+
+	entities := index.QuerySegment(mgl32.Vec3{0, 0, 0}, mgl32.Vec3{5000, 0, 0})
+	if len(entities) != 1 || entities[0].ScaIndex != 1 {
+		t.Errorf("Expected the wall in the line of sight, got %v", entities)
+	}
+}
+
+func TestRoomRemoveCollisionEntity(t *testing.T) {
+	collisionEntities := []fileio.CollisionEntity{
+		{ScaIndex: 1, Shape: 0, X: 0, Z: 0, Width: 1000, Density: 1000, FloorCheck: []bool{true}},
+		{ScaIndex: 2, Shape: 0, X: 2000, Z: 0, Width: 1000, Density: 1000, FloorCheck: []bool{true}},
+		{ScaIndex: 3, Shape: 0, X: 4000, Z: 0, Width: 1000, Density: 1000, FloorCheck: []bool{true}},
+	}
+	room := &Room{CollisionEntities: collisionEntities, Collision: NewCollisionIndex(collisionEntities)}
+
+	room.RemoveCollisionEntity(2)
+	if len(room.CollisionEntities) != 2 || room.CollisionEntities[1].ScaIndex != 3 {
+		t.Fatalf("Expected entity 2 to be removed, got %v", room.CollisionEntities)
+	}
+	if room.Collision.CheckCollision(mgl32.Vec3{2500, 0, 500}) != nil {
+		t.Error("Expected no collision where the entity was removed")
+	}
+	if entity := room.Collision.CheckCollision(mgl32.Vec3{4500, 0, 500}); entity == nil || entity.ScaIndex != 3 {
+		t.Errorf("Expected entity 3 after the removed entity, got %v", entity)
+	}
+	if collisionEntities[1].ScaIndex != 2 {
+		t.Error("Expected the room data not to change")
+	}
+}
+
+func newTestAotManager(count int) *AotManager {
+	// Adding aots logs every one of them
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	random := rand.New(rand.NewSource(3))
+	aotManager := NewAotManager()
+	for i := 0; i < count; i++ {
+		x := int16(random.Intn(40000) - 20000)
+		z := int16(random.Intn(40000) - 20000)
+		aotManager.AddDoorAot(fileio.ScriptInstrDoorAotSet{
+			Aot: uint8(i), Floor: AOT_FLOOR_ANY, X: x, Z: z, Width: 1500, Depth: 1500,
+		})
+		aotManager.AddAotTrigger(fileio.ScriptInstrAotSet{
+			Aot: uint8(count + i), Id: AOT_EVENT, Type: AOT_TYPE_PLAYER, Floor: AOT_FLOOR_ANY,
+			X: z, Z: x, Width: 1500, Depth: 1500,
+		})
+	}
+	return aotManager
+}
+
+// The scan that was used before the grid
+func getDoorNearPlayerLinear(aotManager *AotManager, position mgl32.Vec3) *AotDoor {
+	for i := range aotManager.Doors {
+		if aotManager.isPositionInAot(position, aotManager.Doors[i].Header, aotManager.Doors[i].Bounds) {
+			return &aotManager.Doors[i]
+		}
+	}
+	return nil
+}
+
+func getAotTriggerNearPlayerLinear(aotManager *AotManager, position mgl32.Vec3, actionPressed bool) *AotObject {
+	var triggeredAot *AotObject
+	for i := range aotManager.AotTriggers {
+		aot := &aotManager.AotTriggers[i]
+		isInside := aotManager.isPositionInAot(position, aot.Header, aot.Bounds)
+		wasInside := aotManager.playerInsideAots[aot.Header.Aot]
+		aotManager.playerInsideAots[aot.Header.Aot] = isInside
+		if !isInside || triggeredAot != nil {
+			continue
+		}
+		if aot.Header.Type&AOT_TYPE_MANUAL != 0 {
+			if actionPressed {
+				triggeredAot = aot
+			}
+		} else if !wasInside {
+			triggeredAot = aot
+		}
+	}
+	return triggeredAot
+}
+
+func TestAotManagerIndexMatchesLinearScan(t *testing.T) {
+	aotManager := newTestAotManager(40)
+	linearAotManager := newTestAotManager(40)
+
+	for _, position := range newTestPositions(2000) {
+		if door, expected := aotManager.GetDoorNearPlayer(position), getDoorNearPlayerLinear(aotManager, position); door != expected {
+			t.Fatalf("Door at %v: expected %v, got %v", position, expected, door)
+		}
+
+		aot := aotManager.GetAotTriggerNearPlayer(position, false)
+		expected := getAotTriggerNearPlayerLinear(linearAotManager, position, false)
+		if (aot == nil) != (expected == nil) || (aot != nil && aot.Header.Aot != expected.Header.Aot) {
+			t.Fatalf("Trigger at %v: expected %v, got %v", position, expected, aot)
+		}
+	}
+}
+
+func TestAotManagerIndexAfterDirectAppend(t *testing.T) {
+	aotManager := NewAotManager()
+	aotManager.Doors = append(aotManager.Doors, AotDoor{
+		Header: AotHeader{Aot: 1, Floor: AOT_FLOOR_ANY},
+		Bounds: geometry.NewRectangle(0, 0, 100, 100),
+	})
+
+	if aotManager.GetDoorNearPlayer(mgl32.Vec3{50, 0, 50}) == nil {
+		t.Error("Expected door added to the list to be found")
+	}
+}
+
+func BenchmarkCheckCollisionLinear(b *testing.B) {
+	collisionEntities := newTestRoomCollisionEntities(60)
+	positions := newTestPositions(1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CheckCollision(positions[i%len(positions)], collisionEntities)
+	}
+}
+
+func BenchmarkCheckCollisionIndex(b *testing.B) {
+	index := NewCollisionIndex(newTestRoomCollisionEntities(60))
+	positions := newTestPositions(1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index.CheckCollision(positions[i%len(positions)])
+	}
+}
+
+func BenchmarkCheckCircleCollisionLinear(b *testing.B) {
+	collisionEntities := newTestRoomCollisionEntities(60)
+	positions := newTestPositions(1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CheckCircleCollision(positions[i%len(positions)], 400, collisionEntities)
+	}
+}
+
+func BenchmarkCheckCircleCollisionIndex(b *testing.B) {
+	index := NewCollisionIndex(newTestRoomCollisionEntities(60))
+	positions := newTestPositions(1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index.CheckCircleCollision(positions[i%len(positions)], 400)
+	}
+}
+
+func BenchmarkGetDoorNearPlayerLinear(b *testing.B) {
+	aotManager := newTestAotManager(40)
+	positions := newTestPositions(1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		getDoorNearPlayerLinear(aotManager, positions[i%len(positions)])
+	}
+}
+
+func BenchmarkGetDoorNearPlayerIndex(b *testing.B) {
+	aotManager := newTestAotManager(40)
+	positions := newTestPositions(1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		aotManager.GetDoorNearPlayer(positions[i%len(positions)])
+	}
+}
+
+func BenchmarkGetAotTriggerNearPlayerLinear(b *testing.B) {
+	aotManager := newTestAotManager(40)
+	positions := newTestPositions(1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		getAotTriggerNearPlayerLinear(aotManager, positions[i%len(positions)], false)
+	}
+}
+
+func BenchmarkGetAotTriggerNearPlayerIndex(b *testing.B) {
+	aotManager := newTestAotManager(40)
+	positions := newTestPositions(1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		aotManager.GetAotTriggerNearPlayer(positions[i%len(positions)], false)
+	}
+}
@@ -0,0 +1,23 @@
+package world
+
+import (
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Sound used when the position is outside every floor region
+const FLOOR_SOUND_DEFAULT = 0
+
+// Floor regions select the footstep sound for carpet, metal, water and so on
+// The region's Y is the z coordinate on the floor
+func GetFloorSoundEffect(position mgl32.Vec3, floorSounds []fileio.FLRSound) int {
+	for _, floorSound := range floorSounds {
+		minX := float32(floorSound.X)
+		minZ := float32(floorSound.Y)
+		if position.X() >= minX && position.X() <= minX+float32(floorSound.Width) &&
+			position.Z() >= minZ && position.Z() <= minZ+float32(floorSound.Depth) {
+			return int(floorSound.SoundEffect)
+		}
+	}
+	return FLOOR_SOUND_DEFAULT
+}
@@ -0,0 +1,25 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestGetFloorSoundEffect(t *testing.T) {
+	floorSounds := []fileio.FLRSound{
+		{X: 0, Y: 0, Width: 1000, Depth: 1000, SoundEffect: 3},
+		{X: -2000, Y: 500, Width: 500, Depth: 500, SoundEffect: 5},
+	}
+
+	if sound := GetFloorSoundEffect(mgl32.Vec3{500, 0, 500}, floorSounds); sound != 3 {
+		t.Errorf("Expected sound 3, got %d", sound)
+	}
+	if sound := GetFloorSoundEffect(mgl32.Vec3{-1800, -100, 700}, floorSounds); sound != 5 {
+		t.Errorf("Expected sound 5, got %d", sound)
+	}
+	if sound := GetFloorSoundEffect(mgl32.Vec3{5000, 0, 5000}, floorSounds); sound != FLOOR_SOUND_DEFAULT {
+		t.Errorf("Expected default sound, got %d", sound)
+	}
+}
@@ -2,6 +2,8 @@ package world
 
 import (
 	"fmt"
+	"log"
+
 	"github.com/OpenBiohazard2/OpenBiohazard2/fileio"
 )
 
@@ -14,6 +16,8 @@ type Room struct {
 	CameraPositionData  []fileio.CameraInfo
 	CameraSwitchHandler *CameraSwitchHandler
 	CollisionEntities   []fileio.CollisionEntity
+	Collision           *CollisionIndex // Same boundaries as CollisionEntities
+	FloorSounds         []fileio.FLRSound
 	MaxCamerasInRoom    int
 }
 
@@ -32,15 +36,32 @@ func NewRoom(rdtOutput *fileio.RDTOutput) *Room {
 	fmt.Println("Max cameras in room = ", maxCamerasInRoom)
 
 	cameraSwitches := rdtOutput.CameraSwitchData.CameraSwitches
+	var floorSounds []fileio.FLRSound
+	if rdtOutput.FloorSoundData != nil {
+		floorSounds = rdtOutput.FloorSoundData.FloorSounds
+	}
 
 	return &Room{
 		CameraSwitchHandler: NewCameraSwitchHandler(cameraSwitches, maxCamerasInRoom),
 		CameraPositionData:  rdtOutput.RIDOutput.CameraPositions,
 		CollisionEntities:   rdtOutput.CollisionData.CollisionEntities,
+		Collision:           NewCollisionIndex(rdtOutput.CollisionData.CollisionEntities),
+		FloorSounds:         floorSounds,
 		MaxCamerasInRoom:    maxCamerasInRoom,
 	}
 }
 
+// Scripts remove boundaries when furniture is moved out of the way
+func (room *Room) RemoveCollisionEntity(scaIndex int) {
+	if room.Collision == nil {
+		room.Collision = NewCollisionIndex(room.CollisionEntities)
+	}
+	if room.Collision.RemoveEntity(scaIndex) {
+		log.Printf("Removing collision entity id %d", scaIndex)
+	}
+	room.CollisionEntities = room.Collision.Entities
+}
+
 func (room *Room) ClampNewCameraId(newCameraId int) int {
 	cameraId := newCameraId
 	if cameraId >= room.MaxCamerasInRoom {
@@ -0,0 +1,198 @@
+package world
+
+import (
+	"math"
+	"slices"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	// Rooms are a few tens of thousands of units across and most boundaries are a few thousand
+	SPATIAL_GRID_CELL_SIZE = 2000
+)
+
+// Axis aligned box on the floor plane
+type SpatialBounds struct {
+	MinX, MinZ float32
+	MaxX, MaxZ float32
+}
+
+type spatialCell struct {
+	X, Z int
+}
+
+// Uniform grid over the floor plane that finds the boundaries near a position
+// Ids are chosen by the caller, usually the index in the list that is indexed
+// Queries only compare bounding boxes, the caller checks the exact shape
+type SpatialGrid struct {
+	CellSize float32
+	cells    map[spatialCell][]int
+	bounds   map[int]SpatialBounds
+	// Reused by every query so they don't allocate
+	results []int
+}
+
+func NewSpatialGrid(cellSize float32) *SpatialGrid {
+	return &SpatialGrid{
+		CellSize: cellSize,
+		cells:    make(map[spatialCell][]int),
+		bounds:   make(map[int]SpatialBounds),
+		results:  make([]int, 0),
+	}
+}
+
+func NewSpatialBounds(x1 float32, z1 float32, x2 float32, z2 float32) SpatialBounds {
+	return SpatialBounds{
+		MinX: min(x1, x2),
+		MinZ: min(z1, z2),
+		MaxX: max(x1, x2),
+		MaxZ: max(z1, z2),
+	}
+}
+
+func (bounds SpatialBounds) ContainsPoint(x float32, z float32) bool {
+	return x >= bounds.MinX && x <= bounds.MaxX && z >= bounds.MinZ && z <= bounds.MaxZ
+}
+
+func (bounds SpatialBounds) IntersectsCircle(x float32, z float32, radius float32) bool {
+	closestX := mgl32.Clamp(x, bounds.MinX, bounds.MaxX)
+	closestZ := mgl32.Clamp(z, bounds.MinZ, bounds.MaxZ)
+	deltaX := x - closestX
+	deltaZ := z - closestZ
+	return deltaX*deltaX+deltaZ*deltaZ <= radius*radius
+}
+
+// Clips the segment against the box one axis at a time
+func (bounds SpatialBounds) IntersectsSegment(start mgl32.Vec3, end mgl32.Vec3) bool {
+	tMin := float32(0)
+	tMax := float32(1)
+	axes := [2][4]float32{
+		{start.X(), end.X() - start.X(), bounds.MinX, bounds.MaxX},
+		{start.Z(), end.Z() - start.Z(), bounds.MinZ, bounds.MaxZ},
+	}
+	for _, axis := range axes {
+		origin, direction, boundsMin, boundsMax := axis[0], axis[1], axis[2], axis[3]
+		if direction == 0 {
+			if origin < boundsMin || origin > boundsMax {
+				return false
+			}
+			continue
+		}
+		t1 := (boundsMin - origin) / direction
+		t2 := (boundsMax - origin) / direction
+		tMin = max(tMin, min(t1, t2))
+		tMax = min(tMax, max(t1, t2))
+		if tMin > tMax {
+			return false
+		}
+	}
+	return true
+}
+
+func (grid *SpatialGrid) getCell(x float32, z float32) spatialCell {
+	return spatialCell{
+		X: int(math.Floor(float64(x / grid.CellSize))),
+		Z: int(math.Floor(float64(z / grid.CellSize))),
+	}
+}
+
+// Adds the id to every cell the bounds overlap, replacing any bounds it already had
+func (grid *SpatialGrid) Insert(id int, bounds SpatialBounds) {
+	grid.Remove(id)
+	grid.bounds[id] = bounds
+	minCell := grid.getCell(bounds.MinX, bounds.MinZ)
+	maxCell := grid.getCell(bounds.MaxX, bounds.MaxZ)
+	for cellX := minCell.X; cellX <= maxCell.X; cellX++ {
+		for cellZ := minCell.Z; cellZ <= maxCell.Z; cellZ++ {
+			cell := spatialCell{cellX, cellZ}
+			// Cells stay sorted so results are in the same order as a scan of the list
+			ids := grid.cells[cell]
+			index, _ := slices.BinarySearch(ids, id)
+			grid.cells[cell] = slices.Insert(ids, index, id)
+		}
+	}
+}
+
+func (grid *SpatialGrid) Remove(id int) {
+	bounds, exists := grid.bounds[id]
+	if !exists {
+		return
+	}
+	delete(grid.bounds, id)
+	minCell := grid.getCell(bounds.MinX, bounds.MinZ)
+	maxCell := grid.getCell(bounds.MaxX, bounds.MaxZ)
+	for cellX := minCell.X; cellX <= maxCell.X; cellX++ {
+		for cellZ := minCell.Z; cellZ <= maxCell.Z; cellZ++ {
+			cell := spatialCell{cellX, cellZ}
+			ids := grid.cells[cell]
+			if index, found := slices.BinarySearch(ids, id); found {
+				ids = slices.Delete(ids, index, index+1)
+			}
+			if len(ids) == 0 {
+				delete(grid.cells, cell)
+			} else {
+				grid.cells[cell] = ids
+			}
+		}
+	}
+}
+
+func (grid *SpatialGrid) Clear() {
+	clear(grid.cells)
+	clear(grid.bounds)
+}
+
+func (grid *SpatialGrid) Len() int {
+	return len(grid.bounds)
+}
+
+// Returns the ids with bounds that contain the point, sorted by id
+// The slice is reused by the next query
+func (grid *SpatialGrid) QueryPoint(point mgl32.Vec3) []int {
+	grid.results = grid.results[:0]
+	for _, id := range grid.cells[grid.getCell(point.X(), point.Z())] {
+		if grid.bounds[id].ContainsPoint(point.X(), point.Z()) {
+			grid.results = append(grid.results, id)
+		}
+	}
+	return grid.results
+}
+
+// Returns the ids with bounds that overlap the circle, sorted by id
+// The slice is reused by the next query
+func (grid *SpatialGrid) QueryCircle(center mgl32.Vec3, radius float32) []int {
+	queryBounds := NewSpatialBounds(center.X()-radius, center.Z()-radius, center.X()+radius, center.Z()+radius)
+	return grid.query(queryBounds, func(bounds SpatialBounds) bool {
+		return bounds.IntersectsCircle(center.X(), center.Z(), radius)
+	})
+}
+
+// Returns the ids with bounds that the segment crosses, sorted by id
+// The slice is reused by the next query
+func (grid *SpatialGrid) QuerySegment(start mgl32.Vec3, end mgl32.Vec3) []int {
+	queryBounds := NewSpatialBounds(start.X(), start.Z(), end.X(), end.Z())
+	return grid.query(queryBounds, func(bounds SpatialBounds) bool {
+		return bounds.IntersectsSegment(start, end)
+	})
+}
+
+func (grid *SpatialGrid) query(queryBounds SpatialBounds, intersects func(bounds SpatialBounds) bool) []int {
+	grid.results = grid.results[:0]
+	minCell := grid.getCell(queryBounds.MinX, queryBounds.MinZ)
+	maxCell := grid.getCell(queryBounds.MaxX, queryBounds.MaxZ)
+	for cellX := minCell.X; cellX <= maxCell.X; cellX++ {
+		for cellZ := minCell.Z; cellZ <= maxCell.Z; cellZ++ {
+			for _, id := range grid.cells[spatialCell{cellX, cellZ}] {
+				if intersects(grid.bounds[id]) {
+					grid.results = append(grid.results, id)
+				}
+			}
+		}
+	}
+
+	// Boundaries in more than one cell are found more than once
+	slices.Sort(grid.results)
+	grid.results = slices.Compact(grid.results)
+	return grid.results
+}
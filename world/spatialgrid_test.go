@@ -0,0 +1,90 @@
+package world
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func newTestSpatialGrid() *SpatialGrid {
+	grid := NewSpatialGrid(1000)
+	grid.Insert(0, NewSpatialBounds(0, 0, 500, 500))
+	grid.Insert(1, NewSpatialBounds(800, 800, 2500, 1200)) // Spans several cells
+	grid.Insert(2, NewSpatialBounds(-1500, -1500, -1000, -1000))
+	return grid
+}
+
+func TestSpatialGridQueryPoint(t *testing.T) {
+	grid := newTestSpatialGrid()
+
+	tests := []struct {
+		name     string
+		point    mgl32.Vec3
+		expected []int
+	}{
+		{"inside first box", mgl32.Vec3{250, 0, 250}, []int{0}},
+		{"inside box over several cells", mgl32.Vec3{2200, 0, 1000}, []int{1}},
+		{"negative coordinates", mgl32.Vec3{-1200, 0, -1200}, []int{2}},
+		{"same cell but outside the box", mgl32.Vec3{700, 0, 700}, []int{}},
+		{"empty cell", mgl32.Vec3{5000, 0, 5000}, []int{}},
+	}
+
+	for _, test := range tests {
+		if result := grid.QueryPoint(test.point); !slices.Equal(result, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, result)
+		}
+	}
+}
+
+func TestSpatialGridQueryCircle(t *testing.T) {
+	grid := newTestSpatialGrid()
+
+	if result := grid.QueryCircle(mgl32.Vec3{650, 0, 650}, 300); !slices.Equal(result, []int{0, 1}) {
+		t.Errorf("Expected both boxes near the circle, got %v", result)
+	}
+	// The corner of the first box is further than the radius even though it overlaps the box around the circle
+	if result := grid.QueryCircle(mgl32.Vec3{700, 0, 700}, 250); !slices.Equal(result, []int{1}) {
+		t.Errorf("Expected only the second box, got %v", result)
+	}
+}
+
+func TestSpatialGridQuerySegment(t *testing.T) {
+	grid := newTestSpatialGrid()
+
+	if result := grid.QuerySegment(mgl32.Vec3{-2000, 0, -2000}, mgl32.Vec3{3000, 0, 3000}); !slices.Equal(result, []int{0, 1, 2}) {
+		t.Errorf("Expected diagonal to cross every box, got %v", result)
+	}
+	if result := grid.QuerySegment(mgl32.Vec3{0, 0, 1000}, mgl32.Vec3{3000, 0, 1000}); !slices.Equal(result, []int{1}) {
+		t.Errorf("Expected horizontal segment to cross one box, got %v", result)
+	}
+	if result := grid.QuerySegment(mgl32.Vec3{600, 0, 0}, mgl32.Vec3{600, 0, 700}); !slices.Equal(result, []int{}) {
+		t.Errorf("Expected segment between the boxes to cross nothing, got %v", result)
+	}
+}
+
+func TestSpatialGridRemoveAndMove(t *testing.T) {
+	grid := newTestSpatialGrid()
+
+	grid.Remove(1)
+	if result := grid.QueryPoint(mgl32.Vec3{2200, 0, 1000}); len(result) != 0 {
+		t.Errorf("Expected removed box not to be found, got %v", result)
+	}
+	if grid.Len() != 2 {
+		t.Errorf("Expected 2 boxes, got %d", grid.Len())
+	}
+
+	// Inserting an id again moves it
+	grid.Insert(0, NewSpatialBounds(5000, 5000, 5500, 5500))
+	if result := grid.QueryPoint(mgl32.Vec3{250, 0, 250}); len(result) != 0 {
+		t.Errorf("Expected box to leave its old position, got %v", result)
+	}
+	if result := grid.QueryPoint(mgl32.Vec3{5250, 0, 5250}); !slices.Equal(result, []int{0}) {
+		t.Errorf("Expected box at its new position, got %v", result)
+	}
+
+	grid.Clear()
+	if grid.Len() != 0 {
+		t.Errorf("Expected empty grid, got %d boxes", grid.Len())
+	}
+}